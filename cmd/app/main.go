@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"tourism-backend/config"
 	"tourism-backend/internal/app"
@@ -14,6 +15,14 @@ func main() {
 		log.Fatalf("Config error: %s", err)
 	}
 
+	// Schema migrations: app migrate up | down [steps] | status | force <version>
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := app.Migrate(cfg, os.Args[2:]); err != nil {
+			log.Fatalf("Migrate error: %s", err)
+		}
+		return
+	}
+
 	// Run
 	app.Run(cfg)
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/joho/godotenv"
 	"log"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
@@ -11,10 +12,14 @@ import (
 type (
 	// Config -.
 	Config struct {
-		App  `yaml:"app"`
-		HTTP `yaml:"http"`
-		Log  `yaml:"logger"`
-		PG   `yaml:"postgres"`
+		App         `yaml:"app"`
+		HTTP        `yaml:"http"`
+		Log         `yaml:"logger"`
+		PG          `yaml:"postgres"`
+		Alerts      `yaml:"alerts"`
+		Media       `yaml:"media"`
+		Translation `yaml:"translation"`
+		Tracing     `yaml:"tracing"`
 		//RMQ  `yaml:"rabbitmq"`
 	}
 
@@ -27,6 +32,15 @@ type (
 	// HTTP -.
 	HTTP struct {
 		Port string `env-required:"true" yaml:"port" env:"HTTP_PORT"`
+		// RequestTimeout bounds a request, its database queries are cancelled when it passes.
+		RequestTimeout time.Duration `env-default:"15s" yaml:"request_timeout" env:"HTTP_REQUEST_TIMEOUT"`
+		// ShutdownTimeout bounds the draining of requests and queued payments on shutdown.
+		ShutdownTimeout time.Duration `env-default:"15s" yaml:"shutdown_timeout" env:"HTTP_SHUTDOWN_TIMEOUT"`
+		// DrainDelay is the time between failing readiness and closing the listener on shutdown,
+		// it should cover the readiness probe period so no new requests are routed to a stopping instance.
+		DrainDelay time.Duration `env-default:"5s" yaml:"drain_delay" env:"HTTP_DRAIN_DELAY"`
+		// ReadinessTimeout bounds each dependency check of the readiness probe.
+		ReadinessTimeout time.Duration `env-default:"2s" yaml:"readiness_timeout" env:"HTTP_READINESS_TIMEOUT"`
 	}
 
 	// Log -.
@@ -36,8 +50,115 @@ type (
 
 	// PG -.
 	PG struct {
-		//PoolMax int    `env-required:"true" yaml:"pool_max" env:"PG_POOL_MAX"`
-		URL string `env-required:"true"                 env:"PG_URL"`
+		URL string `env-required:"true" env:"PG_URL"`
+		// ReplicaURLs are read replicas, catalog reads are spread over them when set.
+		ReplicaURLs  []string `yaml:"replica_urls" env:"PG_REPLICA_URLS"`
+		PoolMax      int      `env-default:"10" yaml:"pool_max"       env:"PG_POOL_MAX"`
+		MaxIdleConns int      `env-default:"5"  yaml:"max_idle_conns" env:"PG_MAX_IDLE_CONNS"`
+		// ConnMaxLifetime recycles connections, so they are spread again after a failover.
+		ConnMaxLifetime time.Duration `env-default:"30m" yaml:"conn_max_lifetime" env:"PG_CONN_MAX_LIFETIME"`
+		// StatementTimeout makes the server cancel runaway statements, zero disables it.
+		StatementTimeout time.Duration `env-default:"30s" yaml:"statement_timeout" env:"PG_STATEMENT_TIMEOUT"`
+		// ConnAttempts is the number of tries to reach the database on startup.
+		ConnAttempts int `env-default:"10" yaml:"conn_attempts" env:"PG_CONN_ATTEMPTS"`
+		// SlowQueryThreshold is the duration above which queries are logged as slow, zero disables it.
+		SlowQueryThreshold time.Duration `env-default:"200ms" yaml:"slow_query_threshold" env:"PG_SLOW_QUERY_THRESHOLD"`
+	}
+
+	// Alerts -.
+	Alerts struct {
+		SavedSearchInterval time.Duration `env-required:"true" yaml:"saved_search_interval" env:"ALERTS_SAVED_SEARCH_INTERVAL"`
+	}
+
+	// Translation -.
+	Translation struct {
+		// Backend is google, fake or none. With none only the locales written by providers are served.
+		Backend        string   `env-default:"none"     yaml:"backend"         env:"TRANSLATION_BACKEND"`
+		APIKey         string   `                                             env:"TRANSLATION_API_KEY"`
+		SourceLanguage string   `env-default:"ru"       yaml:"source_language" env:"TRANSLATION_SOURCE_LANGUAGE"`
+		Languages      []string `env-default:"ru,kk,en" yaml:"languages"       env:"TRANSLATION_LANGUAGES"`
+		// FallbackLanguages are tried in order when a text is missing in the requested language.
+		FallbackLanguages []string      `env-default:"ru,en" yaml:"fallback_languages" env:"TRANSLATION_FALLBACK_LANGUAGES"`
+		Timeout           time.Duration `env-default:"10s"   yaml:"timeout"            env:"TRANSLATION_TIMEOUT"`
+	}
+
+	// Tracing -.
+	Tracing struct {
+		// Exporter is otlp, stdout or none.
+		Exporter string `env-default:"none" yaml:"exporter" env:"TRACING_EXPORTER"`
+		// Endpoint is the host:port of the OTLP/HTTP collector.
+		Endpoint string `env-default:"localhost:4318" yaml:"endpoint" env:"TRACING_ENDPOINT"`
+		Insecure bool   `env-default:"true"           yaml:"insecure" env:"TRACING_INSECURE"`
+		// SampleRatio is the share of new traces that are recorded.
+		SampleRatio float64 `env-default:"1" yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+	}
+
+	// Media -.
+	Media struct {
+		Backend  string `env-default:"local"     yaml:"backend"   env:"MEDIA_BACKEND"`
+		LocalDir string `env-default:"./uploads" yaml:"local_dir" env:"MEDIA_LOCAL_DIR"`
+		BaseURL  string `env-default:"/uploads"  yaml:"base_url"  env:"MEDIA_BASE_URL"`
+		S3       S3     `yaml:"s3"`
+		Uploads  `yaml:"uploads"`
+		Images   `yaml:"images"`
+		GC       `yaml:"gc"`
+		Tus      `yaml:"tus"`
+		Signing  `yaml:"signing"`
+	}
+
+	// Tus -.
+	// The chunks of unfinished uploads are kept in the media store and an upload is locked in the database
+	// while a chunk is written, so any instance can serve its requests. Dir only buffers the chunk being received.
+	Tus struct {
+		Dir          string        `env-default:"./uploads_partial" yaml:"dir"           env:"MEDIA_TUS_DIR"`
+		Expiration   time.Duration `env-default:"24h"               yaml:"expiration"    env:"MEDIA_TUS_EXPIRATION"`
+		ChunkTimeout time.Duration `env-default:"2m"                yaml:"chunk_timeout" env:"MEDIA_TUS_CHUNK_TIMEOUT"`
+	}
+
+	// GC -.
+	GC struct {
+		Interval    time.Duration `env-default:"6h"  yaml:"interval"     env:"MEDIA_GC_INTERVAL"`
+		GracePeriod time.Duration `env-default:"24h" yaml:"grace_period" env:"MEDIA_GC_GRACE_PERIOD"`
+	}
+
+	// Images -.
+	Images struct {
+		Workers        int           `env-default:"2"             yaml:"workers"         env:"IMAGES_WORKERS"`
+		SweepInterval  time.Duration `env-default:"1m"            yaml:"sweep_interval"  env:"IMAGES_SWEEP_INTERVAL"`
+		Widths         []int         `env-default:"640,1024,1600" yaml:"widths"          env:"IMAGES_WIDTHS"`
+		ThumbnailWidth int           `env-default:"320"           yaml:"thumbnail_width" env:"IMAGES_THUMBNAIL_WIDTH"`
+		JPEGQuality    int           `env-default:"82"            yaml:"jpeg_quality"    env:"IMAGES_JPEG_QUALITY"`
+		// MaxPixels bounds the width times height of the processed images, larger ones are marked failed.
+		MaxPixels int64 `env-default:"40000000" yaml:"max_pixels" env:"IMAGES_MAX_PIXELS"`
+	}
+
+	// Uploads -.
+	Uploads struct {
+		ImageMaxSize  int64 `env-default:"10485760"  yaml:"image_max_size"  env:"UPLOADS_IMAGE_MAX_SIZE"`
+		ImageMaxCount int   `env-default:"20"        yaml:"image_max_count" env:"UPLOADS_IMAGE_MAX_COUNT"`
+		VideoMaxSize  int64 `env-default:"104857600" yaml:"video_max_size"  env:"UPLOADS_VIDEO_MAX_SIZE"`
+		VideoMaxCount int   `env-default:"2"         yaml:"video_max_count" env:"UPLOADS_VIDEO_MAX_COUNT"`
+		// Documents are uploaded one per request.
+		DocumentMaxSize int64 `env-default:"20971520" yaml:"document_max_size" env:"UPLOADS_DOCUMENT_MAX_SIZE"`
+	}
+
+	// Signing -.
+	Signing struct {
+		// Secret keys the HMAC of signed media URLs. When empty a random secret is generated,
+		// so URLs stop working on restart and are not accepted by other instances.
+		Secret string        `                    env:"MEDIA_SIGNING_SECRET"`
+		TTL    time.Duration `env-default:"15m" yaml:"ttl" env:"MEDIA_SIGNING_TTL"`
+	}
+
+	// S3 -.
+	S3 struct {
+		Endpoint  string `yaml:"endpoint"   env:"MEDIA_S3_ENDPOINT"`
+		Region    string `yaml:"region"     env:"MEDIA_S3_REGION"`
+		Bucket    string `yaml:"bucket"     env:"MEDIA_S3_BUCKET"`
+		AccessKey string `                  env:"MEDIA_S3_ACCESS_KEY"`
+		SecretKey string `                  env:"MEDIA_S3_SECRET_KEY"`
+		PathStyle bool   `yaml:"path_style" env:"MEDIA_S3_PATH_STYLE"`
+		PublicURL string `yaml:"public_url" env:"MEDIA_S3_PUBLIC_URL"`
 	}
 
 	// RMQ -.
@@ -54,7 +175,7 @@ func NewConfig() (*Config, error) {
 
 	err := godotenv.Load()
 	if err != nil {
-		log.Fatalf("Error loading .env file", err)
+		log.Fatalf("Error loading .env file: %v", err)
 	}
 
 	err = cleanenv.ReadConfig("./config/config.yml", cfg)
@@ -2,70 +2,270 @@
 package app
 
 import (
+	"context"
+	"crypto/rand"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
+	"tourism-backend/pkg/alerts"
 	"tourism-backend/pkg/casbin"
+	"tourism-backend/pkg/health"
+	"tourism-backend/pkg/imageworker"
+	"tourism-backend/pkg/imaging"
+	"tourism-backend/pkg/media"
+	"tourism-backend/pkg/mediagc"
 	"tourism-backend/pkg/payment"
+	"tourism-backend/pkg/tus"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"tourism-backend/config"
 	v1 "tourism-backend/internal/controller/http/v1"
+	"tourism-backend/internal/entity"
 	"tourism-backend/internal/usecase"
 	"tourism-backend/internal/usecase/repo"
+	"tourism-backend/internal/usecase/webapi"
+	"tourism-backend/migrations"
 	"tourism-backend/pkg/httpserver"
 	"tourism-backend/pkg/logger"
 	"tourism-backend/pkg/postgres"
+	"tourism-backend/pkg/tracing"
 )
 
+const (
+	// _writeTimeoutMargin is the time left to write a response after the request deadline.
+	_writeTimeoutMargin = time.Second
+	// _traceFlushTimeout bounds the export of the spans still buffered on shutdown.
+	_traceFlushTimeout = 5 * time.Second
+)
+
+// catalogTables are read from the replicas: browsing tours is most of the load and tolerates lag.
+// Categories, their links to tours and the locales stay on the primary, their admin writes are
+// guarded by reads of the same tables that must not miss a change made just before.
+var catalogTables = []interface{}{
+	&entity.Tour{}, &entity.TourEvent{}, &entity.TourLocation{},
+	&entity.Image{}, &entity.ImageVariant{}, &entity.Video{},
+}
+
 // Run creates objects via constructors.
 func Run(cfg *config.Config) {
 	l := logger.New(cfg.Log.Level)
 
+	// Tracing
+	tracerProvider, err := tracing.New(context.Background(), tracing.Config{
+		Exporter:       cfg.Tracing.Exporter,
+		ServiceName:    cfg.App.Name,
+		ServiceVersion: cfg.App.Version,
+		Endpoint:       cfg.Tracing.Endpoint,
+		Insecure:       cfg.Tracing.Insecure,
+		SampleRatio:    cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - tracing.New: %w", err))
+	}
+
 	// Repository
-	pg, err := postgres.New(cfg.PG.URL)
+	pg, err := postgres.New(cfg.PG.URL,
+		postgres.Logger(l, cfg.PG.SlowQueryThreshold),
+		postgres.MaxPoolSize(cfg.PG.PoolMax),
+		postgres.MaxIdleConns(cfg.PG.MaxIdleConns),
+		postgres.ConnMaxLifetime(cfg.PG.ConnMaxLifetime),
+		postgres.StatementTimeout(cfg.PG.StatementTimeout),
+		postgres.ConnAttempts(cfg.PG.ConnAttempts),
+		postgres.Replicas(cfg.PG.ReplicaURLs, catalogTables...),
+		postgres.Metrics(prometheus.DefaultRegisterer),
+	)
 	if err != nil {
-		l.Fatal(fmt.Errorf("app - Run - postgres.NewTourismUseCase: %w", err))
+		l.Fatal(fmt.Errorf("app - Run - postgres.New: %w", err))
 	}
+	defer pg.Close()
 
-	err = pg.Connect(cfg)
+	err = checkSchema(cfg)
 	if err != nil {
-		l.Fatal(fmt.Errorf("app - Run - postgres.connect: %w", err))
+		l.Fatal(fmt.Errorf("app - Run - checkSchema: %w", err))
 	}
-	err = pg.Migrate()
+
+	// Media storage
+	mediaStore, err := newMediaStore(cfg)
 	if err != nil {
-		l.Fatal(fmt.Errorf("app - Run - postgres.Migrate: %w", err))
+		l.Fatal(fmt.Errorf("app - Run - newMediaStore: %w", err))
 	}
 
+	uploadValidator := media.NewValidator(
+		media.Limits{MaxSize: cfg.Media.Uploads.ImageMaxSize, MaxCount: cfg.Media.Uploads.ImageMaxCount},
+		media.Limits{MaxSize: cfg.Media.Uploads.VideoMaxSize, MaxCount: cfg.Media.Uploads.VideoMaxCount},
+		media.Limits{MaxSize: cfg.Media.Uploads.DocumentMaxSize, MaxCount: 1},
+	)
+
+	signingSecret := []byte(cfg.Media.Signing.Secret)
+	if len(signingSecret) == 0 {
+		l.Warn("app - Run - MEDIA_SIGNING_SECRET is not set, signed media URLs will not survive a restart")
+		signingSecret = make([]byte, 32)
+		if _, err := rand.Read(signingSecret); err != nil {
+			l.Fatal(fmt.Errorf("app - Run - rand.Read: %w", err))
+		}
+	}
+	mediaSigner := media.NewSigner(signingSecret, cfg.Media.BaseURL, cfg.Media.Signing.TTL)
+
 	// Use case
+	auditUseCase := usecase.NewAuditUseCase(
+		repo.NewAuditRepo(pg),
+	)
+	tourismRepo := repo.NewTourismRepo(pg, mediaStore)
+
+	translator, err := newTranslator(cfg)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - newTranslator: %w", err))
+	}
+	translationUseCase := usecase.NewTranslationUseCase(
+		repo.NewTranslationRepo(pg),
+		pg,
+		tourismRepo,
+		auditUseCase,
+		translator,
+		cfg.Translation.SourceLanguage,
+		cfg.Translation.Languages,
+		cfg.Translation.FallbackLanguages,
+	)
+
 	tourismUseCase := usecase.NewTourismUseCase(
-		repo.NewTourismRepo(pg),
+		tourismRepo,
+		pg,
+		auditUseCase,
+		uploadValidator,
+		translationUseCase,
 	)
 	userUseCase := usecase.NewUserUseCase(
 		repo.NewUserRepo(pg),
 	)
 	adminUseCase := usecase.NewAdminUseCase(
 		repo.NewAdminRepo(pg),
+		pg,
+		auditUseCase,
+	)
+
+	reviewUseCase := usecase.NewReviewUseCase(
+		repo.NewReviewRepo(pg, mediaStore),
+		pg,
+		tourismRepo,
+		auditUseCase,
+		uploadValidator,
 	)
 
-	service := usecase.NewService(userUseCase, tourismUseCase, adminUseCase)
+	wishlistUseCase := usecase.NewWishlistUseCase(
+		repo.NewWishlistRepo(pg, mediaStore),
+		tourismRepo,
+	)
+
+	itineraryUseCase := usecase.NewItineraryUseCase(
+		repo.NewItineraryRepo(pg),
+		pg,
+		tourismRepo,
+		auditUseCase,
+	)
+
+	imageUseCase := usecase.NewImageUseCase(
+		repo.NewImageRepo(pg, mediaStore),
+		imaging.Options{
+			Widths:         cfg.Media.Images.Widths,
+			ThumbnailWidth: cfg.Media.Images.ThumbnailWidth,
+			JPEGQuality:    cfg.Media.Images.JPEGQuality,
+			MaxPixels:      cfg.Media.Images.MaxPixels,
+		},
+	)
+
+	tourMediaRepo := repo.NewTourMediaRepo(pg, mediaStore)
+	tourMediaUseCase := usecase.NewTourMediaUseCase(
+		tourMediaRepo,
+		pg,
+		tourismRepo,
+		auditUseCase,
+		uploadValidator,
+	)
+
+	uploadParts, err := tus.NewPartStore(mediaStore, cfg.Media.Tus.Dir)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - tus.NewPartStore: %w", err))
+	}
+	uploadUseCase := usecase.NewUploadUseCase(
+		repo.NewUploadRepo(pg, mediaStore),
+		pg,
+		uploadParts,
+		tourismRepo,
+		tourMediaRepo,
+		auditUseCase,
+		uploadValidator,
+		cfg.Media.Tus.Expiration,
+	)
+
+	documentUseCase := usecase.NewDocumentUseCase(
+		repo.NewDocumentRepo(pg, mediaStore),
+		pg,
+		tourismRepo,
+		auditUseCase,
+		uploadValidator,
+		mediaSigner,
+	)
+
+	categoryUseCase := usecase.NewCategoryUseCase(
+		tourismRepo,
+		pg,
+		auditUseCase,
+		translationUseCase,
+	)
+
+	service := usecase.NewService(userUseCase, tourismUseCase, adminUseCase, reviewUseCase, wishlistUseCase, itineraryUseCase, tourMediaUseCase, uploadUseCase, documentUseCase, translationUseCase, categoryUseCase)
 
 	// HTTP Server
 	handler := gin.New()
-	handler.Static("/uploads", "./uploads")
+	// Media downloads: public objects of the local backend and signed URLs of private objects of any backend.
+	// Public S3 objects are fetched from the bucket directly.
+	mediaHandler := gin.WrapH(http.StripPrefix(cfg.Media.BaseURL, media.NewHandler(mediaStore, mediaSigner)))
+	handler.GET(cfg.Media.BaseURL+"/*key", mediaHandler)
+	handler.HEAD(cfg.Media.BaseURL+"/*key", mediaHandler)
 	handler.MaxMultipartMemory = 200 << 20
 
 	// Casbin
 	csbn := casbin.InitCasbin()
 
 	// Payment Processor
-	paymentProcessor := payment.NewPaymentProcessor(10, tourismUseCase)
+	paymentProcessor := payment.NewPaymentProcessor(10, tourismUseCase, l)
+
+	// Image variants
+	imageProcessor := imageworker.NewImageProcessor(100, cfg.Media.Images.Workers, cfg.Media.Images.SweepInterval, imageUseCase, l)
+	defer imageProcessor.Stop()
+
+	// Saved search alerts
+	savedSearchAlerter := alerts.NewSavedSearchAlerter(cfg.Alerts.SavedSearchInterval, wishlistUseCase, l)
+	defer savedSearchAlerter.Stop()
+
+	// Orphaned media files
+	orphanCollector := mediagc.NewOrphanCollector(cfg.Media.GC.Interval, cfg.Media.GC.GracePeriod, tourMediaUseCase, uploadUseCase, l)
+	defer orphanCollector.Stop()
+
+	// Readiness
+	latestSchema, err := postgres.LatestVersion(migrations.FS)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - postgres.LatestVersion: %w", err))
+	}
+	readiness := health.New(health.Timeout(cfg.HTTP.ReadinessTimeout))
+	readiness.Add("postgres", pg.Ping)
+	readiness.Add("schema", func(ctx context.Context) error { return pg.CheckSchema(ctx, latestSchema) })
+	readiness.Add("payment_worker", paymentProcessor.Check)
+	// RabbitMQ RPC is not configured, its server joins the checks with readiness.Add("rabbitmq", rmqServer.Check).
 
 	// New Router
-	v1.NewRouter(handler, l, service, csbn, paymentProcessor)
-	httpServer := httpserver.New(handler, httpserver.Port(cfg.HTTP.Port))
+	v1.NewRouter(handler, l, service, csbn, paymentProcessor, imageProcessor, readiness, cfg.Media.Tus.ChunkTimeout, cfg.HTTP.RequestTimeout)
+	httpServer := httpserver.New(handler,
+		httpserver.Port(cfg.HTTP.Port),
+		// Handlers give up at the request deadline, the server leaves them time to write the error.
+		httpserver.WriteTimeout(cfg.HTTP.RequestTimeout+_writeTimeoutMargin),
+		httpserver.ShutdownTimeout(cfg.HTTP.ShutdownTimeout),
+	)
 
 	// Waiting signal
 	interrupt := make(chan os.Signal, 1)
@@ -74,14 +274,69 @@ func Run(cfg *config.Config) {
 	select {
 	case s := <-interrupt:
 		l.Info("app - Run - signal: " + s.String())
+		// Fail readiness and keep serving until load balancers stopped routing new requests here.
+		readiness.Drain()
+		l.Info("app - Run - draining for " + cfg.HTTP.DrainDelay.String())
+		time.Sleep(cfg.HTTP.DrainDelay)
 	case err = <-httpServer.Notify():
 		l.Error(fmt.Errorf("app - Run - httpServer.Notify: %w", err))
+		readiness.Drain()
 	}
 
-	// Shutdown
+	// Shutdown: in-flight requests are finished before the listener closes.
 	err = httpServer.Shutdown()
 	if err != nil {
 		l.Error(fmt.Errorf("app - Run - httpServer.Shutdown: %w", err))
 	}
 
+	// Purchases accepted before the shutdown are still paid, those left when the shutdown timeout runs out are failed.
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	defer cancel()
+	err = paymentProcessor.Shutdown(ctx)
+	if err != nil {
+		l.Error(fmt.Errorf("app - Run - paymentProcessor.Shutdown: %w", err))
+	}
+
+	// Spans of the drained requests and payments are still buffered.
+	flushCtx, flushCancel := context.WithTimeout(context.Background(), _traceFlushTimeout)
+	defer flushCancel()
+	err = tracerProvider.Shutdown(flushCtx)
+	if err != nil {
+		l.Error(fmt.Errorf("app - Run - tracerProvider.Shutdown: %w", err))
+	}
+}
+
+func newMediaStore(cfg *config.Config) (media.Store, error) {
+	switch cfg.Media.Backend {
+	case "", "local":
+		return media.NewLocalStore(cfg.Media.LocalDir, cfg.Media.BaseURL), nil
+	case "s3":
+		return media.NewS3Store(media.S3Config{
+			Endpoint:  cfg.Media.S3.Endpoint,
+			Region:    cfg.Media.S3.Region,
+			Bucket:    cfg.Media.S3.Bucket,
+			AccessKey: cfg.Media.S3.AccessKey,
+			SecretKey: cfg.Media.S3.SecretKey,
+			PathStyle: cfg.Media.S3.PathStyle,
+			PublicURL: cfg.Media.S3.PublicURL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown media backend %q", cfg.Media.Backend)
+	}
+}
+
+func newTranslator(cfg *config.Config) (webapi.Translator, error) {
+	switch cfg.Translation.Backend {
+	case "", "none":
+		return nil, nil
+	case "fake":
+		return webapi.NewFakeTranslator(), nil
+	case "google":
+		if cfg.Translation.APIKey == "" {
+			return nil, fmt.Errorf("TRANSLATION_API_KEY is required for the google translation backend")
+		}
+		return webapi.NewGoogleTranslator(cfg.Translation.APIKey, cfg.Translation.Timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown translation backend %q", cfg.Translation.Backend)
+	}
 }
@@ -1,60 +1,70 @@
-//go:build migrate
-
 package app
 
 import (
-	"time"
+	"fmt"
+	"strconv"
 
-	// migrate tools
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"tourism-backend/config"
+	"tourism-backend/migrations"
+	"tourism-backend/pkg/logger"
+	"tourism-backend/pkg/postgres"
 )
 
-const (
-	_defaultAttempts = 50
-	_defaultTimeout  = time.Second
-)
+// Migrate runs the migrate subcommand: up, down [steps], status or force <version>.
+func Migrate(cfg *config.Config, args []string) error {
+	l := logger.New(cfg.Log.Level)
+	if len(args) == 0 {
+		return fmt.Errorf("usage: migrate up | down [steps] | status | force <version>")
+	}
+
+	m, err := postgres.NewMigrator(cfg.PG.URL, migrations.FS)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	switch args[0] {
+	case "up":
+		err = m.Up()
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return fmt.Errorf("migrate down: steps must be a positive number")
+			}
+		}
+		err = m.Down(steps)
+	case "force":
+		if len(args) < 2 {
+			return fmt.Errorf("migrate force: version is required")
+		}
+		version, convErr := strconv.Atoi(args[1])
+		if convErr != nil {
+			return fmt.Errorf("migrate force: %w", convErr)
+		}
+		err = m.Force(version)
+	case "status":
+	default:
+		return fmt.Errorf("migrate: unknown command %q", args[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	version, dirty, err := m.Version()
+	if err != nil {
+		return err
+	}
+	l.Info("migrate: schema version %d, latest %d, dirty %t", version, m.Latest(), dirty)
+	return nil
+}
 
-//
-//func init() {
-//	databaseURL, ok := os.LookupEnv("PG_URL")
-//	if !ok || len(databaseURL) == 0 {
-//		log.Fatalf("migrate: environment variable not declared: PG_URL")
-//	}
-//
-//	databaseURL += "?sslmode=disable"
-//
-//	var (
-//		//attempts = _defaultAttempts
-//		err error
-//		m   *migrate.Migrate
-//	)
-//
-//	//for attempts > 0 {
-//	//	m, err = migrate.New("file://migrations", databaseURL)
-//	//	if err == nil {
-//	//		break
-//	//	}
-//	//	log.Printf("migrate: unable to connect to postgres database: %s", databaseURL)
-//	//	log.Printf("Migrate: postgres is trying to connect, attempts left: %d", attempts)
-//	//	time.Sleep(_defaultTimeout)
-//	//	attempts--
-//	//}
-//
-//	if err != nil {
-//		log.Fatalf("Migrate: postgres connect error: %s", err)
-//	}
-//
-//	err = m.Up()
-//	defer m.Close()
-//	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
-//		log.Fatalf("Migrate: up error: %s", err)
-//	}
-//
-//	if errors.Is(err, migrate.ErrNoChange) {
-//		log.Printf("Migrate: no change")
-//		return
-//	}
-//
-//	log.Printf("Migrate: up success")
-//}
+// checkSchema refuses to start against a schema that is not at the latest migration.
+func checkSchema(cfg *config.Config) error {
+	m, err := postgres.NewMigrator(cfg.PG.URL, migrations.FS)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	return m.Check()
+}
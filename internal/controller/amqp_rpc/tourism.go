@@ -21,8 +21,8 @@ func newTourismRoutes(routes map[string]server.CallHandler, t usecase.TourismInt
 // }
 
 // func (r *tourismRoutes) getHistory() server.CallHandler {
-// 	return func(d *amqp.Delivery) (interface{}, error) {
-// 		tourisms, err := r.tourismUseCase.History(context.Background())
+// 	return func(ctx context.Context, d *amqp.Delivery) (interface{}, error) {
+// 		tourisms, err := r.tourismUseCase.History(ctx)
 // 		if err != nil {
 // 			return nil, fmt.Errorf("amqp_rpc - tourismRoutes - getHistory - r.tourismUseCase.History: %w", err)
 // 		}
@@ -4,6 +4,7 @@ import (
 	"github.com/casbin/casbin/v2"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"tourism-backend/internal/entity"
 	"tourism-backend/internal/usecase"
 	"tourism-backend/pkg/logger"
 	"tourism-backend/utils"
@@ -26,6 +27,8 @@ func newAdminRoutes(handler *gin.RouterGroup, t usecase.AdminInterface, l logger
 	h.Use(utils.JWTAuthMiddleware(), utils.CasbinMiddleware(csbn))
 	{
 		h.GET("/users", r.GetUsers)
+		h.GET("/audit", r.GetAuditEvents)
+		h.GET("/audit/verify", r.VerifyAuditChain)
 	}
 }
 
@@ -37,15 +40,69 @@ func newAdminRoutes(handler *gin.RouterGroup, t usecase.AdminInterface, l logger
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {array} entity.User
-// @Failure 500 {object} map[string]string
+// @Failure 500 {object} problem
 // @Router /admin/users [get]
 func (r *adminRoutes) GetUsers(c *gin.Context) {
-	users, err := r.t.GetUsers()
+	users, err := r.t.GetUsers(c.Request.Context(), utils.GetAuditActor(c))
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch users"})
+		errorProblem(c, r.l, err, "http - v1 - GetUsers")
 		return
 	}
 
 	c.JSON(http.StatusOK, &users)
 }
+
+// GetAuditEvents retrieves audit log entries.
+// @Summary Get audit log
+// @Description Fetches audit events, newest first, filtered by actor, action, resource, request ID and time range.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param actor_id query string false "Actor user ID"
+// @Param action query string false "Action, e.g. tour.create"
+// @Param resource query string false "Resource type, e.g. purchase"
+// @Param resource_id query string false "Resource ID"
+// @Param request_id query string false "Request ID"
+// @Param from query string false "From time (RFC3339)"
+// @Param to query string false "To time (RFC3339)"
+// @Param limit query int false "Page size (default 50, max 500)"
+// @Param offset query int false "Page offset"
+// @Success 200 {array} entity.AuditEvent
+// @Failure 400 {object} problem
+// @Failure 500 {object} problem
+// @Router /admin/audit [get]
+func (r *adminRoutes) GetAuditEvents(c *gin.Context) {
+	var filter entity.AuditEventFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		bindingProblem(c, err)
+		return
+	}
+
+	events, err := r.t.GetAuditEvents(c.Request.Context(), &filter)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - GetAuditEvents")
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// VerifyAuditChain checks the audit log hash chain for tampering.
+// @Summary Verify audit log integrity
+// @Description Recomputes the hash chain over all audit events and reports the first broken link.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} entity.AuditIntegrityReport
+// @Failure 500 {object} problem
+// @Router /admin/audit/verify [get]
+func (r *adminRoutes) VerifyAuditChain(c *gin.Context) {
+	report, err := r.t.VerifyAuditChain(c.Request.Context())
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - VerifyAuditChain")
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,146 @@
+package v1
+
+import (
+	"github.com/casbin/casbin/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase"
+	"tourism-backend/pkg/logger"
+	"tourism-backend/utils"
+)
+
+type categoryRoutes struct {
+	t usecase.CategoryInterface
+	l logger.Interface
+}
+
+func newCategoryRoutes(handler *gin.RouterGroup, t usecase.CategoryInterface, l logger.Interface, csbn *casbin.Enforcer) {
+	r := &categoryRoutes{t, l}
+
+	handler.GET("/tours/categories/tree", r.GetCategoryTree)
+
+	admin := handler.Group("/admin/categories")
+	admin.Use(utils.JWTAuthMiddleware(), utils.CasbinMiddleware(csbn))
+	{
+		admin.POST("", r.CreateCategory)
+		admin.PATCH("/:id", r.UpdateCategory)
+		admin.DELETE("/:id", r.DeleteCategory)
+	}
+}
+
+// GetCategoryTree returns the category hierarchy.
+// @Summary Get category tree
+// @Description Returns the top-level categories with nested children, ordered by sort_order and name. tour_count counts the tours of the category and all of its descendants. Names are given in the language requested by lang or negotiated from Accept-Language.
+// @Tags tours
+// @Produce json
+// @Param lang query string false "Content language, overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred content languages"
+// @Success 200 {array} entity.CategoryNode
+// @Failure 400 {object} problem
+// @Failure 500 {object} problem
+// @Router /tours/categories/tree [get]
+func (r *categoryRoutes) GetCategoryTree(c *gin.Context) {
+	supported := r.t.Languages()
+	lang, ok := negotiateLanguage(c, supported)
+	if !ok {
+		return
+	}
+	tree, err := r.t.GetCategoryTree(c.Request.Context(), lang)
+	if err != nil && !untranslated(c, r.l, err, supported, "http - v1 - GetCategoryTree") {
+		errorProblem(c, r.l, err, "http - v1 - GetCategoryTree")
+		return
+	}
+
+	c.JSON(http.StatusOK, tree)
+}
+
+// CreateCategory adds a category.
+// @Summary Create category
+// @Description Creates a category, nested under parent_id when it is set. The slug defaults to the name in latin letters.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param category body entity.CreateCategoryDTO true "Category"
+// @Success 201 {object} entity.Category
+// @Failure 400 {object} problem
+// @Failure 409 {object} problem
+// @Router /admin/categories [post]
+func (r *categoryRoutes) CreateCategory(c *gin.Context) {
+	var createCategoryDTO entity.CreateCategoryDTO
+	if err := c.ShouldBindJSON(&createCategoryDTO); err != nil {
+		bindingProblem(c, err)
+		return
+	}
+
+	category, err := r.t.CreateCategory(c.Request.Context(), utils.GetAuditActor(c), &createCategoryDTO)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - CreateCategory")
+		return
+	}
+
+	c.JSON(http.StatusCreated, category)
+}
+
+// UpdateCategory changes a category.
+// @Summary Update category
+// @Description Changes the fields that are set. parent_id moves the category with its subtree, an empty parent_id makes it a top-level category.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Category ID"
+// @Param category body entity.UpdateCategoryDTO true "Changed fields"
+// @Success 200 {object} entity.Category
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 409 {object} problem
+// @Router /admin/categories/{id} [patch]
+func (r *categoryRoutes) UpdateCategory(c *gin.Context) {
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "category")
+		return
+	}
+	var updateCategoryDTO entity.UpdateCategoryDTO
+	if err := c.ShouldBindJSON(&updateCategoryDTO); err != nil {
+		bindingProblem(c, err)
+		return
+	}
+
+	category, err := r.t.UpdateCategory(c.Request.Context(), utils.GetAuditActor(c), categoryID, &updateCategoryDTO)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - UpdateCategory")
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+// DeleteCategory removes a category.
+// @Summary Delete category
+// @Description Removes a category without subcategories, its tours lose the category.
+// @Tags admin
+// @Security BearerAuth
+// @Param id path string true "Category ID"
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 409 {object} problem
+// @Router /admin/categories/{id} [delete]
+func (r *categoryRoutes) DeleteCategory(c *gin.Context) {
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "category")
+		return
+	}
+
+	if err := r.t.DeleteCategory(c.Request.Context(), utils.GetAuditActor(c), categoryID); err != nil {
+		errorProblem(c, r.l, err, "http - v1 - DeleteCategory")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,177 @@
+package v1
+
+import (
+	"errors"
+	"github.com/casbin/casbin/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase"
+	"tourism-backend/pkg/logger"
+	"tourism-backend/utils"
+)
+
+const _maxDocumentRequestSize = 25 << 20 // 25MB
+
+type documentRoutes struct {
+	d usecase.DocumentInterface
+	l logger.Interface
+}
+
+func newDocumentRoutes(handler *gin.RouterGroup, d usecase.DocumentInterface, l logger.Interface, csbn *casbin.Enforcer) {
+	r := &documentRoutes{d, l}
+
+	provider := handler.Group("/tours/provider/documents")
+	provider.Use(utils.JWTAuthMiddleware(), utils.CasbinMiddleware(csbn))
+	{
+		provider.POST("", r.UploadDocument)
+		provider.GET("", r.GetDocuments)
+		provider.GET("/:documentId", r.GetDocument)
+		provider.DELETE("/:documentId", r.DeleteDocument)
+	}
+
+	admin := handler.Group("/admin/documents")
+	admin.Use(utils.JWTAuthMiddleware(), utils.CasbinMiddleware(csbn))
+	{
+		admin.GET("", r.ReviewDocuments)
+	}
+}
+
+// UploadDocument stores a private provider document.
+// @Summary Upload private document
+// @Description Uploads a verification document or an internal tour briefing (PDF, JPEG or PNG). Documents are private: the returned url is signed and expires, request the document again for a fresh one.
+// @Tags provider
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Document"
+// @Param kind formData string true "Document kind" Enums(verification, briefing)
+// @Param tour_id formData string false "Tour the document belongs to"
+// @Success 201 {object} entity.Document
+// @Failure 400 {object} problem
+// @Failure 403 {object} problem
+// @Failure 413 {object} problem
+// @Router /tours/provider/documents [post]
+func (r *documentRoutes) UploadDocument(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, _maxDocumentRequestSize)
+	file, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			errorResponse(c, http.StatusRequestEntityTooLarge, _codePayloadTooLarge, "File size too large")
+			return
+		}
+		errorResponse(c, http.StatusBadRequest, _codeInvalidRequest, "file is required", entity.FieldError{Field: "file", Code: "required", Message: "file is required"})
+		return
+	}
+	var createDocumentDTO entity.CreateDocumentDTO
+	if err := c.ShouldBind(&createDocumentDTO); err != nil {
+		bindingProblem(c, err)
+		return
+	}
+
+	document, err := r.d.UploadDocument(c.Request.Context(), utils.GetAuditActor(c), &createDocumentDTO, file)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - UploadDocument")
+		return
+	}
+
+	c.JSON(http.StatusCreated, document)
+}
+
+// GetDocuments lists the provider's documents.
+// @Summary Get private documents
+// @Description Lists the documents of the provider, newest first, with fresh signed download URLs.
+// @Tags provider
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} entity.Document
+// @Failure 500 {object} problem
+// @Router /tours/provider/documents [get]
+func (r *documentRoutes) GetDocuments(c *gin.Context) {
+	documents, err := r.d.GetDocuments(c.Request.Context(), utils.GetUserIDFromContext(c))
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - GetDocuments")
+		return
+	}
+
+	c.JSON(http.StatusOK, documents)
+}
+
+// GetDocument returns one of the provider's documents.
+// @Summary Get private document
+// @Description Returns the document with a fresh signed download URL.
+// @Tags provider
+// @Produce json
+// @Security BearerAuth
+// @Param documentId path string true "Document ID"
+// @Success 200 {object} entity.Document
+// @Failure 404 {object} problem
+// @Router /tours/provider/documents/{documentId} [get]
+func (r *documentRoutes) GetDocument(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("documentId"))
+	if err != nil {
+		invalidID(c, "document")
+		return
+	}
+
+	document, err := r.d.GetDocument(c.Request.Context(), utils.GetUserIDFromContext(c), id)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - GetDocument")
+		return
+	}
+
+	c.JSON(http.StatusOK, document)
+}
+
+// DeleteDocument removes one of the provider's documents.
+// @Summary Delete private document
+// @Description Deletes the document and its file. Signed URLs issued before stop working.
+// @Tags provider
+// @Security BearerAuth
+// @Param documentId path string true "Document ID"
+// @Success 204
+// @Failure 404 {object} problem
+// @Router /tours/provider/documents/{documentId} [delete]
+func (r *documentRoutes) DeleteDocument(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("documentId"))
+	if err != nil {
+		invalidID(c, "document")
+		return
+	}
+
+	if err := r.d.DeleteDocument(c.Request.Context(), utils.GetAuditActor(c), id); err != nil {
+		errorProblem(c, r.l, err, "http - v1 - DeleteDocument")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ReviewDocuments lists provider documents for admins.
+// @Summary Review provider documents
+// @Description Lists provider documents with signed download URLs, e.g. to check verification documents. Every call is recorded in the audit log.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param provider_id query string false "Provider user ID"
+// @Param kind query string false "Document kind" Enums(verification, briefing)
+// @Success 200 {array} entity.Document
+// @Failure 400 {object} problem
+// @Router /admin/documents [get]
+func (r *documentRoutes) ReviewDocuments(c *gin.Context) {
+	var filter entity.DocumentFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		bindingProblem(c, err)
+		return
+	}
+
+	documents, err := r.d.ReviewDocuments(c.Request.Context(), utils.GetAuditActor(c), &filter)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - ReviewDocuments")
+		return
+	}
+
+	c.JSON(http.StatusOK, documents)
+}
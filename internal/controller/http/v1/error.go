@@ -1,13 +1,184 @@
 package v1
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/logger"
+	"tourism-backend/pkg/media"
+
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// _problemContentType is the media type of RFC 7807 problem details.
+const _problemContentType = "application/problem+json"
+
+// Codes of the problems that are not raised by a domain error.
+const (
+	_codeInvalidRequest  = "invalid_request"
+	_codeInvalidID       = "invalid_id"
+	_codeInvalidUpload   = "invalid_upload"
+	_codePayloadTooLarge = "payload_too_large"
+	_codeUnavailable     = "service_unavailable"
+	_codeTimeout         = "timeout"
+	_codeInternal        = "internal_error"
 )
 
-type response struct {
-	Error string `json:"error" example:"message"`
+// problem is an RFC 7807 problem details object. Code is stable and meant for clients
+// to branch on, Detail is a human readable explanation.
+type problem struct {
+	Type     string              `json:"type" example:"about:blank"`
+	Title    string              `json:"title,omitempty" example:"Not Found"`
+	Status   int                 `json:"status" example:"404"`
+	Code     string              `json:"code" example:"tour_not_found"`
+	Detail   string              `json:"detail,omitempty" example:"tour not found"`
+	Instance string              `json:"instance,omitempty" example:"/v1/tours/4f72a1cb-6ed4-4f01-b38b-b605d3062236"`
+	Errors   []entity.FieldError `json:"errors,omitempty"`
+}
+
+// errorResponse aborts the request with a problem of the given status.
+func errorResponse(c *gin.Context, status int, code, detail string, fields ...entity.FieldError) {
+	c.Header("Content-Type", _problemContentType)
+	c.AbortWithStatusJSON(status, problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Code:     code,
+		Detail:   detail,
+		Instance: c.Request.URL.Path,
+		Errors:   fields,
+	})
+}
+
+// errorProblem maps err to a problem. Domain errors keep their code and message, anything
+// else is logged under op and answered with a bare 500 so internal details do not leak.
+func errorProblem(c *gin.Context, l logger.Interface, err error, op string) {
+	l = l.WithContext(c.Request.Context())
+	var (
+		validationErr   *entity.ValidationError
+		uploadErr       *media.ValidationError
+		notFoundErr     *entity.NotFoundError
+		conflictErr     *entity.ConflictError
+		forbiddenErr    *entity.ForbiddenError
+		unauthorizedErr *entity.UnauthorizedError
+		soldOutErr      *entity.SoldOutError
+	)
+	switch {
+	case errors.As(err, &validationErr):
+		errorResponse(c, http.StatusBadRequest, validationErr.Code, validationErr.Message, validationErr.Fields...)
+	case errors.As(err, &uploadErr):
+		fields := make([]entity.FieldError, 0, len(uploadErr.Files))
+		for _, file := range uploadErr.Files {
+			fields = append(fields, entity.FieldError{Field: file.Field, Code: file.Code, Message: file.Message})
+		}
+		errorResponse(c, http.StatusBadRequest, _codeInvalidUpload, uploadErr.Error(), fields...)
+	case errors.As(err, &notFoundErr):
+		errorResponse(c, http.StatusNotFound, notFoundErr.Code, notFoundErr.Message)
+	case errors.As(err, &conflictErr):
+		errorResponse(c, http.StatusConflict, conflictErr.Code, conflictErr.Message)
+	case errors.As(err, &soldOutErr):
+		errorResponse(c, http.StatusConflict, soldOutErr.Code, soldOutErr.Message)
+	case errors.As(err, &forbiddenErr):
+		errorResponse(c, http.StatusForbidden, forbiddenErr.Code, forbiddenErr.Message)
+	case errors.As(err, &unauthorizedErr):
+		errorResponse(c, http.StatusUnauthorized, unauthorizedErr.Code, unauthorizedErr.Message)
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		l.Warn(fmt.Sprintf("%s: %v", op, err))
+		errorResponse(c, http.StatusServiceUnavailable, _codeTimeout, "the request could not be completed in time")
+	default:
+		l.Error(err, op)
+		span := trace.SpanFromContext(c.Request.Context())
+		span.RecordError(err)
+		span.SetStatus(codes.Error, op)
+		errorResponse(c, http.StatusInternalServerError, _codeInternal, "internal server error")
+	}
+}
+
+// bindingProblem answers a request whose body or query could not be bound with a validation
+// problem listing the rejected fields.
+func bindingProblem(c *gin.Context, err error) {
+	var (
+		validationErrs validator.ValidationErrors
+		typeErr        *json.UnmarshalTypeError
+		syntaxErr      *json.SyntaxError
+	)
+	switch {
+	case errors.As(err, &validationErrs):
+		fields := make([]entity.FieldError, 0, len(validationErrs))
+		for _, fieldErr := range validationErrs {
+			fields = append(fields, entity.FieldError{
+				Field:   fieldErr.Field(),
+				Code:    fieldErr.Tag(),
+				Message: fieldMessage(fieldErr),
+			})
+		}
+		errorResponse(c, http.StatusBadRequest, _codeInvalidRequest, "request validation failed", fields...)
+	case errors.As(err, &typeErr):
+		errorResponse(c, http.StatusBadRequest, _codeInvalidRequest, "request validation failed", entity.FieldError{
+			Field:   typeErr.Field,
+			Code:    "type",
+			Message: fmt.Sprintf("%s must be a %s", typeErr.Field, typeErr.Type),
+		})
+	case errors.As(err, &syntaxErr):
+		errorResponse(c, http.StatusBadRequest, _codeInvalidRequest, "request body is not valid JSON")
+	default:
+		errorResponse(c, http.StatusBadRequest, _codeInvalidRequest, "malformed request")
+	}
+}
+
+// _ruleMessages explain the rules whose tag alone would not tell a client what to send,
+// %s is replaced by the parameter of the rule.
+var _ruleMessages = map[string]string{
+	"required": "is required",
+	"future":   "must be in the future",
+	"money":    "must be a positive amount with at most two decimal places",
+	"whole":    "must be a whole number",
+	"email":    "must be a valid email address",
+	"uuid":     "must be a UUID",
+	"oneof":    "must be one of: %s",
+	"min":      "must be at least %s",
+	"max":      "must be at most %s",
+	"len":      "must be exactly %s",
+}
+
+// fieldMessage explains why a field was rejected. Limits of strings and lists apply to their length.
+func fieldMessage(fieldErr validator.FieldError) string {
+	message, ok := _ruleMessages[fieldErr.Tag()]
+	if !ok {
+		return fmt.Sprintf("%s failed on the %s rule", fieldErr.Field(), fieldErr.Tag())
+	}
+	if strings.Contains(message, "%s") {
+		message = fmt.Sprintf(message, fieldErr.Param())
+	}
+	switch fieldErr.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		if tag := fieldErr.Tag(); tag == "min" || tag == "max" || tag == "len" {
+			return fieldErr.Field() + " length " + message
+		}
+	}
+	return fieldErr.Field() + " " + message
+}
+
+// invalidID answers a request whose path carries a malformed UUID.
+func invalidID(c *gin.Context, name string) {
+	errorResponse(c, http.StatusBadRequest, _codeInvalidID, "Invalid "+name+" ID")
 }
 
-func errorResponse(c *gin.Context, code int, msg string) {
-	c.AbortWithStatusJSON(code, response{msg})
+// problems renders the errors that middlewares attached with c.Error as problems,
+// unless a response was already written.
+func problems(l logger.Interface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if err := c.Errors.Last(); err != nil && !c.Writer.Written() {
+			errorProblem(c, l, err.Err, "http - v1 - "+c.FullPath())
+		}
+	}
 }
@@ -0,0 +1,31 @@
+package v1
+
+import (
+	"net/http"
+	"tourism-backend/pkg/health"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// _livenessPath answers as long as the process serves requests.
+	_livenessPath = "/healthz"
+	// _readinessPath answers whether the dependencies are usable and the service is not shutting down.
+	_readinessPath = "/readyz"
+)
+
+// newHealthRoutes registers the probes. Liveness does not look at dependencies, so an outage of
+// the database makes instances unready instead of having them restarted.
+func newHealthRoutes(handler *gin.Engine, readiness *health.Readiness) {
+	handler.GET(_livenessPath, func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": health.StatusOK})
+	})
+	handler.GET(_readinessPath, func(c *gin.Context) {
+		report := readiness.Check(c.Request.Context())
+		status := http.StatusOK
+		if !report.Ready() {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, report)
+	})
+}
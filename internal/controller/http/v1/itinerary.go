@@ -0,0 +1,178 @@
+package v1
+
+import (
+	"bytes"
+	"github.com/casbin/casbin/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase"
+	"tourism-backend/pkg/logger"
+	"tourism-backend/utils"
+)
+
+const _maxRouteFileSize = 10 << 20 // 10MB
+
+type itineraryRoutes struct {
+	t usecase.ItineraryInterface
+	l logger.Interface
+}
+
+func newItineraryRoutes(handler *gin.RouterGroup, t usecase.ItineraryInterface, l logger.Interface, csbn *casbin.Enforcer) {
+	r := &itineraryRoutes{t, l}
+
+	h := handler.Group("/tours")
+	{
+		h.GET("/:id/itinerary", r.GetItinerary)
+		h.GET("/:id/itinerary/export", r.ExportItinerary)
+
+		provider := h.Group("/provider")
+		provider.Use(utils.JWTAuthMiddleware(), utils.CasbinMiddleware(csbn))
+		{
+			provider.PUT("/:id/itinerary", r.UpdateItinerary)
+			provider.POST("/:id/itinerary/import", r.ImportItinerary)
+		}
+	}
+}
+
+// GetItinerary retrieves the itinerary of a tour.
+// @Summary Get tour itinerary
+// @Description Fetches ordered stops, the track polyline as [lon, lat, ele] positions, total distance and elevation gain.
+// @Tags itinerary
+// @Produce json
+// @Param id path string true "Tour ID"
+// @Success 200 {object} entity.Itinerary
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Router /tours/{id}/itinerary [get]
+func (r *itineraryRoutes) GetItinerary(c *gin.Context) {
+	tourID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "tour")
+		return
+	}
+
+	itinerary, err := r.t.GetItinerary(c.Request.Context(), tourID)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - GetItinerary")
+		return
+	}
+
+	c.JSON(http.StatusOK, itinerary)
+}
+
+// ExportItinerary downloads the itinerary of a tour.
+// @Summary Export tour itinerary
+// @Description Downloads the itinerary as GPX or as a GeoJSON FeatureCollection.
+// @Tags itinerary
+// @Produce xml,json
+// @Param id path string true "Tour ID"
+// @Param format query string false "gpx (default) or geojson"
+// @Success 200 {file} file
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Router /tours/{id}/itinerary/export [get]
+func (r *itineraryRoutes) ExportItinerary(c *gin.Context) {
+	tourID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "tour")
+		return
+	}
+
+	switch c.DefaultQuery("format", entity.ItineraryFormatGPX) {
+	case entity.ItineraryFormatGeoJSON:
+		collection, err := r.t.ExportItineraryGeoJSON(c.Request.Context(), tourID)
+		if err != nil {
+			errorProblem(c, r.l, err, "http - v1 - ExportItinerary")
+			return
+		}
+		c.Header("Content-Disposition", `attachment; filename="`+tourID.String()+`.geojson"`)
+		c.Header("Content-Type", "application/geo+json")
+		c.JSON(http.StatusOK, collection)
+	case entity.ItineraryFormatGPX:
+		// Render into a buffer first, so a failure can still produce a JSON error.
+		var buf bytes.Buffer
+		if err := r.t.ExportItineraryGPX(c.Request.Context(), tourID, &buf); err != nil {
+			errorProblem(c, r.l, err, "http - v1 - ExportItinerary")
+			return
+		}
+		c.Header("Content-Disposition", `attachment; filename="`+tourID.String()+`.gpx"`)
+		c.Data(http.StatusOK, "application/gpx+xml", buf.Bytes())
+	default:
+		errorResponse(c, http.StatusBadRequest, _codeInvalidRequest, "format must be gpx or geojson",
+			entity.FieldError{Field: "format", Code: "oneof", Message: "format must be gpx or geojson"})
+	}
+}
+
+// UpdateItinerary replaces the itinerary of a tour.
+// @Summary Update tour itinerary
+// @Description Replaces stops and track of the tour. Distance and elevation gain are computed by the server.
+// @Tags provider
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tour ID"
+// @Param itinerary body entity.UpdateItineraryDTO true "Itinerary"
+// @Success 200 {object} entity.Itinerary
+// @Failure 400 {object} problem
+// @Failure 403 {object} problem
+// @Router /tours/provider/{id}/itinerary [put]
+func (r *itineraryRoutes) UpdateItinerary(c *gin.Context) {
+	tourID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "tour")
+		return
+	}
+
+	var updateItineraryDTO entity.UpdateItineraryDTO
+	if err := c.ShouldBindJSON(&updateItineraryDTO); err != nil {
+		bindingProblem(c, err)
+		return
+	}
+
+	itinerary, err := r.t.UpdateItinerary(c.Request.Context(), utils.GetAuditActor(c), tourID, &updateItineraryDTO)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - UpdateItinerary")
+		return
+	}
+
+	c.JSON(http.StatusOK, itinerary)
+}
+
+// ImportItinerary replaces the itinerary of a tour from a GPX or KML file.
+// @Summary Import tour itinerary
+// @Description Replaces stops and track of the tour with the waypoints and track of an uploaded GPX or KML file.
+// @Tags provider
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tour ID"
+// @Param file formData file true "GPX or KML file"
+// @Success 200 {object} entity.Itinerary
+// @Failure 400 {object} problem
+// @Failure 403 {object} problem
+// @Router /tours/provider/{id}/itinerary/import [post]
+func (r *itineraryRoutes) ImportItinerary(c *gin.Context) {
+	tourID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "tour")
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, _maxRouteFileSize)
+	file, err := c.FormFile("file")
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, _codeInvalidRequest, "A GPX or KML file up to 10MB is required",
+			entity.FieldError{Field: "file", Code: "required", Message: "A GPX or KML file up to 10MB is required"})
+		return
+	}
+
+	itinerary, err := r.t.ImportItinerary(c.Request.Context(), utils.GetAuditActor(c), tourID, file)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - ImportItinerary")
+		return
+	}
+
+	c.JSON(http.StatusOK, itinerary)
+}
@@ -0,0 +1,78 @@
+package v1
+
+import (
+	"net/http"
+	"time"
+	"tourism-backend/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// _serverName names the server in the spans of requests.
+	_serverName = "tourism-backend"
+	// _headerRequestID carries the ID that ties the log entries and audit records of a request together.
+	_headerRequestID = "X-Request-ID"
+	// _maxRequestIDLength bounds the request IDs accepted from clients and proxies.
+	_maxRequestIDLength = 128
+)
+
+// requestID takes the request ID from X-Request-ID or generates one, echoes it in the response
+// and adds it to the request context along with the route, so log entries carry both.
+func requestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(_headerRequestID)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+		c.Header(_headerRequestID, id)
+
+		trace.SpanFromContext(c.Request.Context()).SetAttributes(attribute.String("http.request_id", id))
+		ctx := logger.WithRequestID(c.Request.Context(), id)
+		if route := c.FullPath(); route != "" {
+			ctx = logger.WithRoute(ctx, route)
+		}
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// validRequestID accepts IDs of printable ASCII characters that are not too long to log.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > _maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// accessLog logs every request once it is served. Server errors are logged as warnings,
+// the handler has logged their cause already.
+func accessLog(l logger.Interface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		entry := l.WithContext(c.Request.Context()).With(
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"status", status,
+			"latency_ms", time.Since(start).Milliseconds(),
+			"client_ip", c.ClientIP(),
+			"size", c.Writer.Size(),
+		)
+		if status >= http.StatusInternalServerError {
+			entry.Warn("http request")
+			return
+		}
+		entry.Info("http request")
+	}
+}
@@ -0,0 +1,222 @@
+package v1
+
+import (
+	"github.com/casbin/casbin/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"mime/multipart"
+	"net/http"
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase"
+	"tourism-backend/pkg/logger"
+	"tourism-backend/utils"
+)
+
+type reviewRoutes struct {
+	t usecase.ReviewInterface
+	l logger.Interface
+}
+
+func newReviewRoutes(handler *gin.RouterGroup, t usecase.ReviewInterface, l logger.Interface, csbn *casbin.Enforcer) {
+	r := &reviewRoutes{t, l}
+
+	h := handler.Group("/tours")
+	{
+		h.GET("/:id/reviews", r.GetTourReviews)
+		h.POST("/:id/reviews", utils.JWTAuthMiddleware(), r.CreateReview)
+
+		provider := h.Group("/provider")
+		provider.Use(utils.JWTAuthMiddleware(), utils.CasbinMiddleware(csbn))
+		{
+			provider.POST("/reviews/:id/reply", r.ReplyToReview)
+		}
+	}
+
+	admin := handler.Group("/admin")
+	admin.Use(utils.JWTAuthMiddleware(), utils.CasbinMiddleware(csbn))
+	{
+		admin.PATCH("/reviews/:id", r.ModerateReview)
+		admin.DELETE("/reviews/:id", r.DeleteReview)
+	}
+}
+
+// GetTourReviews retrieves published reviews of a tour.
+// @Summary Get tour reviews
+// @Description Fetches published reviews of a tour with provider replies and photos.
+// @Tags reviews
+// @Produce json
+// @Param id path string true "Tour ID"
+// @Param sort query string false "Sort order: newest (default), oldest, rating_desc, rating_asc"
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param offset query int false "Page offset"
+// @Success 200 {array} entity.Review
+// @Failure 400 {object} problem
+// @Failure 500 {object} problem
+// @Router /tours/{id}/reviews [get]
+func (r *reviewRoutes) GetTourReviews(c *gin.Context) {
+	tourID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "tour")
+		return
+	}
+
+	var filter entity.ReviewFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		bindingProblem(c, err)
+		return
+	}
+
+	reviews, err := r.t.GetTourReviews(c.Request.Context(), tourID, &filter)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - GetTourReviews")
+		return
+	}
+
+	c.JSON(http.StatusOK, reviews)
+}
+
+// CreateReview leaves a review for a tour.
+// @Summary Review a tour
+// @Description Creates a 1-5 review. Only users with a paid purchase of an event of this tour that has already taken place can review it.
+// @Tags reviews
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tour ID"
+// @Param rating formData int true "Rating from 1 to 5"
+// @Param text formData string false "Review text"
+// @Param photos formData file false "Review photos (multiple allowed)"
+// @Success 201 {object} entity.Review
+// @Failure 400 {object} problem
+// @Failure 403 {object} problem
+// @Failure 409 {object} problem
+// @Failure 500 {object} problem
+// @Router /tours/{id}/reviews [post]
+func (r *reviewRoutes) CreateReview(c *gin.Context) {
+	tourID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "tour")
+		return
+	}
+
+	var createReviewDTO entity.CreateReviewDTO
+	if err := c.ShouldBind(&createReviewDTO); err != nil {
+		bindingProblem(c, err)
+		return
+	}
+
+	var photoFiles []*multipart.FileHeader
+	if form, err := c.MultipartForm(); err == nil {
+		photoFiles = form.File["photos"]
+	}
+	review := &entity.Review{
+		TourID: tourID,
+		UserID: utils.GetUserIDFromContext(c),
+		Rating: createReviewDTO.Rating,
+		Text:   createReviewDTO.Text,
+	}
+
+	createdReview, err := r.t.CreateReview(c.Request.Context(), review, photoFiles)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - CreateReview")
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdReview)
+}
+
+// ReplyToReview posts the provider's public reply to a review.
+// @Summary Reply to a review
+// @Description Adds or replaces the tour owner's public reply to a review.
+// @Tags provider
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Review ID"
+// @Param reply body entity.ReplyReviewDTO true "Reply"
+// @Success 200 {object} entity.Review
+// @Failure 400 {object} problem
+// @Failure 403 {object} problem
+// @Failure 404 {object} problem
+// @Router /tours/provider/reviews/{id}/reply [post]
+func (r *reviewRoutes) ReplyToReview(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "review")
+		return
+	}
+
+	var replyReviewDTO entity.ReplyReviewDTO
+	if err := c.ShouldBindJSON(&replyReviewDTO); err != nil {
+		bindingProblem(c, err)
+		return
+	}
+
+	review, err := r.t.ReplyToReview(c.Request.Context(), utils.GetUserIDFromContext(c), reviewID, replyReviewDTO.Reply)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - ReplyToReview")
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// ModerateReview publishes or hides a review.
+// @Summary Moderate a review
+// @Description Changes review status to published or hidden. Hidden reviews are excluded from tour ratings.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Review ID"
+// @Param status body entity.ModerateReviewDTO true "New status"
+// @Success 200 {object} entity.Review
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Router /admin/reviews/{id} [patch]
+func (r *reviewRoutes) ModerateReview(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "review")
+		return
+	}
+
+	var moderateReviewDTO entity.ModerateReviewDTO
+	if err := c.ShouldBindJSON(&moderateReviewDTO); err != nil {
+		bindingProblem(c, err)
+		return
+	}
+
+	review, err := r.t.ModerateReview(c.Request.Context(), utils.GetAuditActor(c), reviewID, moderateReviewDTO.Status)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - ModerateReview")
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// DeleteReview removes a review.
+// @Summary Delete a review
+// @Description Removes a review with its photos. A published review no longer counts in the tour rating.
+// @Tags admin
+// @Security BearerAuth
+// @Param id path string true "Review ID"
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Router /admin/reviews/{id} [delete]
+func (r *reviewRoutes) DeleteReview(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "review")
+		return
+	}
+
+	if err := r.t.DeleteReview(c.Request.Context(), utils.GetAuditActor(c), reviewID); err != nil {
+		errorProblem(c, r.l, err, "http - v1 - DeleteReview")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
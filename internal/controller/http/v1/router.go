@@ -2,14 +2,21 @@
 package v1
 
 import (
+	"context"
+	"fmt"
 	"github.com/casbin/casbin/v2"
 	"net/http"
+	"time"
+	"tourism-backend/pkg/health"
+	"tourism-backend/pkg/imageworker"
 	"tourism-backend/pkg/payment"
+	"tourism-backend/pkg/tus"
 
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
 	// Swagger docs.
 	_ "tourism-backend/docs"
@@ -24,26 +31,65 @@ import (
 // @version     1.0
 // @host        localhost:8080
 // @BasePath    /v1
-func NewRouter(handler *gin.Engine, l logger.Interface, service *usecase.Service, csbn *casbin.Enforcer, paymentProcessor *payment.PaymentProcessor) {
+func NewRouter(handler *gin.Engine, l logger.Interface, service *usecase.Service, csbn *casbin.Enforcer, paymentProcessor *payment.PaymentProcessor, imageProcessor *imageworker.ImageProcessor, readiness *health.Readiness, uploadChunkTimeout, requestTimeout time.Duration) {
 	// Options
-	handler.Use(gin.Logger())
+	handler.Use(otelgin.Middleware(_serverName, otelgin.WithFilter(traced)), requestID(), accessLog(l), instrument())
 	handler.Use(gin.Recovery())
 
 	// Swagger
 	swaggerHandler := ginSwagger.DisablingWrapHandler(swaggerFiles.Handler, "DISABLE_SWAGGER_HTTP_HANDLER")
 	handler.GET("/swagger/*any", swaggerHandler)
 
-	// K8s probe
-	handler.GET("/healthz", func(c *gin.Context) { c.Status(http.StatusOK) })
+	// K8s probes
+	newHealthRoutes(handler, readiness)
 
 	// Prometheus metrics
 	handler.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
+	// Request validation
+	if err := registerValidators(); err != nil {
+		l.Fatal(fmt.Errorf("http - v1 - NewRouter: %w", err))
+	}
+
 	// Routers
 	h := handler.Group("/v1")
+	h.Use(problems(l), deadline(requestTimeout))
 	{
-		newTourismRoutes(h, service.TourUseCase, l, csbn, paymentProcessor)
+		newTourismRoutes(h, service.TourUseCase, l, csbn, paymentProcessor, imageProcessor)
 		newUserRoutes(h, service.UserUseCase, l)
 		newAdminRoutes(h, service.AdminUseCase, l, csbn)
+		newReviewRoutes(h, service.ReviewUseCase, l, csbn)
+		newWishlistRoutes(h, service.WishlistUseCase, l)
+		newItineraryRoutes(h, service.ItineraryUseCase, l, csbn)
+		newTourMediaRoutes(h, service.TourMediaUseCase, l, csbn, imageProcessor)
+		newUploadRoutes(h, service.UploadUseCase, l, csbn, uploadChunkTimeout)
+		newDocumentRoutes(h, service.DocumentUseCase, l, csbn)
+		newTranslationRoutes(h, service.TranslationUseCase, l, csbn)
+		newCategoryRoutes(h, service.CategoryUseCase, l, csbn)
+	}
+}
+
+// traced leaves probes and metric scrapes out of the traces and request metrics.
+func traced(r *http.Request) bool {
+	switch r.URL.Path {
+	case _livenessPath, _readinessPath, "/metrics":
+		return false
+	default:
+		return true
+	}
+}
+
+// deadline bounds the time a request may take, the database queries it started are cancelled once
+// the deadline passes or the client goes away. Chunks of resumable uploads get their own deadline.
+func deadline(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodPatch && c.GetHeader(tus.HeaderResumable) != "" {
+			c.Next()
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
 	}
 }
@@ -0,0 +1,366 @@
+package v1
+
+import (
+	"github.com/casbin/casbin/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"mime/multipart"
+	"net/http"
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase"
+	"tourism-backend/pkg/imageworker"
+	"tourism-backend/pkg/logger"
+	"tourism-backend/utils"
+)
+
+const _maxMediaRequestSize = 200 << 20 // 200MB
+
+type tourMediaRoutes struct {
+	t usecase.TourMediaInterface
+	l logger.Interface
+	i *imageworker.ImageProcessor
+}
+
+func newTourMediaRoutes(handler *gin.RouterGroup, t usecase.TourMediaInterface, l logger.Interface, csbn *casbin.Enforcer, images *imageworker.ImageProcessor) {
+	r := &tourMediaRoutes{t, l, images}
+
+	provider := handler.Group("/tours/provider")
+	provider.Use(utils.JWTAuthMiddleware(), utils.CasbinMiddleware(csbn))
+	{
+		provider.POST("/:id/images", r.AddTourImages)
+		provider.PUT("/:id/images/order", r.ReorderTourImages)
+		provider.PUT("/:id/images/:mediaId/cover", r.SetCoverImage)
+		provider.PATCH("/:id/images/:mediaId", r.UpdateTourImage)
+		provider.DELETE("/:id/images/:mediaId", r.DeleteTourImage)
+
+		provider.POST("/:id/videos", r.AddTourVideos)
+		provider.PUT("/:id/videos/order", r.ReorderTourVideos)
+		provider.PATCH("/:id/videos/:mediaId", r.UpdateTourVideo)
+		provider.DELETE("/:id/videos/:mediaId", r.DeleteTourVideo)
+	}
+}
+
+// AddTourImages uploads images to an existing tour.
+// @Summary Add tour images
+// @Description Appends images after the existing ones. alt_text and caption values apply to the files in the same order. Thumbnails are generated in the background.
+// @Tags provider
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tour ID"
+// @Param images formData file true "Tour images (multiple allowed)"
+// @Param alt_text formData []string false "Alt text per image" collectionFormat(multi)
+// @Param caption formData []string false "Caption per image" collectionFormat(multi)
+// @Success 201 {array} entity.ImageDocs
+// @Failure 400 {object} problem
+// @Failure 403 {object} problem
+// @Router /tours/provider/{id}/images [post]
+func (r *tourMediaRoutes) AddTourImages(c *gin.Context) {
+	tourID, files, meta, ok := r.bindUpload(c, "images")
+	if !ok {
+		return
+	}
+
+	images, err := r.t.AddTourImages(c.Request.Context(), utils.GetAuditActor(c), tourID, files, meta)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - AddTourImages")
+		return
+	}
+
+	// Thumbnails and responsive sizes are built in the background
+	for _, image := range images {
+		r.i.Enqueue(image.ID)
+	}
+	c.JSON(http.StatusCreated, images)
+}
+
+// AddTourVideos uploads videos to an existing tour.
+// @Summary Add tour videos
+// @Description Appends videos after the existing ones. alt_text and caption values apply to the files in the same order.
+// @Tags provider
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tour ID"
+// @Param videos formData file true "Tour videos (multiple allowed)"
+// @Param alt_text formData []string false "Alt text per video" collectionFormat(multi)
+// @Param caption formData []string false "Caption per video" collectionFormat(multi)
+// @Success 201 {array} entity.VideoDocs
+// @Failure 400 {object} problem
+// @Failure 403 {object} problem
+// @Router /tours/provider/{id}/videos [post]
+func (r *tourMediaRoutes) AddTourVideos(c *gin.Context) {
+	tourID, files, meta, ok := r.bindUpload(c, "videos")
+	if !ok {
+		return
+	}
+
+	videos, err := r.t.AddTourVideos(c.Request.Context(), utils.GetAuditActor(c), tourID, files, meta)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - AddTourVideos")
+		return
+	}
+
+	c.JSON(http.StatusCreated, videos)
+}
+
+// ReorderTourImages sets the display order of the tour images.
+// @Summary Reorder tour images
+// @Description Sets the order of the tour images. ids must list every image of the tour exactly once.
+// @Tags provider
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tour ID"
+// @Param order body entity.ReorderMediaDTO true "Image IDs in display order"
+// @Success 200 {object} entity.TourDocs
+// @Failure 400 {object} problem
+// @Failure 403 {object} problem
+// @Router /tours/provider/{id}/images/order [put]
+func (r *tourMediaRoutes) ReorderTourImages(c *gin.Context) {
+	tourID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "tour")
+		return
+	}
+	var reorderMediaDTO entity.ReorderMediaDTO
+	if err := c.ShouldBindJSON(&reorderMediaDTO); err != nil {
+		bindingProblem(c, err)
+		return
+	}
+
+	tour, err := r.t.ReorderTourImages(c.Request.Context(), utils.GetUserIDFromContext(c), tourID, reorderMediaDTO.IDs)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - ReorderTourImages")
+		return
+	}
+
+	c.JSON(http.StatusOK, tour)
+}
+
+// ReorderTourVideos sets the display order of the tour videos.
+// @Summary Reorder tour videos
+// @Description Sets the order of the tour videos. ids must list every video of the tour exactly once.
+// @Tags provider
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tour ID"
+// @Param order body entity.ReorderMediaDTO true "Video IDs in display order"
+// @Success 200 {object} entity.TourDocs
+// @Failure 400 {object} problem
+// @Failure 403 {object} problem
+// @Router /tours/provider/{id}/videos/order [put]
+func (r *tourMediaRoutes) ReorderTourVideos(c *gin.Context) {
+	tourID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "tour")
+		return
+	}
+	var reorderMediaDTO entity.ReorderMediaDTO
+	if err := c.ShouldBindJSON(&reorderMediaDTO); err != nil {
+		bindingProblem(c, err)
+		return
+	}
+
+	tour, err := r.t.ReorderTourVideos(c.Request.Context(), utils.GetUserIDFromContext(c), tourID, reorderMediaDTO.IDs)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - ReorderTourVideos")
+		return
+	}
+
+	c.JSON(http.StatusOK, tour)
+}
+
+// SetCoverImage makes an image the cover of its tour.
+// @Summary Set tour cover image
+// @Description Marks the image as the cover shown in tour listings.
+// @Tags provider
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tour ID"
+// @Param mediaId path string true "Image ID"
+// @Success 200 {object} entity.TourDocs
+// @Failure 400 {object} problem
+// @Failure 403 {object} problem
+// @Failure 404 {object} problem
+// @Router /tours/provider/{id}/images/{mediaId}/cover [put]
+func (r *tourMediaRoutes) SetCoverImage(c *gin.Context) {
+	tourID, imageID, ok := parseMediaIDs(c)
+	if !ok {
+		return
+	}
+
+	tour, err := r.t.SetCoverImage(c.Request.Context(), utils.GetUserIDFromContext(c), tourID, imageID)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - SetCoverImage")
+		return
+	}
+
+	c.JSON(http.StatusOK, tour)
+}
+
+// UpdateTourImage changes the alt text or caption of an image.
+// @Summary Update tour image texts
+// @Description Changes the alt text and/or caption of an image. Omitted fields are kept.
+// @Tags provider
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tour ID"
+// @Param mediaId path string true "Image ID"
+// @Param media body entity.UpdateMediaDTO true "Texts"
+// @Success 200 {object} entity.TourDocs
+// @Failure 400 {object} problem
+// @Failure 403 {object} problem
+// @Failure 404 {object} problem
+// @Router /tours/provider/{id}/images/{mediaId} [patch]
+func (r *tourMediaRoutes) UpdateTourImage(c *gin.Context) {
+	tourID, imageID, ok := parseMediaIDs(c)
+	if !ok {
+		return
+	}
+	var updateMediaDTO entity.UpdateMediaDTO
+	if err := c.ShouldBindJSON(&updateMediaDTO); err != nil {
+		bindingProblem(c, err)
+		return
+	}
+
+	tour, err := r.t.UpdateTourImage(c.Request.Context(), utils.GetUserIDFromContext(c), tourID, imageID, &updateMediaDTO)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - UpdateTourImage")
+		return
+	}
+
+	c.JSON(http.StatusOK, tour)
+}
+
+// UpdateTourVideo changes the alt text or caption of a video.
+// @Summary Update tour video texts
+// @Description Changes the alt text and/or caption of a video. Omitted fields are kept.
+// @Tags provider
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tour ID"
+// @Param mediaId path string true "Video ID"
+// @Param media body entity.UpdateMediaDTO true "Texts"
+// @Success 200 {object} entity.TourDocs
+// @Failure 400 {object} problem
+// @Failure 403 {object} problem
+// @Failure 404 {object} problem
+// @Router /tours/provider/{id}/videos/{mediaId} [patch]
+func (r *tourMediaRoutes) UpdateTourVideo(c *gin.Context) {
+	tourID, videoID, ok := parseMediaIDs(c)
+	if !ok {
+		return
+	}
+	var updateMediaDTO entity.UpdateMediaDTO
+	if err := c.ShouldBindJSON(&updateMediaDTO); err != nil {
+		bindingProblem(c, err)
+		return
+	}
+
+	tour, err := r.t.UpdateTourVideo(c.Request.Context(), utils.GetUserIDFromContext(c), tourID, videoID, &updateMediaDTO)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - UpdateTourVideo")
+		return
+	}
+
+	c.JSON(http.StatusOK, tour)
+}
+
+// DeleteTourImage removes an image and its files.
+// @Summary Delete tour image
+// @Description Deletes the image, its variants and stored files. Deleting the cover promotes the next image.
+// @Tags provider
+// @Security BearerAuth
+// @Param id path string true "Tour ID"
+// @Param mediaId path string true "Image ID"
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 403 {object} problem
+// @Failure 404 {object} problem
+// @Router /tours/provider/{id}/images/{mediaId} [delete]
+func (r *tourMediaRoutes) DeleteTourImage(c *gin.Context) {
+	tourID, imageID, ok := parseMediaIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := r.t.DeleteTourImage(c.Request.Context(), utils.GetAuditActor(c), tourID, imageID); err != nil {
+		errorProblem(c, r.l, err, "http - v1 - DeleteTourImage")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteTourVideo removes a video and its file.
+// @Summary Delete tour video
+// @Description Deletes the video and its stored file.
+// @Tags provider
+// @Security BearerAuth
+// @Param id path string true "Tour ID"
+// @Param mediaId path string true "Video ID"
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 403 {object} problem
+// @Failure 404 {object} problem
+// @Router /tours/provider/{id}/videos/{mediaId} [delete]
+func (r *tourMediaRoutes) DeleteTourVideo(c *gin.Context) {
+	tourID, videoID, ok := parseMediaIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := r.t.DeleteTourVideo(c.Request.Context(), utils.GetAuditActor(c), tourID, videoID); err != nil {
+		errorProblem(c, r.l, err, "http - v1 - DeleteTourVideo")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (r *tourMediaRoutes) bindUpload(c *gin.Context, field string) (uuid.UUID, []*multipart.FileHeader, []entity.MediaMeta, bool) {
+	tourID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "tour")
+		return uuid.Nil, nil, nil, false
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, _maxMediaRequestSize)
+	form, err := c.MultipartForm()
+	if err != nil {
+		errorResponse(c, http.StatusRequestEntityTooLarge, _codePayloadTooLarge, "File size too large")
+		return uuid.Nil, nil, nil, false
+	}
+
+	files := form.File[field]
+	altTexts := form.Value["alt_text"]
+	captions := form.Value["caption"]
+	meta := make([]entity.MediaMeta, len(files))
+	for i := range meta {
+		if i < len(altTexts) {
+			meta[i].AltText = altTexts[i]
+		}
+		if i < len(captions) {
+			meta[i].Caption = captions[i]
+		}
+	}
+	return tourID, files, meta, true
+}
+
+func parseMediaIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	tourID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "tour")
+		return uuid.Nil, uuid.Nil, false
+	}
+	mediaID, err := uuid.Parse(c.Param("mediaId"))
+	if err != nil {
+		invalidID(c, "media")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return tourID, mediaID, true
+}
@@ -1,23 +1,36 @@
 package v1
 
 import (
+	"context"
+	"errors"
 	"github.com/casbin/casbin/v2"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/google/uuid"
 	"mime/multipart"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 	"tourism-backend/internal/entity"
 	"tourism-backend/internal/usecase"
+	"tourism-backend/pkg/geo"
+	"tourism-backend/pkg/imageworker"
 	"tourism-backend/pkg/logger"
 	"tourism-backend/pkg/payment"
 	"tourism-backend/utils"
 )
 
+// _defaultMultipartMemory is the form size kept in memory when the tour uploads are unlimited,
+// larger files go to temporary files.
+const _defaultMultipartMemory = 32 << 20 // 32MB
+
 type tourismRoutes struct {
 	t usecase.TourismInterface
 	l logger.Interface
 	p *payment.PaymentProcessor
+	i *imageworker.ImageProcessor
+	v *requestValidator
 }
 
 // newTourismRoutes initializes tourism routes.
@@ -26,8 +39,8 @@ type tourismRoutes struct {
 // @description API for managing tourism-related data (tours, images, videos).
 // @host localhost:8080
 // @BasePath /api
-func newTourismRoutes(handler *gin.RouterGroup, t usecase.TourismInterface, l logger.Interface, csbn *casbin.Enforcer, payment *payment.PaymentProcessor) {
-	r := &tourismRoutes{t, l, payment}
+func newTourismRoutes(handler *gin.RouterGroup, t usecase.TourismInterface, l logger.Interface, csbn *casbin.Enforcer, payment *payment.PaymentProcessor, images *imageworker.ImageProcessor) {
+	r := &tourismRoutes{t, l, payment, images, newRequestValidator(t, l)}
 
 	h := handler.Group("/tours")
 	{
@@ -35,6 +48,7 @@ func newTourismRoutes(handler *gin.RouterGroup, t usecase.TourismInterface, l lo
 		h.GET("/:id", r.GetTourByID)
 		h.GET("/categories", r.GetAllCategories)
 		h.GET("/tour-events", r.GetFilteredTourEvents)
+		h.GET("/geo", r.SearchToursByLocation)
 		pay := h.Group("/payment")
 		pay.Use(utils.JWTAuthMiddleware())
 		{
@@ -63,15 +77,135 @@ func newTourismRoutes(handler *gin.RouterGroup, t usecase.TourismInterface, l lo
 // @Param category_ids query []string false "Category IDs"
 // @Param min_price query number false "Minimum price"
 // @Param max_price query number false "Maximum price"
+// @Param q query string false "Text matched against the tour description and route in the content language and its fallbacks"
+// @Param lang query string false "Content language, overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred content languages"
 // @Success 200 {array} entity.TourEvent "List of filtered tour events"
+// @Failure 400 {object} problem
 // @Router /tours/tour-events [get]
 func (r *tourismRoutes) GetFilteredTourEvents(c *gin.Context) {
-	var filter entity.TourEventFilter
+	filter, err := bindTourEventFilter(c)
+	if err != nil {
+		bindingProblem(c, err)
+		return
+	}
 
-	if err := c.BindQuery(&filter); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	supported := r.t.Languages()
+	lang, ok := negotiateLanguage(c, supported)
+	if !ok {
 		return
 	}
+	filter.Language = lang
+
+	tourEvents, err := r.t.GetFilteredTourEvents(c.Request.Context(), &filter)
+	if err != nil && !untranslated(c, r.l, err, supported, "http - v1 - GetFilteredTourEvents") {
+		errorProblem(c, r.l, err, "http - v1 - GetFilteredTourEvents")
+		return
+	}
+
+	c.JSON(http.StatusOK, tourEvents)
+}
+
+// SearchToursByLocation finds tours near a point or inside a map viewport.
+// @Summary Search tours by location
+// @Description Finds tours within radius_km of (lat, lon) sorted by distance, or inside bbox. Can be combined with the tour event filters. Returns a GeoJSON FeatureCollection.
+// @Tags tours
+// @Produce json
+// @Param lat query number false "Center latitude"
+// @Param lon query number false "Center longitude"
+// @Param radius_km query number false "Search radius in kilometers"
+// @Param bbox query string false "Viewport as min_lon,min_lat,max_lon,max_lat"
+// @Param limit query int false "Maximum number of tours (default 100, max 500)"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param category_ids query []string false "Category IDs"
+// @Param min_price query number false "Minimum price"
+// @Param max_price query number false "Maximum price"
+// @Param q query string false "Text matched against the tour description and route in the content language and its fallbacks"
+// @Param lang query string false "Content language, overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred content languages"
+// @Success 200 {object} geo.FeatureCollection
+// @Failure 400 {object} problem
+// @Failure 500 {object} problem
+// @Router /tours/geo [get]
+func (r *tourismRoutes) SearchToursByLocation(c *gin.Context) {
+	events, err := bindTourEventFilter(c)
+	if err != nil {
+		bindingProblem(c, err)
+		return
+	}
+	filter := entity.TourGeoSearchFilter{Events: events}
+
+	latStr, lonStr := c.Query("lat"), c.Query("lon")
+	if latStr != "" || lonStr != "" {
+		lat, errLat := strconv.ParseFloat(latStr, 64)
+		lon, errLon := strconv.ParseFloat(lonStr, 64)
+		if errLat != nil || errLon != nil || !geo.ValidPoint(geo.Point{Latitude: lat, Longitude: lon}) {
+			errorResponse(c, http.StatusBadRequest, _codeInvalidRequest, "lat and lon must be a valid coordinate pair",
+				entity.FieldError{Field: "lat", Code: "invalid", Message: "lat and lon must be a valid coordinate pair"})
+			return
+		}
+		filter.Latitude, filter.Longitude = &lat, &lon
+	}
+	if radius := c.Query("radius_km"); radius != "" {
+		filter.RadiusKm, err = strconv.ParseFloat(radius, 64)
+		if err != nil || filter.RadiusKm <= 0 || filter.Latitude == nil {
+			errorResponse(c, http.StatusBadRequest, _codeInvalidRequest, "radius_km must be positive and requires lat and lon",
+				entity.FieldError{Field: "radius_km", Code: "invalid", Message: "radius_km must be positive and requires lat and lon"})
+			return
+		}
+	}
+	if bbox := c.Query("bbox"); bbox != "" {
+		parts := strings.Split(bbox, ",")
+		values := make([]float64, 0, 4)
+		for _, part := range parts {
+			v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+			if err != nil {
+				break
+			}
+			values = append(values, v)
+		}
+		if len(parts) != 4 || len(values) != 4 ||
+			!geo.ValidPoint(geo.Point{Longitude: values[0], Latitude: values[1]}) ||
+			!geo.ValidPoint(geo.Point{Longitude: values[2], Latitude: values[3]}) ||
+			values[1] > values[3] {
+			errorResponse(c, http.StatusBadRequest, _codeInvalidRequest, "bbox must be min_lon,min_lat,max_lon,max_lat",
+				entity.FieldError{Field: "bbox", Code: "invalid", Message: "bbox must be min_lon,min_lat,max_lon,max_lat"})
+			return
+		}
+		filter.HasBounds = true
+		filter.MinLon, filter.MinLat, filter.MaxLon, filter.MaxLat = values[0], values[1], values[2], values[3]
+	}
+	if filter.Latitude == nil && !filter.HasBounds {
+		errorResponse(c, http.StatusBadRequest, _codeInvalidRequest, "either lat and lon or bbox is required")
+		return
+	}
+	if limit := c.Query("limit"); limit != "" {
+		filter.Limit, _ = strconv.Atoi(limit)
+	}
+
+	supported := r.t.Languages()
+	lang, ok := negotiateLanguage(c, supported)
+	if !ok {
+		return
+	}
+	collection, err := r.t.SearchToursByLocation(c.Request.Context(), &filter, lang)
+	if err != nil && !untranslated(c, r.l, err, supported, "http - v1 - SearchToursByLocation") {
+		errorProblem(c, r.l, err, "http - v1 - SearchToursByLocation")
+		return
+	}
+
+	c.Header("Content-Type", "application/geo+json")
+	c.JSON(http.StatusOK, collection)
+}
+
+// bindTourEventFilter reads the tour event filter from the query string.
+func bindTourEventFilter(c *gin.Context) (entity.TourEventFilter, error) {
+	var filter entity.TourEventFilter
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		return filter, err
+	}
 	categoryIDs := c.QueryArray("category_ids")
 
 	for _, id := range categoryIDs {
@@ -97,14 +231,7 @@ func (r *tourismRoutes) GetFilteredTourEvents(c *gin.Context) {
 	if maxPrice := c.Query("max_price"); maxPrice != "" {
 		filter.MaxPrice = utils.ParseFloat(maxPrice)
 	}
-
-	tourEvents, err := r.t.GetFilteredTourEvents(&filter)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tour events"})
-		return
-	}
-
-	c.JSON(http.StatusOK, tourEvents)
+	return filter, nil
 }
 
 // GetTourLocationByID retrieves a tour location by ID.
@@ -115,22 +242,25 @@ func (r *tourismRoutes) GetFilteredTourEvents(c *gin.Context) {
 // @Param id path string true "Tour Location ID"
 // @Security BearerAuth
 // @Success 200 {object} entity.TourLocation "Tour location details"
+// @Failure 400 {object} problem
+// @Failure 403 {object} problem
+// @Failure 404 {object} problem
 // @Router /tours/provider/tour-location/{id} [get]
 func (r *tourismRoutes) GetTourLocationByID(c *gin.Context) {
 	userID := utils.GetUserIDFromContext(c)
 
 	tourID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.Status(http.StatusBadRequest)
+		invalidID(c, "tour")
 		return
 	}
-	if !r.t.CheckTourOwner(tourID, userID) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized: You are not owner of this tour"})
+	if !r.t.CheckTourOwner(c.Request.Context(), tourID, userID) {
+		errorProblem(c, r.l, usecase.ErrNotTourOwner, "http - v1 - GetTourLocationByID")
 		return
 	}
-	tourLocation, err := r.t.GetTourLocationByID(tourID)
+	tourLocation, err := r.t.GetTourLocationByID(c.Request.Context(), tourID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		errorProblem(c, r.l, err, "http - v1 - GetTourLocationByID")
 		return
 	}
 
@@ -147,23 +277,25 @@ func (r *tourismRoutes) GetTourLocationByID(c *gin.Context) {
 // @Param location body entity.CreateTourLocationDTO true "Tour location details"
 // @Security BearerAuth
 // @Success 201 {object} entity.TourLocation "Created tour location"
+// @Failure 400 {object} problem "Invalid fields, including a tour that does not exist"
+// @Failure 403 {object} problem
+// @Failure 404 {object} problem
 // @Router /tours/provider/tour-location [post]
 func (r *tourismRoutes) CreateTourLocation(c *gin.Context) {
 	var createTourLocationDTO entity.CreateTourLocationDTO
-	if err := c.ShouldBind(&createTourLocationDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !r.v.bind(c, &createTourLocationDTO, binding.Default(c.Request.Method, c.ContentType()), "http - v1 - CreateTourLocation") {
 		return
 	}
 
 	userID := utils.GetUserIDFromContext(c)
-	if !r.t.CheckTourOwner(createTourLocationDTO.TourID, userID) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized: You are not owner of this tour"})
+	if !r.t.CheckTourOwner(c.Request.Context(), createTourLocationDTO.TourID, userID) {
+		errorProblem(c, r.l, usecase.ErrNotTourOwner, "http - v1 - CreateTourLocation")
 		return
 	}
 
-	createdTourLocation, err := r.t.CreateTourLocation(&createTourLocationDTO)
+	createdTourLocation, err := r.t.CreateTourLocation(c.Request.Context(), utils.GetAuditActor(c), &createTourLocationDTO)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		errorProblem(c, r.l, err, "http - v1 - CreateTourLocation")
 		return
 	}
 	c.JSON(http.StatusCreated, gin.H{"message": "Tour Location created successfully!", "Tour Location": createdTourLocation})
@@ -178,23 +310,26 @@ func (r *tourismRoutes) CreateTourLocation(c *gin.Context) {
 // @Param category body entity.CreateTourCategoryDTO true "Tour category details"
 // @Security BearerAuth
 // @Success 201 {object} entity.TourCategory "Created tour category"
+// @Failure 400 {object} problem "Invalid fields, including a tour or category that does not exist"
+// @Failure 403 {object} problem
+// @Failure 404 {object} problem
+// @Failure 409 {object} problem "Tour is already in the category"
 // @Router /tours/provider/tour-category [post]
 func (r *tourismRoutes) CreateTourCategory(c *gin.Context) {
 	var createTourCategoryDTO entity.CreateTourCategoryDTO
-	if err := c.ShouldBind(&createTourCategoryDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !r.v.bind(c, &createTourCategoryDTO, binding.Default(c.Request.Method, c.ContentType()), "http - v1 - CreateTourCategory") {
 		return
 	}
 
 	userID := utils.GetUserIDFromContext(c)
-	if !r.t.CheckTourOwner(createTourCategoryDTO.TourID, userID) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized: You are not owner of this tour"})
+	if !r.t.CheckTourOwner(c.Request.Context(), createTourCategoryDTO.TourID, userID) {
+		errorProblem(c, r.l, usecase.ErrNotTourOwner, "http - v1 - CreateTourCategory")
 		return
 	}
 
-	createdTourCategory, err := r.t.CreateTourCategory(&createTourCategoryDTO)
+	createdTourCategory, err := r.t.CreateTourCategory(c.Request.Context(), utils.GetAuditActor(c), &createTourCategoryDTO)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		errorProblem(c, r.l, err, "http - v1 - CreateTourCategory")
 		return
 	}
 	c.JSON(http.StatusCreated, gin.H{"message": "Tour Category created successfully!", "Tour Category": createdTourCategory})
@@ -203,15 +338,23 @@ func (r *tourismRoutes) CreateTourCategory(c *gin.Context) {
 
 // GetAllCategories retrieves all tour categories.
 // @Summary Get all tour categories
-// @Description Fetches a list of all available tour categories.
+// @Description Fetches a list of all available tour categories. Names are given in the language requested by lang or negotiated from Accept-Language, falling back along the configured chain, and categories are sorted by them. See Content-Language.
 // @Tags tours
 // @Produce json
+// @Param lang query string false "Content language, overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred content languages"
 // @Success 200 {array} entity.TourCategory "List of tour categories"
+// @Failure 400 {object} problem
 // @Router /tours/categories [get]
 func (r *tourismRoutes) GetAllCategories(c *gin.Context) {
-	categories, err := r.t.GetAllCategories()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tours"})
+	supported := r.t.Languages()
+	lang, ok := negotiateLanguage(c, supported)
+	if !ok {
+		return
+	}
+	categories, err := r.t.GetAllCategories(c.Request.Context(), lang)
+	if err != nil && !untranslated(c, r.l, err, supported, "http - v1 - GetAllCategories") {
+		errorProblem(c, r.l, err, "http - v1 - GetAllCategories")
 		return
 	}
 
@@ -227,11 +370,14 @@ func (r *tourismRoutes) GetAllCategories(c *gin.Context) {
 // @Param payment body entity.TourPurchaseRequest true "Payment details"
 // @Security BearerAuth
 // @Success 200 {object} entity.Purchase "Purchase details"
+// @Failure 400 {object} problem "Invalid fields, including a tour event that does not exist"
+// @Failure 404 {object} problem "Tour event not found"
+// @Failure 409 {object} problem "Tour event sold out"
+// @Failure 503 {object} problem
 // @Router /tours/payment [post]
 func (r *tourismRoutes) PayTourEvent(c *gin.Context) {
 	var purchaseRaw entity.TourPurchaseRequest
-	if err := c.ShouldBindJSON(&purchaseRaw); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+	if !r.v.bind(c, &purchaseRaw, binding.JSON, "http - v1 - PayTourEvent") {
 		return
 	}
 
@@ -240,46 +386,53 @@ func (r *tourismRoutes) PayTourEvent(c *gin.Context) {
 	purchase := entity.Purchase{
 		TourEventID: purchaseRaw.TourEventID,
 		UserID:      UserID,
-		Status:      "Processing",
+		Status:      entity.PurchaseStatusProcessing,
 	}
 
-	processingPurchase, err := r.t.CreatePurchase(&purchase)
+	processingPurchase, err := r.t.CreatePurchase(c.Request.Context(), utils.GetAuditActor(c), &purchase)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		errorProblem(c, r.l, err, "http - v1 - PayTourEvent")
 		return
 	}
 
-	r.p.PurchaseQueue <- processingPurchase
+	if err := r.p.Enqueue(c.Request.Context(), processingPurchase); err != nil {
+		r.l.WithContext(c.Request.Context()).Error(err, "http - v1 - PayTourEvent")
+		// The purchase will never be paid, its seat goes back even when the client is gone.
+		if err := r.t.FailPurchase(context.WithoutCancel(c.Request.Context()), processingPurchase); err != nil {
+			r.l.WithContext(c.Request.Context()).Error(err, "http - v1 - PayTourEvent - FailPurchase")
+		}
+		errorResponse(c, http.StatusServiceUnavailable, _codeUnavailable, "Payment processing is unavailable, try again later")
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{"Purchase": processingPurchase})
 }
 
 // CreateTourEvent handles the creation of a new tour event related to some specific tour with images and videos.
 // @Summary Create a new tour event
-// @Description Create a new tour event.
-// @Tags tours
-// @Accept multipart/form-data
+// @Description Create a new event of one of your tours. The date must be in the future, the price positive with at most two decimal places and the amount of places a whole number.
+// @Tags provider
+// @Accept json
 // @Produce json
-// @Param description formData string true "Tour Description"
-// @Param route formData string true "Tour Route"
-// @Param price formData int true "Tour Price"
-// @Success 201 {object} entity.TourDocs
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /tours [post]
+// @Param event body entity.CreateTourEventDTO true "Tour event details"
+// @Security BearerAuth
+// @Success 201 {object} entity.TourEvent
+// @Failure 400 {object} problem "Invalid fields, including a tour that does not exist"
+// @Failure 403 {object} problem
+// @Failure 404 {object} problem
+// @Failure 500 {object} problem
+// @Router /tours/provider/tour-event [post]
 func (r *tourismRoutes) CreateTourEvent(c *gin.Context) {
 	var createTourEventDTO entity.CreateTourEventDTO
-
-	if err := c.ShouldBindJSON(&createTourEventDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !r.v.bind(c, &createTourEventDTO, binding.JSON, "http - v1 - CreateTourEvent") {
 		return
 	}
 
 	// Get User ID from JWTMiddleware
 	userID := utils.GetUserIDFromContext(c)
 
-	if !r.t.CheckTourOwner(createTourEventDTO.TourID, userID) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized: You are not owner of this tour"})
+	if !r.t.CheckTourOwner(c.Request.Context(), createTourEventDTO.TourID, userID) {
+		errorProblem(c, r.l, usecase.ErrNotTourOwner, "http - v1 - CreateTourEvent")
 		return
 	}
 
@@ -291,9 +444,9 @@ func (r *tourismRoutes) CreateTourEvent(c *gin.Context) {
 		AmountOfPlaces: createTourEventDTO.AmountOfPlaces,
 	}
 
-	createdTourEvent, err := r.t.CreateTourEvent(tour)
+	createdTourEvent, err := r.t.CreateTourEvent(c.Request.Context(), utils.GetAuditActor(c), tour)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create tour. Make sure the tour with such ID exists"})
+		errorProblem(c, r.l, err, "http - v1 - CreateTourEvent")
 		return
 	}
 	c.JSON(http.StatusCreated, gin.H{"message": "Tour event created successfully!", "Tour Event": createdTourEvent})
@@ -307,8 +460,8 @@ func (r *tourismRoutes) CreateTourEvent(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Tour ID"
 // @Success 200 {object} map[string]interface{} "Returns a list of image and video URLs."
-// @Failure 400 {object} map[string]string "Invalid Tour ID"
-// @Failure 404 {object} map[string]string "Tour not found"
+// @Failure 400 {object} problem "Invalid Tour ID"
+// @Failure 404 {object} problem "Tour not found"
 // @Router /tours/{id}/ [get]
 func GetStaticFiles(c *gin.Context) {
 
@@ -316,18 +469,30 @@ func GetStaticFiles(c *gin.Context) {
 
 // GetTourByID retrieves a specific tour by ID.
 // @Summary Get a tour by ID
-// @Description Fetch details of a specific tour by its UUID.
+// @Description Fetch details of a specific tour by its UUID. Description and route are given in the language requested by lang or negotiated from Accept-Language, falling back along the configured chain. See Content-Language.
 // @Tags tours
 // @Produce json
 // @Param id path string true "Tour ID"
+// @Param lang query string false "Content language, overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred content languages"
 // @Success 200 {object} entity.TourDocs
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 500 {object} problem
 // @Router /tours/{id} [get]
 func (r *tourismRoutes) GetTourByID(c *gin.Context) {
-	tour, err := r.t.GetTourByID(c.Param("id"))
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	supported := r.t.Languages()
+	lang, ok := negotiateLanguage(c, supported)
+	if !ok {
+		return
+	}
+	if _, err := uuid.Parse(c.Param("id")); err != nil {
+		invalidID(c, "tour")
+		return
+	}
+	tour, err := r.t.GetTourByID(c.Request.Context(), c.Param("id"), lang)
+	if err != nil && !untranslated(c, r.l, err, supported, "http - v1 - GetTourByID") {
+		errorProblem(c, r.l, err, "http - v1 - GetTourByID")
 		return
 	}
 
@@ -336,17 +501,24 @@ func (r *tourismRoutes) GetTourByID(c *gin.Context) {
 
 // GetTours retrieves all tours.
 // @Summary Get all tours
-// @Description Fetch a list of all available tours.
+// @Description Fetch a list of all available tours. Descriptions and routes are given in the language requested by lang or negotiated from Accept-Language, falling back along the configured chain. See Content-Language.
 // @Tags tours
 // @Produce json
+// @Param lang query string false "Content language, overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred content languages"
 // @Success 200 {array} entity.TourDocs
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} problem
+// @Failure 500 {object} problem
 // @Router /tours [get]
 func (r *tourismRoutes) GetTours(c *gin.Context) {
-
-	tours, err := r.t.GetTours()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tours"})
+	supported := r.t.Languages()
+	lang, ok := negotiateLanguage(c, supported)
+	if !ok {
+		return
+	}
+	tours, err := r.t.GetTours(c.Request.Context(), lang)
+	if err != nil && !untranslated(c, r.l, err, supported, "http - v1 - GetTours") {
+		errorProblem(c, r.l, err, "http - v1 - GetTours")
 		return
 	}
 
@@ -359,38 +531,51 @@ func (r *tourismRoutes) GetTours(c *gin.Context) {
 // @Tags tours
 // @Accept multipart/form-data
 // @Produce json
-// @Param description formData string true "Tour Description"
-// @Param route formData string true "Tour Route"
+// @Param description formData string true "Tour Description" maxlength(5000)
+// @Param route formData string true "Tour Route" maxlength(5000)
+// @Param language formData string false "Language of the description and route, defaults to the source content language" maxlength(16)
 // @Param images formData file false "Tour Images (multiple allowed)"
 // @Param videos formData file false "Tour Videos (multiple allowed)"
 // @Success 201 {object} entity.TourDocs
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} problem
+// @Failure 413 {object} problem
+// @Failure 500 {object} problem
 // @Router /tours [post]
 func (r *tourismRoutes) CreateTour(c *gin.Context) {
-	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, 200<<20) // 200MB
-
-	if err := c.Request.ParseMultipartForm(200 << 20); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "File size too large"})
+	maxSize := r.t.MaxCreateTourSize()
+	if maxSize > 0 {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize)
+	} else {
+		maxSize = _defaultMultipartMemory
+	}
+	if err := c.Request.ParseMultipartForm(maxSize); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			errorResponse(c, http.StatusRequestEntityTooLarge, _codePayloadTooLarge, "File size too large")
+			return
+		}
+		errorResponse(c, http.StatusBadRequest, _codeInvalidRequest, "request body is not a valid multipart form")
 		return
 	}
 
 	// Get User ID from JWTMiddleware
 	userIDStr, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		errorProblem(c, r.l, utils.ErrTokenRequired, "http - v1 - CreateTour")
 		return
 	}
 
 	// Convert user_id string to UUID
 	userID, err := uuid.Parse(userIDStr.(string))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		errorProblem(c, r.l, utils.ErrInvalidToken, "http - v1 - CreateTour")
 		return
 	}
 
-	description := c.PostForm("description")
-	route := c.PostForm("route")
+	var createTourDTO entity.CreateTourDTO
+	if !r.v.bind(c, &createTourDTO, binding.FormMultipart, "http - v1 - CreateTour") {
+		return
+	}
 
 	form, _ := c.MultipartForm()
 	var imageFiles []*multipart.FileHeader
@@ -405,16 +590,22 @@ func (r *tourismRoutes) CreateTour(c *gin.Context) {
 
 	tour := &entity.Tour{
 		ID:          uuid.New(),
-		Description: description,
-		Route:       route,
+		Description: createTourDTO.Description,
+		Route:       createTourDTO.Route,
+		Language:    createTourDTO.Language,
 		OwnerID:     userID,
 	}
 
-	createdTour, err := r.t.CreateTour(tour, imageFiles, videoFiles)
+	createdTour, err := r.t.CreateTour(c.Request.Context(), utils.GetAuditActor(c), tour, imageFiles, videoFiles)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create tour"})
+		errorProblem(c, r.l, err, "http - v1 - CreateTour")
 		return
 	}
+
+	// Thumbnails and responsive sizes are built in the background
+	for _, image := range createdTour.TourImages {
+		r.i.Enqueue(image.ID)
+	}
 	c.JSON(http.StatusCreated, gin.H{"message": "Tour created successfully", "tour": createdTour})
 
 }
@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase"
+	"tourism-backend/internal/usecase/repo/inmemory"
+	"tourism-backend/pkg/logger"
+	"tourism-backend/pkg/payment"
+)
+
+func TestPayTourEventUnavailable(t *testing.T) {
+	require.NoError(t, registerValidators())
+	gin.SetMode(gin.TestMode)
+
+	ctx := context.Background()
+	repo := inmemory.NewTourismRepo()
+	tourism := usecase.NewTourismUseCase(repo, inmemory.Transactor{}, usecase.NewAuditUseCase(inmemory.NewAuditRepo()), nil, nil)
+	l := logger.New("error")
+
+	tour, err := repo.CreateTour(ctx, &entity.Tour{Description: "Old town walk", OwnerID: uuid.New()}, nil, nil)
+	require.NoError(t, err)
+	tourEvent, err := repo.CreateTourEvent(ctx, &entity.TourEvent{
+		TourID:         tour.ID,
+		Date:           time.Now().Add(24 * time.Hour),
+		Price:          10,
+		Place:          "Square",
+		AmountOfPlaces: 1,
+		IsOpened:       true,
+	})
+	require.NoError(t, err)
+
+	// A processor that is shutting down refuses new payments.
+	processor := payment.NewPaymentProcessor(1, tourism, l)
+	require.NoError(t, processor.Shutdown(ctx))
+	r := &tourismRoutes{t: tourism, l: l, p: processor, v: newRequestValidator(tourism, l)}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/tours/payment", strings.NewReader(`{"tour_event_id":"`+tourEvent.ID.String()+`"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("userID", uuid.NewString())
+	r.PayTourEvent(c)
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	// The purchase that was not enqueued gave its only seat back.
+	_, err = repo.CreatePurchase(ctx, &entity.Purchase{TourEventID: tourEvent.ID, UserID: uuid.New(), Status: entity.PurchaseStatusProcessing})
+	require.NoError(t, err)
+}
@@ -0,0 +1,192 @@
+package v1
+
+import (
+	"errors"
+	"github.com/casbin/casbin/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strings"
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase"
+	"tourism-backend/pkg/locale"
+	"tourism-backend/pkg/logger"
+	"tourism-backend/utils"
+)
+
+type translationRoutes struct {
+	t usecase.TranslationInterface
+	l logger.Interface
+}
+
+func newTranslationRoutes(handler *gin.RouterGroup, t usecase.TranslationInterface, l logger.Interface, csbn *casbin.Enforcer) {
+	r := &translationRoutes{t, l}
+
+	provider := handler.Group("/tours/provider")
+	provider.Use(utils.JWTAuthMiddleware(), utils.CasbinMiddleware(csbn))
+	{
+		provider.GET("/:id/translations", r.GetTourLocales)
+		provider.PUT("/:id/translations/:lang", r.SetTourLocale)
+		provider.DELETE("/:id/translations/:lang", r.DeleteTourLocale)
+	}
+
+	admin := handler.Group("/admin/categories")
+	admin.Use(utils.JWTAuthMiddleware(), utils.CasbinMiddleware(csbn))
+	{
+		admin.PUT("/:id/translations/:lang", r.SetCategoryLocale)
+	}
+}
+
+// negotiateLanguage picks the content language of the response from the lang query
+// parameter or the Accept-Language header. An unsupported lang is answered with 400.
+func negotiateLanguage(c *gin.Context, supported []string) (string, bool) {
+	lang := locale.Negotiate(c.GetHeader("Accept-Language"), supported)
+	if tag := c.Query("lang"); tag != "" {
+		var ok bool
+		if lang, ok = locale.Match(tag, supported); !ok {
+			detail := "unsupported language, expected one of " + strings.Join(supported, ", ")
+			errorResponse(c, http.StatusBadRequest, usecase.ErrUnsupportedLanguage.Code, detail,
+				entity.FieldError{Field: "lang", Code: "oneof", Message: detail})
+			return "", false
+		}
+	}
+	c.Header("Vary", "Accept-Language")
+	c.Header("Content-Language", lang)
+	return lang, true
+}
+
+// untranslated reports whether err only means that machine translation failed.
+// The content is then served from the fallback chain, mostly in the source language.
+func untranslated(c *gin.Context, l logger.Interface, err error, supported []string, op string) bool {
+	if !errors.Is(err, usecase.ErrTranslationUnavailable) {
+		return false
+	}
+	l.WithContext(c.Request.Context()).Warn(op + ": " + err.Error())
+	c.Header("Content-Language", supported[0])
+	return true
+}
+
+// GetTourLocales lists the locales of a tour.
+// @Summary Get tour locales
+// @Description Lists the description and route of the tour written in other languages. outdated marks locales written before the tour texts were last edited.
+// @Tags provider
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tour ID"
+// @Success 200 {array} entity.TourLocale
+// @Failure 403 {object} problem
+// @Failure 404 {object} problem
+// @Router /tours/provider/{id}/translations [get]
+func (r *translationRoutes) GetTourLocales(c *gin.Context) {
+	tourID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "tour")
+		return
+	}
+
+	locales, err := r.t.GetTourLocales(c.Request.Context(), utils.GetUserIDFromContext(c), tourID)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - GetTourLocales")
+		return
+	}
+
+	c.JSON(http.StatusOK, locales)
+}
+
+// SetTourLocale writes the tour texts in one language.
+// @Summary Set tour locale
+// @Description Writes the tour description and/or route in the given language. Omitted fields keep their current text, empty fields fall back to the next language of the chain.
+// @Tags provider
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tour ID"
+// @Param lang path string true "Language code, e.g. kk or en"
+// @Param locale body entity.UpdateTourLocaleDTO true "Texts in the language"
+// @Success 200 {object} entity.TourLocale
+// @Failure 400 {object} problem
+// @Failure 403 {object} problem
+// @Failure 404 {object} problem
+// @Router /tours/provider/{id}/translations/{lang} [put]
+func (r *translationRoutes) SetTourLocale(c *gin.Context) {
+	tourID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "tour")
+		return
+	}
+	var updateTourLocaleDTO entity.UpdateTourLocaleDTO
+	if err := c.ShouldBindJSON(&updateTourLocaleDTO); err != nil {
+		bindingProblem(c, err)
+		return
+	}
+
+	tourLocale, err := r.t.SetTourLocale(c.Request.Context(), utils.GetAuditActor(c), tourID, c.Param("lang"), &updateTourLocaleDTO)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - SetTourLocale")
+		return
+	}
+
+	c.JSON(http.StatusOK, tourLocale)
+}
+
+// DeleteTourLocale removes the tour texts in one language.
+// @Summary Delete tour locale
+// @Description Removes the tour texts written in the given language together with their cached machine translations, reads fall back to the next language of the chain.
+// @Tags provider
+// @Security BearerAuth
+// @Param id path string true "Tour ID"
+// @Param lang path string true "Language code, e.g. kk or en"
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 403 {object} problem
+// @Failure 404 {object} problem
+// @Router /tours/provider/{id}/translations/{lang} [delete]
+func (r *translationRoutes) DeleteTourLocale(c *gin.Context) {
+	tourID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "tour")
+		return
+	}
+
+	if err := r.t.DeleteTourLocale(c.Request.Context(), utils.GetAuditActor(c), tourID, c.Param("lang")); err != nil {
+		errorProblem(c, r.l, err, "http - v1 - DeleteTourLocale")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SetCategoryLocale writes the category name in one language.
+// @Summary Set category locale
+// @Description Writes the category name in the given language.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Category ID"
+// @Param lang path string true "Language code, e.g. kk or en"
+// @Param locale body entity.UpdateCategoryLocaleDTO true "Name in the language"
+// @Success 200 {object} entity.CategoryLocale
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Router /admin/categories/{id}/translations/{lang} [put]
+func (r *translationRoutes) SetCategoryLocale(c *gin.Context) {
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "category")
+		return
+	}
+	var updateCategoryLocaleDTO entity.UpdateCategoryLocaleDTO
+	if err := c.ShouldBindJSON(&updateCategoryLocaleDTO); err != nil {
+		bindingProblem(c, err)
+		return
+	}
+
+	categoryLocale, err := r.t.SetCategoryLocale(c.Request.Context(), utils.GetAuditActor(c), categoryID, c.Param("lang"), &updateCategoryLocaleDTO)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - SetCategoryLocale")
+		return
+	}
+
+	c.JSON(http.StatusOK, categoryLocale)
+}
@@ -0,0 +1,276 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"github.com/casbin/casbin/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase"
+	"tourism-backend/pkg/logger"
+	"tourism-backend/pkg/tus"
+	"tourism-backend/utils"
+)
+
+type uploadRoutes struct {
+	u            usecase.UploadInterface
+	l            logger.Interface
+	chunkTimeout time.Duration
+}
+
+// newUploadRoutes serves tus 1.0.0 resumable video uploads. Clients send the file
+// in chunks small enough for their connection and resume after a failure from the
+// offset returned by HEAD.
+func newUploadRoutes(handler *gin.RouterGroup, u usecase.UploadInterface, l logger.Interface, csbn *casbin.Enforcer, chunkTimeout time.Duration) {
+	r := &uploadRoutes{u, l, chunkTimeout}
+
+	// Discovery is public, tus clients and CORS preflights send it without credentials.
+	handler.OPTIONS("/tours/provider/uploads", r.Options)
+	handler.OPTIONS("/tours/provider/uploads/:uploadId", r.Options)
+
+	uploads := handler.Group("/tours/provider/uploads")
+	uploads.Use(tusResumable, utils.JWTAuthMiddleware(), utils.CasbinMiddleware(csbn))
+	{
+		uploads.POST("", r.CreateUpload)
+		uploads.HEAD("/:uploadId", r.GetUploadOffset)
+		uploads.PATCH("/:uploadId", r.WriteUploadChunk)
+		uploads.DELETE("/:uploadId", r.DeleteUpload)
+	}
+}
+
+// tusResumable rejects requests of other protocol versions and marks every response with the served version.
+func tusResumable(c *gin.Context) {
+	c.Header(tus.HeaderResumable, tus.Version)
+	if c.GetHeader(tus.HeaderResumable) != tus.Version {
+		c.Header(tus.HeaderVersion, tus.Version)
+		errorResponse(c, http.StatusPreconditionFailed, "unsupported_tus_version", "Unsupported Tus-Resumable version")
+		return
+	}
+	c.Next()
+}
+
+// Options describes the tus server capabilities.
+// @Summary Resumable upload capabilities
+// @Description Returns the supported tus version, extensions, checksum algorithms and maximum upload size in headers.
+// @Tags provider
+// @Success 204
+// @Header 204 {string} Tus-Version "Supported protocol versions"
+// @Header 204 {string} Tus-Extension "Supported protocol extensions"
+// @Header 204 {string} Tus-Checksum-Algorithm "Supported checksum algorithms"
+// @Header 204 {integer} Tus-Max-Size "Maximum upload length in bytes"
+// @Router /tours/provider/uploads [options]
+func (r *uploadRoutes) Options(c *gin.Context) {
+	c.Header(tus.HeaderResumable, tus.Version)
+	c.Header(tus.HeaderVersion, tus.Version)
+	c.Header(tus.HeaderExtension, tus.Extensions)
+	c.Header(tus.HeaderChecksumAlgorithm, tus.ChecksumAlgorithms())
+	if maxSize := r.u.MaxSize(); maxSize > 0 {
+		c.Header(tus.HeaderMaxSize, strconv.FormatInt(maxSize, 10))
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CreateUpload starts a resumable video upload.
+// @Summary Create resumable video upload
+// @Description Starts a tus upload of a tour video. Upload-Metadata must contain tour_id and filename and may contain alt_text and caption, all base64 encoded. The video is attached to the tour when the last chunk arrives.
+// @Tags provider
+// @Security BearerAuth
+// @Param Tus-Resumable header string true "Protocol version" default(1.0.0)
+// @Param Upload-Length header integer true "File size in bytes"
+// @Param Upload-Metadata header string true "tus metadata, e.g. tour_id <base64>,filename <base64>"
+// @Success 201
+// @Header 201 {string} Location "Upload URL"
+// @Header 201 {string} Upload-Expires "Time after which an unfinished upload is deleted"
+// @Failure 400 {object} problem
+// @Failure 403 {object} problem
+// @Failure 412 {object} problem
+// @Failure 413 {object} problem
+// @Router /tours/provider/uploads [post]
+func (r *uploadRoutes) CreateUpload(c *gin.Context) {
+	if c.GetHeader("Upload-Defer-Length") != "" {
+		errorResponse(c, http.StatusBadRequest, _codeInvalidRequest, "Upload-Defer-Length is not supported")
+		return
+	}
+	length, err := strconv.ParseInt(c.GetHeader(tus.HeaderUploadLength), 10, 64)
+	if err != nil || length < 0 {
+		errorResponse(c, http.StatusBadRequest, _codeInvalidRequest, "Invalid Upload-Length")
+		return
+	}
+	metadata, err := tus.ParseMetadata(c.GetHeader(tus.HeaderUploadMetadata))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, _codeInvalidRequest, err.Error())
+		return
+	}
+	tourID, err := uuid.Parse(metadata["tour_id"])
+	if err != nil {
+		invalidID(c, "tour")
+		return
+	}
+
+	upload, err := r.u.CreateUpload(c.Request.Context(), utils.GetAuditActor(c), &entity.CreateUploadDTO{
+		TourID:   tourID,
+		Length:   length,
+		Filename: metadata["filename"],
+		MediaMeta: entity.MediaMeta{
+			AltText: metadata["alt_text"],
+			Caption: metadata["caption"],
+		},
+	})
+	if err != nil {
+		r.uploadError(c, err, "http - v1 - CreateUpload")
+		return
+	}
+
+	c.Header("Location", strings.TrimSuffix(c.Request.URL.Path, "/")+"/"+upload.ID.String())
+	c.Header(tus.HeaderUploadExpires, upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	c.Status(http.StatusCreated)
+}
+
+// GetUploadOffset reports how many bytes of an upload were received.
+// @Summary Get resumable upload offset
+// @Description Returns the received byte count in Upload-Offset, the offset the next chunk must start at.
+// @Tags provider
+// @Security BearerAuth
+// @Param Tus-Resumable header string true "Protocol version" default(1.0.0)
+// @Param uploadId path string true "Upload ID"
+// @Success 200
+// @Header 200 {integer} Upload-Offset "Received bytes"
+// @Header 200 {integer} Upload-Length "File size in bytes"
+// @Failure 404 {object} problem
+// @Failure 410 {object} problem
+// @Router /tours/provider/uploads/{uploadId} [head]
+func (r *uploadRoutes) GetUploadOffset(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("uploadId"))
+	if err != nil {
+		c.Status(http.StatusNotFound)
+		return
+	}
+
+	upload, err := r.u.GetUpload(c.Request.Context(), utils.GetUserIDFromContext(c), id)
+	if err != nil {
+		r.uploadError(c, err, "http - v1 - GetUploadOffset")
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	setUploadHeaders(c, upload)
+	c.Header(tus.HeaderUploadLength, strconv.FormatInt(upload.Length, 10))
+	c.Status(http.StatusOK)
+}
+
+// WriteUploadChunk appends a chunk to an upload.
+// @Summary Upload resumable chunk
+// @Description Appends the body at Upload-Offset. With Upload-Checksum the chunk is rejected with 460 when its digest does not match. The chunk completing the file attaches the video to the tour.
+// @Tags provider
+// @Accept application/offset+octet-stream
+// @Security BearerAuth
+// @Param Tus-Resumable header string true "Protocol version" default(1.0.0)
+// @Param Upload-Offset header integer true "Offset of the chunk"
+// @Param Upload-Checksum header string false "Chunk digest, e.g. sha1 <base64>"
+// @Param uploadId path string true "Upload ID"
+// @Success 204
+// @Header 204 {integer} Upload-Offset "Received bytes"
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 409 {object} problem
+// @Failure 415 {object} problem
+// @Failure 423 {object} problem
+// @Failure 460 {object} problem
+// @Router /tours/provider/uploads/{uploadId} [patch]
+func (r *uploadRoutes) WriteUploadChunk(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("uploadId"))
+	if err != nil {
+		errorProblem(c, r.l, usecase.ErrUploadNotFound, "http - v1 - UploadID")
+		return
+	}
+	if c.ContentType() != tus.ContentType {
+		errorResponse(c, http.StatusUnsupportedMediaType, "unsupported_media_type", "Content-Type must be "+tus.ContentType)
+		return
+	}
+	offset, err := strconv.ParseInt(c.GetHeader(tus.HeaderUploadOffset), 10, 64)
+	if err != nil || offset < 0 {
+		errorResponse(c, http.StatusBadRequest, _codeInvalidRequest, "Invalid Upload-Offset")
+		return
+	}
+	var checksum *tus.Checksum
+	if header := c.GetHeader(tus.HeaderUploadChecksum); header != "" {
+		if checksum, err = tus.ParseChecksum(header); err != nil {
+			errorResponse(c, http.StatusBadRequest, _codeInvalidRequest, err.Error())
+			return
+		}
+	}
+
+	// The server-wide timeouts are sized for small requests, a chunk gets its own deadline.
+	rc := http.NewResponseController(c.Writer)
+	deadline := time.Now().Add(r.chunkTimeout)
+	_ = rc.SetReadDeadline(deadline)
+	_ = rc.SetWriteDeadline(deadline)
+	ctx, cancel := context.WithDeadline(c.Request.Context(), deadline)
+	defer cancel()
+
+	upload, err := r.u.WriteUploadChunk(ctx, utils.GetAuditActor(c), id, offset, c.Request.Body, checksum)
+	if err != nil {
+		r.uploadError(c, err, "http - v1 - WriteUploadChunk")
+		return
+	}
+
+	setUploadHeaders(c, upload)
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteUpload terminates an upload.
+// @Summary Terminate resumable upload
+// @Description Deletes an upload and its received bytes. A video of a completed upload is kept.
+// @Tags provider
+// @Security BearerAuth
+// @Param Tus-Resumable header string true "Protocol version" default(1.0.0)
+// @Param uploadId path string true "Upload ID"
+// @Success 204
+// @Failure 404 {object} problem
+// @Failure 423 {object} problem
+// @Router /tours/provider/uploads/{uploadId} [delete]
+func (r *uploadRoutes) DeleteUpload(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("uploadId"))
+	if err != nil {
+		errorProblem(c, r.l, usecase.ErrUploadNotFound, "http - v1 - UploadID")
+		return
+	}
+
+	if err := r.u.DeleteUpload(c.Request.Context(), utils.GetUserIDFromContext(c), id); err != nil {
+		r.uploadError(c, err, "http - v1 - DeleteUpload")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func setUploadHeaders(c *gin.Context, upload *entity.Upload) {
+	c.Header(tus.HeaderUploadOffset, strconv.FormatInt(upload.Offset, 10))
+	if !upload.Completed() {
+		c.Header(tus.HeaderUploadExpires, upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	}
+}
+
+// uploadError answers the failures the tus protocol assigns their own status to,
+// other errors are mapped to problems as usual.
+func (r *uploadRoutes) uploadError(c *gin.Context, err error, op string) {
+	switch {
+	case errors.Is(err, usecase.ErrUploadExpired):
+		errorResponse(c, http.StatusGone, usecase.ErrUploadExpired.Code, usecase.ErrUploadExpired.Message)
+	case errors.Is(err, usecase.ErrUploadLocked):
+		errorResponse(c, http.StatusLocked, usecase.ErrUploadLocked.Code, usecase.ErrUploadLocked.Message)
+	case errors.Is(err, usecase.ErrUploadTooLarge):
+		errorResponse(c, http.StatusRequestEntityTooLarge, usecase.ErrUploadTooLarge.Code, usecase.ErrUploadTooLarge.Message)
+	case errors.Is(err, tus.ErrChunkTooLarge):
+		errorResponse(c, http.StatusRequestEntityTooLarge, _codePayloadTooLarge, tus.ErrChunkTooLarge.Error())
+	case errors.Is(err, tus.ErrChecksumMismatch):
+		errorResponse(c, tus.StatusChecksumMismatch, "checksum_mismatch", tus.ErrChecksumMismatch.Error())
+	default:
+		errorProblem(c, r.l, err, op)
+	}
+}
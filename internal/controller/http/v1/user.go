@@ -38,20 +38,22 @@ func newUserRoutes(handler *gin.RouterGroup, t usecase.UserInterface, l logger.I
 // @Produce json
 // @Param credentials body entity.LoginUserDTO true "User login credentials"
 // @Success 200 {object} map[string]string "Authentication successful"
-// @Failure 400 {object} map[string]string "Bad request"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem "Bad request"
+// @Failure 401 {object} problem "Invalid username or password"
+// @Failure 500 {object} problem "Internal server error"
 // @Router /users/login [post]
 func (r *userRoutes) LoginUser(c *gin.Context) {
 	var input entity.LoginUserDTO
 
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		bindingProblem(c, err)
 		return
 	}
 
-	token, err := r.t.LoginUser(&input)
+	token, err := r.t.LoginUser(c.Request.Context(), &input)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		errorProblem(c, r.l, err, "http - v1 - LoginUser")
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"token": token})
@@ -65,18 +67,19 @@ func (r *userRoutes) LoginUser(c *gin.Context) {
 // @Produce json
 // @Param user body entity.CreateUserDTO true "User registration data"
 // @Success 201 {object} map[string]interface{} "User registered successfully"
-// @Failure 400 {object} map[string]string "Bad request"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} problem "Bad request"
+// @Failure 409 {object} problem "Username or email already registered"
+// @Failure 500 {object} problem "Internal server error"
 // @Router /users [post]
 func (r *userRoutes) RegisterUser(c *gin.Context) {
 	var createUserDTO entity.CreateUserDTO
 	if err := c.ShouldBindJSON(&createUserDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		bindingProblem(c, err)
 		return
 	}
 	hashedPassword, err := utils.HashPassword(createUserDTO.Password)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error hashing password"})
+		errorProblem(c, r.l, err, "http - v1 - RegisterUser")
 		return
 	}
 
@@ -87,7 +90,11 @@ func (r *userRoutes) RegisterUser(c *gin.Context) {
 		Role:     "user",
 	}
 
-	createdUser, err := r.t.RegisterUser(&user)
+	createdUser, err := r.t.RegisterUser(c.Request.Context(), &user)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - RegisterUser")
+		return
+	}
 
 	c.JSON(http.StatusCreated, gin.H{"message": "User registered successfully", "User": createdUser})
 }
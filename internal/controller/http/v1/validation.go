@@ -0,0 +1,183 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase"
+	"tourism-backend/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// _moneyScale is the number of cents in a unit of money, prices may not be more precise.
+const _moneyScale = 100
+
+// registerValidators adds the rules request DTOs use besides the built-in ones to the validator
+// gin binds requests with, and makes rejected fields be reported by their JSON or form names.
+func registerValidators() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return fmt.Errorf("register validators: unexpected validator engine %T", binding.Validator.Engine())
+	}
+	v.RegisterTagNameFunc(fieldName)
+
+	rules := map[string]validator.Func{
+		"future": isFuture,
+		"money":  isMoney,
+		"whole":  isWhole,
+	}
+	for tag, rule := range rules {
+		if err := v.RegisterValidation(tag, rule); err != nil {
+			return fmt.Errorf("register validators: %s: %w", tag, err)
+		}
+	}
+	return nil
+}
+
+// fieldName is the name clients know a field by: its JSON name, or its form name for form DTOs.
+func fieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// isFuture accepts a time that is after now.
+func isFuture(fl validator.FieldLevel) bool {
+	t, ok := fl.Field().Interface().(time.Time)
+	return ok && t.After(time.Now())
+}
+
+// isMoney accepts a positive amount with at most two decimal places.
+func isMoney(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int() > 0
+	case reflect.Float32, reflect.Float64:
+		cents := field.Float() * _moneyScale
+		return field.Float() > 0 && math.Abs(cents-math.Round(cents)) < 1e-6
+	default:
+		return false
+	}
+}
+
+// isWhole accepts a number without a fractional part.
+func isWhole(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return field.Float() == math.Trunc(field.Float())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+// requestValidator binds request DTOs and checks them before they reach the use cases. Binding
+// tags hold the rules on the values alone, exists tags name the kind of record a UUID field refers to.
+type requestValidator struct {
+	t usecase.TourismInterface
+	l logger.Interface
+}
+
+func newRequestValidator(t usecase.TourismInterface, l logger.Interface) *requestValidator {
+	return &requestValidator{t, l}
+}
+
+// bind binds the request into obj with b and validates it. It answers the request with a
+// problem and returns false when obj is invalid or refers to records that do not exist.
+func (v *requestValidator) bind(c *gin.Context, obj any, b binding.Binding, op string) bool {
+	if err := c.ShouldBindWith(obj, b); err != nil {
+		bindingProblem(c, err)
+		return false
+	}
+
+	fields, err := v.missing(c.Request.Context(), obj)
+	if err != nil {
+		errorProblem(c, v.l, err, op)
+		return false
+	}
+	if len(fields) > 0 {
+		errorResponse(c, http.StatusBadRequest, _codeInvalidRequest, "request validation failed", fields...)
+		return false
+	}
+	return true
+}
+
+// missing looks up the records the exists tags of obj refer to and lists the fields whose record
+// does not exist. Unset IDs are left to the required rule.
+func (v *requestValidator) missing(ctx context.Context, obj any) ([]entity.FieldError, error) {
+	value := reflect.Indirect(reflect.ValueOf(obj))
+	if value.Kind() != reflect.Struct {
+		return nil, nil
+	}
+
+	var fields []entity.FieldError
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		kind := field.Tag.Get("exists")
+		if kind == "" {
+			continue
+		}
+
+		var id uuid.UUID
+		switch fieldValue := value.Field(i).Interface().(type) {
+		case uuid.UUID:
+			id = fieldValue
+		case *uuid.UUID:
+			if fieldValue != nil {
+				id = *fieldValue
+			}
+		default:
+			return nil, fmt.Errorf("validate %s: exists tag on %T", field.Name, fieldValue)
+		}
+		if id == uuid.Nil {
+			continue
+		}
+
+		found, err := v.exists(ctx, kind, id)
+		if err != nil {
+			return nil, fmt.Errorf("validate %s: %w", field.Name, err)
+		}
+		if !found {
+			name := fieldName(field)
+			fields = append(fields, entity.FieldError{
+				Field:   name,
+				Code:    "exists",
+				Message: fmt.Sprintf("%s %s does not exist", strings.ReplaceAll(kind, "_", " "), id),
+			})
+		}
+	}
+	return fields, nil
+}
+
+// exists reports whether the record of the given kind exists.
+func (v *requestValidator) exists(ctx context.Context, kind string, id uuid.UUID) (bool, error) {
+	switch kind {
+	case "tour":
+		return v.t.TourExists(ctx, id)
+	case "tour_event":
+		return v.t.TourEventExists(ctx, id)
+	case "category":
+		return v.t.CategoryExists(ctx, id)
+	default:
+		return false, fmt.Errorf("unknown exists kind %q", kind)
+	}
+}
@@ -0,0 +1,122 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase"
+	"tourism-backend/internal/usecase/repo/inmemory"
+	"tourism-backend/pkg/logger"
+)
+
+func TestValidators(t *testing.T) {
+	require.NoError(t, registerValidators())
+
+	type future struct {
+		At time.Time `binding:"future"`
+	}
+	type money struct {
+		Float float64 `binding:"money"`
+		Int   int     `binding:"money"`
+	}
+	type whole struct {
+		Float float64 `binding:"whole"`
+		Int   int     `binding:"whole"`
+	}
+
+	tests := []struct {
+		name  string
+		obj   any
+		valid bool
+	}{
+		{name: "future time", obj: future{At: time.Now().Add(time.Hour)}, valid: true},
+		{name: "past time", obj: future{At: time.Now().Add(-time.Hour)}},
+		{name: "zero time", obj: future{}},
+		{name: "money with cents", obj: money{Float: 49.99, Int: 5}, valid: true},
+		{name: "money sum with float error", obj: money{Float: 0.1 + 0.2, Int: 1}, valid: true},
+		{name: "money below a cent", obj: money{Float: 10.001, Int: 5}},
+		{name: "zero money", obj: money{Float: 0, Int: 5}},
+		{name: "negative money", obj: money{Float: -1, Int: 5}},
+		{name: "zero int money", obj: money{Float: 1, Int: 0}},
+		{name: "whole numbers", obj: whole{Float: 20, Int: 3}, valid: true},
+		{name: "fraction", obj: whole{Float: 20.5}},
+	}
+	for _, tc := range tests {
+		err := binding.Validator.ValidateStruct(tc.obj)
+		if tc.valid {
+			require.NoError(t, err, tc.name)
+		} else {
+			require.Error(t, err, tc.name)
+		}
+	}
+}
+
+func TestRequestValidatorExists(t *testing.T) {
+	require.NoError(t, registerValidators())
+	gin.SetMode(gin.TestMode)
+
+	ctx := context.Background()
+	repo := inmemory.NewTourismRepo()
+	tourism := usecase.NewTourismUseCase(repo, inmemory.Transactor{}, usecase.NewAuditUseCase(inmemory.NewAuditRepo()), nil, nil)
+	v := newRequestValidator(tourism, logger.New("error"))
+
+	tour, err := repo.CreateTour(ctx, &entity.Tour{Description: "Old town walk", OwnerID: uuid.New()}, nil, nil)
+	require.NoError(t, err)
+	category := &entity.Category{Name: "Hiking", Slug: "hiking"}
+	require.NoError(t, repo.CreateCategory(ctx, category))
+	missingID := uuid.New()
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		fields []entity.FieldError
+	}{
+		{
+			name:   "existing records",
+			body:   `{"tour_id":"` + tour.ID.String() + `","category_id":"` + category.ID.String() + `"}`,
+			status: http.StatusOK,
+		},
+		{
+			name:   "missing category",
+			body:   `{"tour_id":"` + tour.ID.String() + `","category_id":"` + missingID.String() + `"}`,
+			status: http.StatusBadRequest,
+			fields: []entity.FieldError{{Field: "category_id", Code: "exists", Message: "category " + missingID.String() + " does not exist"}},
+		},
+		{
+			name:   "unset ids are left to the required rule",
+			body:   `{"tour_id":"` + tour.ID.String() + `"}`,
+			status: http.StatusBadRequest,
+			fields: []entity.FieldError{{Field: "category_id", Code: "required", Message: "category_id is required"}},
+		},
+	}
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/tours/categories", strings.NewReader(tc.body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		var dto entity.CreateTourCategoryDTO
+		if v.bind(c, &dto, binding.JSON, "test") {
+			c.Status(http.StatusOK)
+		}
+		require.Equal(t, tc.status, w.Code, tc.name)
+		if tc.fields == nil {
+			continue
+		}
+		var body problem
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body), tc.name)
+		require.Equal(t, tc.fields, body.Errors, tc.name)
+	}
+}
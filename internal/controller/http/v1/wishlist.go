@@ -0,0 +1,217 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase"
+	"tourism-backend/pkg/logger"
+	"tourism-backend/utils"
+)
+
+type wishlistRoutes struct {
+	t usecase.WishlistInterface
+	l logger.Interface
+}
+
+func newWishlistRoutes(handler *gin.RouterGroup, t usecase.WishlistInterface, l logger.Interface) {
+	r := &wishlistRoutes{t, l}
+
+	h := handler.Group("/users")
+	h.Use(utils.JWTAuthMiddleware())
+	{
+		h.GET("/favorites", r.GetFavorites)
+		h.POST("/favorites/:tour_id", r.AddFavorite)
+		h.DELETE("/favorites/:tour_id", r.RemoveFavorite)
+
+		h.GET("/saved-searches", r.GetSavedSearches)
+		h.POST("/saved-searches", r.CreateSavedSearch)
+		h.DELETE("/saved-searches/:id", r.DeleteSavedSearch)
+
+		h.GET("/notifications", r.GetNotifications)
+		h.POST("/notifications/:id/read", r.MarkNotificationRead)
+	}
+}
+
+// GetFavorites retrieves the current user's favorite tours.
+// @Summary Get favorite tours
+// @Tags wishlist
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} entity.Favorite
+// @Failure 500 {object} problem
+// @Router /users/favorites [get]
+func (r *wishlistRoutes) GetFavorites(c *gin.Context) {
+	favorites, err := r.t.GetFavorites(c.Request.Context(), utils.GetUserIDFromContext(c))
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - GetFavorites")
+		return
+	}
+
+	c.JSON(http.StatusOK, favorites)
+}
+
+// AddFavorite bookmarks a tour.
+// @Summary Add a tour to favorites
+// @Tags wishlist
+// @Produce json
+// @Security BearerAuth
+// @Param tour_id path string true "Tour ID"
+// @Success 201 {object} entity.Favorite
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Router /users/favorites/{tour_id} [post]
+func (r *wishlistRoutes) AddFavorite(c *gin.Context) {
+	tourID, err := uuid.Parse(c.Param("tour_id"))
+	if err != nil {
+		invalidID(c, "tour")
+		return
+	}
+
+	favorite, err := r.t.AddFavorite(c.Request.Context(), utils.GetUserIDFromContext(c), tourID)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - AddFavorite")
+		return
+	}
+
+	c.JSON(http.StatusCreated, favorite)
+}
+
+// RemoveFavorite removes a tour from favorites.
+// @Summary Remove a tour from favorites
+// @Tags wishlist
+// @Security BearerAuth
+// @Param tour_id path string true "Tour ID"
+// @Success 204
+// @Failure 400 {object} problem
+// @Router /users/favorites/{tour_id} [delete]
+func (r *wishlistRoutes) RemoveFavorite(c *gin.Context) {
+	tourID, err := uuid.Parse(c.Param("tour_id"))
+	if err != nil {
+		invalidID(c, "tour")
+		return
+	}
+
+	if err := r.t.RemoveFavorite(c.Request.Context(), utils.GetUserIDFromContext(c), tourID); err != nil {
+		errorProblem(c, r.l, err, "http - v1 - RemoveFavorite")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetSavedSearches retrieves the current user's saved searches.
+// @Summary Get saved searches
+// @Tags wishlist
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} entity.SavedSearch
+// @Failure 500 {object} problem
+// @Router /users/saved-searches [get]
+func (r *wishlistRoutes) GetSavedSearches(c *gin.Context) {
+	savedSearches, err := r.t.GetSavedSearches(c.Request.Context(), utils.GetUserIDFromContext(c))
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - GetSavedSearches")
+		return
+	}
+
+	c.JSON(http.StatusOK, savedSearches)
+}
+
+// CreateSavedSearch saves a tour event filter.
+// @Summary Save a search
+// @Description Saves a tour event filter. Events that match it now are considered seen; new matching events and price drops create notifications.
+// @Tags wishlist
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param search body entity.CreateSavedSearchDTO true "Saved search"
+// @Success 201 {object} entity.SavedSearch
+// @Failure 400 {object} problem
+// @Failure 500 {object} problem
+// @Router /users/saved-searches [post]
+func (r *wishlistRoutes) CreateSavedSearch(c *gin.Context) {
+	var createSavedSearchDTO entity.CreateSavedSearchDTO
+	if err := c.ShouldBindJSON(&createSavedSearchDTO); err != nil {
+		bindingProblem(c, err)
+		return
+	}
+
+	savedSearch, err := r.t.CreateSavedSearch(c.Request.Context(), utils.GetUserIDFromContext(c), &createSavedSearchDTO)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - CreateSavedSearch")
+		return
+	}
+
+	c.JSON(http.StatusCreated, savedSearch)
+}
+
+// DeleteSavedSearch deletes a saved search.
+// @Summary Delete a saved search
+// @Tags wishlist
+// @Security BearerAuth
+// @Param id path string true "Saved search ID"
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Router /users/saved-searches/{id} [delete]
+func (r *wishlistRoutes) DeleteSavedSearch(c *gin.Context) {
+	savedSearchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "saved search")
+		return
+	}
+
+	err = r.t.DeleteSavedSearch(c.Request.Context(), utils.GetUserIDFromContext(c), savedSearchID)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - DeleteSavedSearch")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetNotifications retrieves the current user's notifications.
+// @Summary Get notifications
+// @Tags wishlist
+// @Produce json
+// @Security BearerAuth
+// @Param unread query bool false "Only unread notifications"
+// @Success 200 {array} entity.Notification
+// @Failure 500 {object} problem
+// @Router /users/notifications [get]
+func (r *wishlistRoutes) GetNotifications(c *gin.Context) {
+	unreadOnly := c.Query("unread") == "true"
+
+	notifications, err := r.t.GetNotifications(c.Request.Context(), utils.GetUserIDFromContext(c), unreadOnly)
+	if err != nil {
+		errorProblem(c, r.l, err, "http - v1 - GetNotifications")
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+}
+
+// MarkNotificationRead marks a notification as read.
+// @Summary Mark notification as read
+// @Tags wishlist
+// @Security BearerAuth
+// @Param id path string true "Notification ID"
+// @Success 204
+// @Failure 400 {object} problem
+// @Router /users/notifications/{id}/read [post]
+func (r *wishlistRoutes) MarkNotificationRead(c *gin.Context) {
+	notificationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		invalidID(c, "notification")
+		return
+	}
+
+	if err := r.t.MarkNotificationRead(c.Request.Context(), utils.GetUserIDFromContext(c), notificationID); err != nil {
+		errorProblem(c, r.l, err, "http - v1 - MarkNotificationRead")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
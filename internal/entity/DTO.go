@@ -6,46 +6,73 @@ import (
 )
 
 type TourPurchaseRequest struct {
-	TourEventID uuid.UUID `json:"tour_event_id"`
+	TourEventID uuid.UUID `json:"tour_event_id" binding:"required" exists:"tour_event" format:"uuid"`
 }
 
 type CreateTourDTO struct {
-	Description string `json:"description"`
-	Route       string `json:"route"`
-	Price       int    `json:"price"`
+	Description string `form:"description" json:"description" binding:"required,max=5000"`
+	Route       string `form:"route" json:"route" binding:"required,max=5000"`
+	// Language of the description and route, defaults to the source content language.
+	Language string `form:"language" json:"language" binding:"max=16"`
 	//TourImages  []Image   `json:"tour_images"`
 	//TourVideos  []Video   `json:"tour_videos"`
 }
 
 type CreateUserDTO struct {
-	Username string `json:"username" binding:"required"`
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=6"`
+	Username string `json:"username" binding:"required,max=100"`
+	Email    string `json:"email" binding:"required,email,max=254"`
+	// Password is hashed with bcrypt, which reads at most 72 bytes.
+	Password string `json:"password" binding:"required,min=6,max=72"`
 	Role     string `json:"role"` // Optional: "user" (default) or "admin"
 }
 
 type LoginUserDTO struct {
-	Username string `json:"username" binding:"required"`
-	Password string `json:"password" binding:"required"`
+	Username string `json:"username" binding:"required,max=100"`
+	Password string `json:"password" binding:"required,max=72"`
 }
 
 type CreateTourEventDTO struct {
-	Date           time.Time `json:"date" gorm:"not null"`
-	Price          float64   `json:"price" gorm:"not null"`
-	Place          string    `json:"place" gorm:"not null"`
-	TourID         uuid.UUID `json:"tour_id" gorm:"type:uuid;index"`
-	AmountOfPlaces float64   `json:"amount_of_places" gorm:"not null"`
+	// Date must be in the future.
+	Date time.Time `json:"date" binding:"required,future" format:"date-time"`
+	// Price is positive with at most two decimal places.
+	Price float64 `json:"price" binding:"required,money" minimum:"0.01" example:"49.99"`
+	Place string  `json:"place" binding:"required,max=500"`
+	// TourID must refer to an existing tour.
+	TourID uuid.UUID `json:"tour_id" binding:"required" exists:"tour" format:"uuid"`
+	// AmountOfPlaces is a whole number of places.
+	AmountOfPlaces float64 `json:"amount_of_places" binding:"required,whole,min=1,max=10000" example:"20"`
 }
 
 type CreateTourCategoryDTO struct {
-	TourID     uuid.UUID `json:"tour_id" gorm:"type:uuid;index"`
-	CategoryID uuid.UUID `json:"category_id" gorm:"type:uuid;index"`
+	// TourID must refer to an existing tour.
+	TourID uuid.UUID `json:"tour_id" binding:"required" exists:"tour" format:"uuid"`
+	// CategoryID must refer to an existing category.
+	CategoryID uuid.UUID `json:"category_id" binding:"required" exists:"category" format:"uuid"`
+}
+
+type CreateCategoryDTO struct {
+	Name string `json:"name" binding:"required,max=200"`
+	// Slug defaults to the name in latin letters, cyrillic letters are transliterated. Names without either get a slug from the ID.
+	Slug      string     `json:"slug" binding:"max=100"`
+	Icon      string     `json:"icon" binding:"max=500"`
+	ParentID  *uuid.UUID `json:"parent_id"`
+	SortOrder int        `json:"sort_order"`
+}
+
+// UpdateCategoryDTO changes the fields that are set. An empty parent_id moves the category to the top level.
+type UpdateCategoryDTO struct {
+	Name      *string `json:"name" binding:"omitempty,min=1,max=200"`
+	Slug      *string `json:"slug" binding:"omitempty,max=100"`
+	Icon      *string `json:"icon" binding:"omitempty,max=500"`
+	ParentID  *string `json:"parent_id"`
+	SortOrder *int    `json:"sort_order"`
 }
 
 type CreateTourLocationDTO struct {
-	TourID    uuid.UUID `json:"tour_id"`
-	Latitude  float64   `json:"latitude"`
-	Longitude float64   `json:"longitude"`
+	// TourID must refer to an existing tour.
+	TourID    uuid.UUID `json:"tour_id" binding:"required" exists:"tour" format:"uuid"`
+	Latitude  float64   `json:"latitude" binding:"min=-90,max=90" example:"41.311"`
+	Longitude float64   `json:"longitude" binding:"min=-180,max=180" example:"69.279"`
 }
 
 type TourEventFilter struct {
@@ -54,4 +81,121 @@ type TourEventFilter struct {
 	EndDate     time.Time   `json:"end_date,omitempty"`
 	MinPrice    float64     `json:"min_price,omitempty"`
 	MaxPrice    float64     `json:"max_price,omitempty"`
+	// Query matches the tour description or route in Language or one of its fallbacks.
+	Query    string `json:"q,omitempty" form:"q" binding:"max=200"`
+	Language string `json:"language,omitempty" form:"-"`
+	// Languages is the fallback chain of Language, filled in by the use case.
+	Languages []string `json:"-" form:"-"`
+}
+
+type AuditEventFilter struct {
+	ActorID    uuid.UUID `form:"actor_id"`
+	Action     string    `form:"action"`
+	Resource   string    `form:"resource"`
+	ResourceID string    `form:"resource_id"`
+	RequestID  string    `form:"request_id"`
+	From       time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit      int       `form:"limit"`
+	Offset     int       `form:"offset"`
+}
+
+type CreateReviewDTO struct {
+	Rating int    `form:"rating" binding:"required,min=1,max=5"`
+	Text   string `form:"text" binding:"max=5000"`
+}
+
+type ReplyReviewDTO struct {
+	Reply string `json:"reply" binding:"required,max=5000"`
+}
+
+type ModerateReviewDTO struct {
+	Status string `json:"status" binding:"required,oneof=published hidden"`
+}
+
+type ReviewFilter struct {
+	Sort   string `form:"sort"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+type CreateSavedSearchDTO struct {
+	Name   string          `json:"name" binding:"max=100"`
+	Filter TourEventFilter `json:"filter"`
+}
+
+// TourGeoSearchFilter selects tours by distance from a point or by a map viewport.
+type TourGeoSearchFilter struct {
+	Latitude  *float64
+	Longitude *float64
+	RadiusKm  float64
+	HasBounds bool
+	MinLat    float64
+	MinLon    float64
+	MaxLat    float64
+	MaxLon    float64
+	Limit     int
+	Events    TourEventFilter
+}
+
+func (f *TourEventFilter) IsEmpty() bool {
+	return len(f.CategoryIDs) == 0 && f.StartDate.IsZero() && f.EndDate.IsZero() && f.MinPrice == 0 && f.MaxPrice == 0
+}
+
+type RouteStopDTO struct {
+	Name            string   `json:"name" binding:"required,max=200"`
+	Description     string   `json:"description" binding:"max=5000"`
+	Latitude        float64  `json:"latitude" binding:"min=-90,max=90"`
+	Longitude       float64  `json:"longitude" binding:"min=-180,max=180"`
+	Elevation       *float64 `json:"elevation"`
+	DurationMinutes int      `json:"duration_minutes" binding:"min=0"`
+}
+
+type UpdateItineraryDTO struct {
+	Stops []RouteStopDTO `json:"stops" binding:"max=200,dive"`
+	// Track is a list of [longitude, latitude] or [longitude, latitude, elevation] positions.
+	Track [][]float64 `json:"track"`
+}
+
+type UpdateMediaDTO struct {
+	AltText *string `json:"alt_text" binding:"omitempty,max=300"`
+	Caption *string `json:"caption" binding:"omitempty,max=1000"`
+}
+
+// MediaMeta holds the optional texts of an uploaded image or video.
+type MediaMeta struct {
+	AltText string
+	Caption string
+}
+
+type ReorderMediaDTO struct {
+	// IDs lists every image or video of the tour in the new order.
+	IDs []uuid.UUID `json:"ids" binding:"required,min=1"`
+}
+
+// CreateUploadDTO is a tus creation request decoded from its Upload-Length and Upload-Metadata headers.
+type CreateUploadDTO struct {
+	TourID   uuid.UUID
+	Length   int64
+	Filename string
+	MediaMeta
+}
+
+type CreateDocumentDTO struct {
+	Kind   string `form:"kind" binding:"required,oneof=verification briefing"`
+	TourID string `form:"tour_id" binding:"omitempty,uuid"`
+}
+
+type DocumentFilter struct {
+	ProviderID string `form:"provider_id" binding:"omitempty,uuid"`
+	Kind       string `form:"kind" binding:"omitempty,oneof=verification briefing"`
+}
+
+type UpdateTourLocaleDTO struct {
+	Description *string `json:"description" binding:"omitempty,max=5000"`
+	Route       *string `json:"route" binding:"omitempty,max=5000"`
+}
+
+type UpdateCategoryLocaleDTO struct {
+	Name string `json:"name" binding:"required,max=200"`
 }
@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEvent is an append-only record of a privileged or financial action.
+// Rows are chained together: Hash covers the event fields and PrevHash, so
+// changing or removing any row breaks every hash that follows it.
+type AuditEvent struct {
+	ID         uuid.UUID `json:"ID" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	Seq        int64     `json:"seq" gorm:"uniqueIndex;not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"index;not null"`
+	ActorID    uuid.UUID `json:"actor_id" gorm:"type:uuid;index"`
+	ActorRole  string    `json:"actor_role"`
+	Action     string    `json:"action" gorm:"index;not null"`
+	Resource   string    `json:"resource" gorm:"index;not null"`
+	ResourceID string    `json:"resource_id" gorm:"index"`
+	Before     string    `json:"before" gorm:"type:text"`
+	After      string    `json:"after" gorm:"type:text"`
+	Diff       string    `json:"diff" gorm:"type:text"`
+	IP         string    `json:"ip"`
+	RequestID  string    `json:"request_id" gorm:"index"`
+	PrevHash   string    `json:"prev_hash" gorm:"not null"`
+	Hash       string    `json:"hash" gorm:"uniqueIndex;not null"`
+}
+
+// AuditActor describes who performed an audited action and from where.
+type AuditActor struct {
+	UserID    uuid.UUID
+	Role      string
+	IP        string
+	RequestID string
+}
+
+// SystemActor is used for actions performed by background workers.
+func SystemActor(name string) AuditActor {
+	return AuditActor{Role: "system:" + name}
+}
+
+// Audit actions.
+const (
+	AuditActionTourCreate         = "tour.create"
+	AuditActionTourEventCreate    = "tour_event.create"
+	AuditActionTourCategoryCreate = "tour_category.create"
+	AuditActionTourLocationCreate = "tour_location.create"
+	AuditActionPurchaseCreate     = "purchase.create"
+	AuditActionPurchasePay        = "purchase.pay"
+	AuditActionPurchaseFail       = "purchase.fail"
+	AuditActionAdminListUsers     = "admin.users.list"
+	AuditActionReviewModerate     = "review.moderate"
+	AuditActionReviewDelete       = "review.delete"
+	AuditActionItineraryUpdate    = "tour.itinerary.update"
+	AuditActionTourMediaAdd       = "tour.media.add"
+	AuditActionTourMediaDelete    = "tour.media.delete"
+	AuditActionDocumentUpload     = "document.upload"
+	AuditActionDocumentDelete     = "document.delete"
+	AuditActionDocumentView       = "document.view"
+	AuditActionTranslationUpdate  = "translation.update"
+	AuditActionTranslationDelete  = "translation.delete"
+	AuditActionCategoryCreate     = "category.create"
+	AuditActionCategoryUpdate     = "category.update"
+	AuditActionCategoryDelete     = "category.delete"
+)
+
+// ComputeHash returns the SHA-256 of the event fields chained to PrevHash.
+func (e *AuditEvent) ComputeHash() string {
+	fields := []string{
+		e.PrevHash,
+		fmt.Sprintf("%d", e.Seq),
+		e.CreatedAt.UTC().Format(time.RFC3339Nano),
+		e.ActorID.String(),
+		e.ActorRole,
+		e.Action,
+		e.Resource,
+		e.ResourceID,
+		e.Before,
+		e.After,
+		e.Diff,
+		e.IP,
+		e.RequestID,
+	}
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x1f")))
+	return hex.EncodeToString(sum[:])
+}
+
+// AuditIntegrityReport is the result of walking the audit hash chain.
+type AuditIntegrityReport struct {
+	Valid       bool   `json:"valid"`
+	Checked     int64  `json:"checked"`
+	BrokenAtSeq int64  `json:"broken_at_seq,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+}
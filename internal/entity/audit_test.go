@@ -0,0 +1,43 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"tourism-backend/internal/entity"
+)
+
+func TestAuditEventComputeHash(t *testing.T) {
+	t.Parallel()
+
+	first := &entity.AuditEvent{
+		Seq:        1,
+		CreatedAt:  time.Date(2025, 1, 2, 3, 4, 5, 6000, time.UTC),
+		ActorID:    uuid.New(),
+		Action:     entity.AuditActionTourEventCreate,
+		Resource:   "tour_event",
+		ResourceID: uuid.NewString(),
+		Before:     "null",
+		After:      `{"price":100}`,
+	}
+	first.Hash = first.ComputeHash()
+
+	second := *first
+	second.Seq = 2
+	second.PrevHash = first.Hash
+	second.Hash = second.ComputeHash()
+
+	require.NotEqual(t, first.Hash, second.Hash)
+	require.Equal(t, first.Hash, first.ComputeHash())
+
+	tampered := *first
+	tampered.After = `{"price":1}`
+	require.NotEqual(t, first.Hash, tampered.ComputeHash())
+
+	relinked := second
+	relinked.PrevHash = tampered.ComputeHash()
+	require.NotEqual(t, second.Hash, relinked.ComputeHash())
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// MediaBlob is a stored object shared by every row that uploaded the same content.
+// Its key is derived from the SHA-256 of the content, RefCount counts the image, video,
+// review photo and document rows referencing it.
+type MediaBlob struct {
+	Key         string    `json:"key" gorm:"primaryKey"`
+	SHA256      string    `json:"sha256" gorm:"column:sha256;index;not null"`
+	Size        int64     `json:"size"`
+	ContentType string    `json:"content_type"`
+	RefCount    int       `json:"ref_count" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
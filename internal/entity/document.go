@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Document kinds.
+const (
+	DocumentKindVerification = "verification"
+	DocumentKindBriefing     = "briefing"
+)
+
+// Document is a private provider file, such as a verification document or an internal tour briefing.
+// It is stored under the private media prefix and only downloadable through signed, expiring URLs.
+type Document struct {
+	gorm.Model  `swaggerignore:"true"`
+	ID          uuid.UUID  `json:"ID" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	ProviderID  uuid.UUID  `json:"provider_id" gorm:"type:uuid;index;not null"`
+	TourID      *uuid.UUID `json:"tour_id,omitempty" gorm:"type:uuid;index"`
+	Tour        *Tour      `json:"-" gorm:"foreignKey:TourID;constraint:OnDelete:CASCADE;"`
+	Kind        string     `json:"kind" gorm:"not null"`
+	Filename    string     `json:"filename"`
+	ContentType string     `json:"content_type"`
+	Size        int64      `json:"size"`
+	// Key is the media store key, it is never exposed since the object must not be fetched unsigned.
+	Key string `json:"-" gorm:"not null"`
+	// URL is a signed download address valid until URLExpiresAt.
+	URL          string     `json:"url" gorm:"-"`
+	URLExpiresAt *time.Time `json:"url_expires_at,omitempty" gorm:"-"`
+}
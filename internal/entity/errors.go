@@ -0,0 +1,104 @@
+package entity
+
+import "strings"
+
+// Domain errors carry a stable Code for API clients and a Message that is safe to show them.
+// Use cases return them, possibly wrapped, and the HTTP layer maps each type to a status.
+
+// NotFoundError is returned when the requested resource does not exist.
+type NotFoundError struct {
+	Code    string
+	Message string
+}
+
+func (e *NotFoundError) Error() string { return e.Message }
+
+// ConflictError is returned when a request clashes with the current state, like a taken slug.
+type ConflictError struct {
+	Code    string
+	Message string
+}
+
+func (e *ConflictError) Error() string { return e.Message }
+
+// ForbiddenError is returned when the user may not act on a resource.
+type ForbiddenError struct {
+	Code    string
+	Message string
+}
+
+func (e *ForbiddenError) Error() string { return e.Message }
+
+// UnauthorizedError is returned when the user could not be authenticated.
+type UnauthorizedError struct {
+	Code    string
+	Message string
+}
+
+func (e *UnauthorizedError) Error() string { return e.Message }
+
+// SoldOutError is returned when a tour event has no places left or is closed for booking.
+type SoldOutError struct {
+	Code    string
+	Message string
+}
+
+func (e *SoldOutError) Error() string { return e.Message }
+
+// FieldError describes why one input field was rejected.
+type FieldError struct {
+	Field   string `json:"field" example:"tour_id"`
+	Code    string `json:"code" example:"required"`
+	Message string `json:"message" example:"tour_id is required"`
+}
+
+// ValidationError is returned when the input is invalid. Fields lists the rejected fields, if known.
+type ValidationError struct {
+	Code    string
+	Message string
+	Fields  []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Message
+	}
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Message)
+	}
+	return e.Message + ": " + strings.Join(messages, "; ")
+}
+
+// NewNotFoundError -.
+func NewNotFoundError(code, message string) *NotFoundError {
+	return &NotFoundError{Code: code, Message: message}
+}
+
+// NewConflictError -.
+func NewConflictError(code, message string) *ConflictError {
+	return &ConflictError{Code: code, Message: message}
+}
+
+// NewForbiddenError -.
+func NewForbiddenError(code, message string) *ForbiddenError {
+	return &ForbiddenError{Code: code, Message: message}
+}
+
+// NewUnauthorizedError -.
+func NewUnauthorizedError(code, message string) *UnauthorizedError {
+	return &UnauthorizedError{Code: code, Message: message}
+}
+
+// NewSoldOutError -.
+func NewSoldOutError(code, message string) *SoldOutError {
+	return &SoldOutError{Code: code, Message: message}
+}
+
+// NewValidationError -.
+func NewValidationError(code, message string, fields ...FieldError) *ValidationError {
+	return &ValidationError{Code: code, Message: message, Fields: fields}
+}
+
+// ErrTourEventSoldOut is returned when a purchase is made for a full or closed tour event.
+var ErrTourEventSoldOut = NewSoldOutError("tour_event_sold_out", "tour event has no places left or is closed")
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RouteStop is one stop of a tour itinerary.
+type RouteStop struct {
+	gorm.Model      `swaggerignore:"true"`
+	ID              uuid.UUID `json:"ID" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	TourID          uuid.UUID `json:"tour_id" gorm:"type:uuid;index:idx_route_stops_tour_position;not null"`
+	Tour            Tour      `json:"-" gorm:"foreignKey:TourID;constraint:OnDelete:CASCADE;"`
+	Position        int       `json:"position" gorm:"index:idx_route_stops_tour_position;not null"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	Latitude        float64   `json:"latitude"`
+	Longitude       float64   `json:"longitude"`
+	Elevation       *float64  `json:"elevation,omitempty"`
+	DurationMinutes int       `json:"duration_minutes"`
+}
+
+// RouteTrack is the optional track polyline of a tour, stored as GeoJSON positions.
+type RouteTrack struct {
+	gorm.Model `swaggerignore:"true"`
+	ID         uuid.UUID `json:"ID" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	TourID     uuid.UUID `json:"tour_id" gorm:"type:uuid;uniqueIndex;not null"`
+	Tour       Tour      `json:"-" gorm:"foreignKey:TourID;constraint:OnDelete:CASCADE;"`
+	Points     string    `json:"-" gorm:"type:text;not null"`
+}
+
+// Itinerary is the ordered stops and track of a tour with server-computed totals.
+type Itinerary struct {
+	TourID         uuid.UUID   `json:"tour_id"`
+	Name           string      `json:"name,omitempty"`
+	Stops          []RouteStop `json:"stops"`
+	Track          [][]float64 `json:"track"`
+	DistanceKm     float64     `json:"distance_km"`
+	ElevationGainM float64     `json:"elevation_gain_m"`
+}
+
+// Itinerary export formats.
+const (
+	ItineraryFormatGPX     = "gpx"
+	ItineraryFormatGeoJSON = "geojson"
+)
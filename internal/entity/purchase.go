@@ -14,3 +14,11 @@ type Purchase struct {
 	TourEventID uuid.UUID `json:"TourEventID"`
 	Status      string    `json:"Status"`
 }
+
+// Purchase statuses.
+const (
+	PurchaseStatusProcessing = "Processing"
+	PurchaseStatusPaid       = "Paid"
+	// PurchaseStatusFailed is a purchase whose payment did not go through, its seat is released.
+	PurchaseStatusFailed = "Failed"
+)
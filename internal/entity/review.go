@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type Review struct {
+	gorm.Model   `swaggerignore:"true"`
+	ID           uuid.UUID     `json:"ID" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	TourID       uuid.UUID     `json:"tour_id" gorm:"type:uuid;uniqueIndex:idx_review_tour_user;not null"`
+	Tour         Tour          `json:"-" gorm:"foreignKey:TourID;constraint:OnDelete:CASCADE;"`
+	UserID       uuid.UUID     `json:"user_id" gorm:"type:uuid;uniqueIndex:idx_review_tour_user;not null"`
+	User         User          `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	Rating       int           `json:"rating" gorm:"not null"`
+	Text         string        `json:"text"`
+	Status       string        `json:"status" gorm:"not null;default:published;index"`
+	Reply        string        `json:"reply,omitempty"`
+	RepliedAt    *time.Time    `json:"replied_at,omitempty"`
+	ReviewPhotos []ReviewPhoto `json:"photos" gorm:"foreignKey:ReviewID;references:ID;constraint:OnDelete:CASCADE;"`
+}
+
+type ReviewPhoto struct {
+	gorm.Model `swaggerignore:"true"`
+	ID         uuid.UUID `json:"ID" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	ReviewID   uuid.UUID `json:"review_id" gorm:"type:uuid;index"`
+	// ImageURL is the media store key of the photo.
+	ImageURL string `json:"image_url"`
+	// URL is the download address generated by the media store.
+	URL string `json:"url" gorm:"-"`
+}
+
+// Review statuses.
+const (
+	ReviewStatusPublished = "published"
+	ReviewStatusHidden    = "hidden"
+)
+
+// Review sort orders.
+const (
+	ReviewSortNewest     = "newest"
+	ReviewSortOldest     = "oldest"
+	ReviewSortRatingHigh = "rating_desc"
+	ReviewSortRatingLow  = "rating_asc"
+)
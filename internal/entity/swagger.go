@@ -6,25 +6,51 @@ import (
 )
 
 type TourDocs struct {
-	ID          uuid.UUID   `json:"ID" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
-	CreatedAt   time.Time   `json:"created_at"`
-	UpdatedAt   time.Time   `json:"updated_at"`
-	DeletedAt   *time.Time  `json:"deleted_at,omitempty" gorm:"index"`
-	Description string      `json:"description"`
-	Route       string      `json:"route"`
-	Price       int         `json:"price"`
-	TourImages  []ImageDocs `json:"tour_images" gorm:"foreignKey:TourID;references:ID"`
-	TourVideos  []VideoDocs `json:"tour_videos" gorm:"foreignKey:TourID;references:ID"`
+	ID            uuid.UUID   `json:"ID" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+	DeletedAt     *time.Time  `json:"deleted_at,omitempty" gorm:"index"`
+	Description   string      `json:"description"`
+	Route         string      `json:"route"`
+	Language      string      `json:"language"`
+	Price         int         `json:"price"`
+	AverageRating float64     `json:"average_rating"`
+	ReviewCount   int64       `json:"review_count"`
+	TourImages    []ImageDocs `json:"tour_images" gorm:"foreignKey:TourID;references:ID"`
+	TourVideos    []VideoDocs `json:"tour_videos" gorm:"foreignKey:TourID;references:ID"`
 }
 
 type ImageDocs struct {
-	ID       uuid.UUID `json:"ID" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
-	TourID   uuid.UUID `json:"tour_id" gorm:"type:uuid;index"`
-	ImageURL string    `json:"image_bytes"`
+	ID       uuid.UUID          `json:"ID" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	TourID   uuid.UUID          `json:"tour_id" gorm:"type:uuid;index"`
+	ImageURL string             `json:"image_bytes"`
+	URL      string             `json:"url"`
+	Position int                `json:"position"`
+	IsCover  bool               `json:"is_cover"`
+	AltText  string             `json:"alt_text"`
+	Caption  string             `json:"caption"`
+	Status   string             `json:"status"`
+	Width    int                `json:"width"`
+	Height   int                `json:"height"`
+	Blurhash string             `json:"blurhash"`
+	Variants []ImageVariantDocs `json:"variants"`
+	SrcSet   string             `json:"srcset"`
+}
+
+type ImageVariantDocs struct {
+	Kind   string `json:"kind" example:"responsive"`
+	Format string `json:"format" example:"jpeg"`
+	Width  int    `json:"width" example:"640"`
+	Height int    `json:"height" example:"427"`
+	URL    string `json:"url"`
 }
 
 type VideoDocs struct {
 	ID       uuid.UUID `json:"ID" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
 	TourID   uuid.UUID `json:"tour_id" gorm:"type:uuid;index"`
 	VideoURL string    `json:"video_bytes"`
+	URL      string    `json:"url"`
+	Position int       `json:"position"`
+	AltText  string    `json:"alt_text"`
+	Caption  string    `json:"caption"`
 }
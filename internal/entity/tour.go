@@ -10,7 +10,18 @@ type Tour struct {
 	ID          uuid.UUID `json:"ID" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
 	Description string    `json:"description"`
 	Route       string    `json:"route"`
-	OwnerID     uuid.UUID `json:"owner_id" gorm:"type:uuid;index"`
+	// Language the tour texts are written in, empty for the default content language.
+	Language string    `json:"language"`
+	OwnerID  uuid.UUID `json:"owner_id" gorm:"type:uuid;index"`
+
+	// Review aggregates, maintained incrementally as reviews are published or hidden.
+	AverageRating float64 `json:"average_rating" gorm:"not null;default:0"`
+	ReviewCount   int64   `json:"review_count" gorm:"not null;default:0"`
+	RatingSum     int64   `json:"-" gorm:"not null;default:0"`
+
+	// Itinerary totals, computed from the route track or stops.
+	RouteDistanceKm     float64 `json:"route_distance_km" gorm:"not null;default:0"`
+	RouteElevationGainM float64 `json:"route_elevation_gain_m" gorm:"not null;default:0"`
 
 	// Relationships
 	TourImages     []Image        `json:"tour_images" gorm:"foreignKey:TourID;references:ID;constraint:OnDelete:CASCADE;"`
@@ -21,12 +32,33 @@ type Tour struct {
 }
 
 type Category struct {
-	gorm.Model     `swaggerignore:"true"`
-	ID             uuid.UUID      `json:"ID" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
-	Name           string         `json:"name"`
+	gorm.Model `swaggerignore:"true"`
+	ID         uuid.UUID `json:"ID" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	Name       string    `json:"name"`
+	// Slug is the unique URL name of the category.
+	Slug string `json:"slug" gorm:"uniqueIndex:idx_categories_slug,where:slug <> ''"`
+	Icon string `json:"icon"`
+	// ParentID is nil for top-level categories.
+	ParentID  *uuid.UUID `json:"parent_id" gorm:"type:uuid;index"`
+	Parent    *Category  `json:"-" gorm:"foreignKey:ParentID;constraint:OnDelete:RESTRICT;"`
+	SortOrder int        `json:"sort_order" gorm:"not null;default:0"`
+
 	TourCategories []TourCategory `gorm:"foreignKey:CategoryID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
 
+// CategoryNode is a category of the category tree.
+type CategoryNode struct {
+	ID        uuid.UUID  `json:"ID"`
+	Name      string     `json:"name"`
+	Slug      string     `json:"slug"`
+	Icon      string     `json:"icon"`
+	ParentID  *uuid.UUID `json:"parent_id"`
+	SortOrder int        `json:"sort_order"`
+	// TourCount is the number of tours in the category or any of its descendants.
+	TourCount int64           `json:"tour_count"`
+	Children  []*CategoryNode `json:"children"`
+}
+
 type TourCategory struct {
 	CategoryID uuid.UUID `gorm:"primaryKey;autoIncrement:false;type:uuid;index"`
 	Category   Category  `gorm:"foreignKey:CategoryID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
@@ -41,8 +73,8 @@ type TourLocation struct {
 	TourID uuid.UUID `json:"tour_id" gorm:"type:uuid;index"`
 	Tour   Tour      `gorm:"foreignKey:TourID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
+	Latitude  float64 `json:"latitude" gorm:"index:idx_tour_locations_lat_lon"`
+	Longitude float64 `json:"longitude" gorm:"index:idx_tour_locations_lat_lon"`
 }
 
 type Image struct {
@@ -50,13 +82,83 @@ type Image struct {
 	ID         uuid.UUID `json:"ID" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
 	TourID     uuid.UUID `json:"tour_id" gorm:"type:uuid;index"`
 	Tour       Tour      `gorm:"foreignKey:TourID;constraint:OnDelete:CASCADE;"`
-	ImageURL   string    `json:"image_url"`
+	// ImageURL is the media store key of the image.
+	ImageURL string `json:"image_url"`
+	// URL is the download address generated by the media store, empty until the image is ready.
+	URL string `json:"url" gorm:"-"`
+
+	Position int    `json:"position" gorm:"not null;default:0"`
+	IsCover  bool   `json:"is_cover" gorm:"not null;default:false"`
+	AltText  string `json:"alt_text"`
+	Caption  string `json:"caption"`
+
+	Status   string         `json:"status" gorm:"not null;default:pending;index"`
+	Width    int            `json:"width"`
+	Height   int            `json:"height"`
+	Blurhash string         `json:"blurhash,omitempty"`
+	Variants []ImageVariant `json:"variants" gorm:"foreignKey:ImageID;references:ID;constraint:OnDelete:CASCADE;"`
+	// SrcSet lists the responsive variants as an HTML srcset value.
+	SrcSet string `json:"srcset,omitempty" gorm:"-"`
+}
+
+// ImageVariant is a resized, metadata-free copy of a tour image.
+type ImageVariant struct {
+	gorm.Model `swaggerignore:"true"`
+	ID         uuid.UUID `json:"ID" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	ImageID    uuid.UUID `json:"image_id" gorm:"type:uuid;index"`
+	Kind       string    `json:"kind"`
+	Format     string    `json:"format"`
+	Width      int       `json:"width"`
+	Height     int       `json:"height"`
+	Key        string    `json:"key"`
+	URL        string    `json:"url" gorm:"-"`
+}
+
+// CoverImage returns the image marked as cover, or the first image when none is.
+func (t *Tour) CoverImage() *Image {
+	for i := range t.TourImages {
+		if t.TourImages[i].IsCover {
+			return &t.TourImages[i]
+		}
+	}
+	if len(t.TourImages) > 0 {
+		return &t.TourImages[0]
+	}
+	return nil
 }
 
+// Image variant kinds.
+const (
+	ImageVariantThumbnail  = "thumbnail"
+	ImageVariantResponsive = "responsive"
+)
+
+// Image processing statuses.
+const (
+	ImageStatusPending = "pending"
+	ImageStatusReady   = "ready"
+	ImageStatusFailed  = "failed"
+)
+
 type Video struct {
 	gorm.Model `swaggerignore:"true"`
 	ID         uuid.UUID `json:"ID" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
 	TourID     uuid.UUID `json:"tour_id" gorm:"type:uuid;index"`
 	Tour       Tour      `gorm:"foreignKey:TourID;constraint:OnDelete:CASCADE;"`
-	VideoURL   string    `json:"video_url"`
+	// VideoURL is the media store key of the video.
+	VideoURL string `json:"video_url"`
+	// URL is the download address generated by the media store.
+	URL string `json:"url" gorm:"-"`
+
+	Position int    `json:"position" gorm:"not null;default:0"`
+	AltText  string `json:"alt_text"`
+	Caption  string `json:"caption"`
+}
+
+// TourGeoResult is a located tour found by a geo search.
+type TourGeoResult struct {
+	Tour       *Tour
+	Latitude   float64
+	Longitude  float64
+	DistanceKm *float64
 }
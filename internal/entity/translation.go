@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Translated resources.
+const (
+	TranslationResourceTour     = "tour"
+	TranslationResourceCategory = "category"
+)
+
+// Translated fields.
+const (
+	TranslationFieldDescription = "description"
+	TranslationFieldRoute       = "route"
+	TranslationFieldName        = "name"
+)
+
+// TourTranslation caches the machine translation of one text field of a tour or category.
+// It is keyed by the hash of the source text and is refreshed when the text changes.
+type TourTranslation struct {
+	ID         uuid.UUID `json:"ID" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Resource   string    `json:"resource" gorm:"not null;uniqueIndex:idx_tour_translations_key,priority:1"`
+	ResourceID uuid.UUID `json:"resource_id" gorm:"type:uuid;not null;uniqueIndex:idx_tour_translations_key,priority:2"`
+	Field      string    `json:"field" gorm:"not null;uniqueIndex:idx_tour_translations_key,priority:3"`
+	Language   string    `json:"language" gorm:"not null;uniqueIndex:idx_tour_translations_key,priority:4"`
+	Text       string    `json:"text"`
+	SourceHash string    `json:"-" gorm:"not null"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// TourLocale is the content of a tour written by its provider in one language.
+// Empty fields fall back to the next language of the fallback chain.
+type TourLocale struct {
+	TourID      uuid.UUID `json:"tour_id" gorm:"type:uuid;primaryKey"`
+	Tour        Tour      `json:"-" gorm:"foreignKey:TourID;constraint:OnDelete:CASCADE;"`
+	Language    string    `json:"language" gorm:"primaryKey"`
+	Description string    `json:"description"`
+	Route       string    `json:"route"`
+	// SourceHash identifies the version of the tour texts the locale was written for.
+	SourceHash string `json:"-"`
+	// Outdated reports a locale written for an earlier version of the tour texts.
+	Outdated  bool      `json:"outdated" gorm:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CategoryLocale is the name of a category in one language.
+type CategoryLocale struct {
+	CategoryID uuid.UUID `json:"category_id" gorm:"type:uuid;primaryKey"`
+	Category   Category  `json:"-" gorm:"foreignKey:CategoryID;constraint:OnDelete:CASCADE;"`
+	Language   string    `json:"language" gorm:"primaryKey"`
+	Name       string    `json:"name"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
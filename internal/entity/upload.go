@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Upload is a resumable (tus) video upload. Its bytes are collected as chunks in the media store
+// and attached to the tour as a Video once Offset reaches Length.
+type Upload struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;index;not null"`
+	TourID    uuid.UUID  `json:"tour_id" gorm:"type:uuid;index;not null"`
+	Tour      Tour       `json:"-" gorm:"foreignKey:TourID;constraint:OnDelete:CASCADE;"`
+	Length    int64      `json:"length" gorm:"not null"`
+	Offset    int64      `json:"offset" gorm:"not null;default:0"`
+	Filename  string     `json:"filename"`
+	AltText   string     `json:"alt_text"`
+	Caption   string     `json:"caption"`
+	VideoID   *uuid.UUID `json:"video_id,omitempty" gorm:"type:uuid"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"index;not null"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// Completed reports whether every byte of the upload has been received.
+func (u *Upload) Completed() bool {
+	return u.Offset == u.Length
+}
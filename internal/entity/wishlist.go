@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type Favorite struct {
+	gorm.Model `swaggerignore:"true"`
+	ID         uuid.UUID `json:"ID" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID     uuid.UUID `json:"user_id" gorm:"type:uuid;uniqueIndex:idx_favorite_user_tour;not null"`
+	TourID     uuid.UUID `json:"tour_id" gorm:"type:uuid;uniqueIndex:idx_favorite_user_tour;not null"`
+	Tour       Tour      `json:"tour" gorm:"foreignKey:TourID;constraint:OnDelete:CASCADE;"`
+}
+
+// SavedSearch is a TourEventFilter stored as JSON that is re-run periodically.
+type SavedSearch struct {
+	gorm.Model `swaggerignore:"true"`
+	ID         uuid.UUID  `json:"ID" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID     uuid.UUID  `json:"user_id" gorm:"type:uuid;index;not null"`
+	Name       string     `json:"name"`
+	Filter     string     `json:"filter" gorm:"type:text;not null"`
+	LastRunAt  *time.Time `json:"last_run_at,omitempty"`
+}
+
+// SavedSearchMatch remembers which events a saved search already reported, and at what price.
+type SavedSearchMatch struct {
+	SavedSearchID uuid.UUID `gorm:"primaryKey;type:uuid"`
+	TourEventID   uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Price         float64   `gorm:"not null"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type Notification struct {
+	gorm.Model    `swaggerignore:"true"`
+	ID            uuid.UUID  `json:"ID" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	UserID        uuid.UUID  `json:"user_id" gorm:"type:uuid;index;not null"`
+	SavedSearchID *uuid.UUID `json:"saved_search_id,omitempty" gorm:"type:uuid;index"`
+	TourEventID   uuid.UUID  `json:"tour_event_id" gorm:"type:uuid"`
+	Kind          string     `json:"kind" gorm:"not null"`
+	Price         float64    `json:"price"`
+	OldPrice      float64    `json:"old_price,omitempty"`
+	Message       string     `json:"message"`
+	ReadAt        *time.Time `json:"read_at,omitempty"`
+}
+
+// Notification kinds.
+const (
+	NotificationKindNewEvent  = "new_event"
+	NotificationKindPriceDrop = "price_drop"
+)
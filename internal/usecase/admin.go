@@ -1,27 +1,56 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
 	"tourism-backend/internal/entity"
-	"tourism-backend/internal/usecase/repo"
 )
 
 // TranslationUseCase -.
 type AdminUseCase struct {
-	repo *repo.AdminRepo
+	repo AdminRepo
+	// tx commits the reads of personal data together with their audit events.
+	tx    Transactor
+	audit *AuditUseCase
 }
 
 // NewTourismUseCase -.
-func NewAdminUseCase(r *repo.AdminRepo) *AdminUseCase {
+func NewAdminUseCase(r AdminRepo, tx Transactor, audit *AuditUseCase) *AdminUseCase {
 	return &AdminUseCase{
-		repo: r,
+		repo:  r,
+		tx:    tx,
+		audit: audit,
 	}
 }
 
-func (a *AdminUseCase) GetUsers() ([]*entity.User, error) {
-	users, err := a.repo.GetUsers()
+func (a *AdminUseCase) GetUsers(ctx context.Context, actor entity.AuditActor) ([]*entity.User, error) {
+	var users []*entity.User
+	err := a.tx.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		users, err = a.repo.GetUsers(ctx)
+		if err != nil {
+			return err
+		}
+		return a.audit.Record(ctx, actor, entity.AuditActionAdminListUsers, "user", "", nil, map[string]interface{}{"count": len(users)})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("get users: %w", err)
 	}
 	return users, nil
 }
+
+func (a *AdminUseCase) GetAuditEvents(ctx context.Context, filter *entity.AuditEventFilter) ([]*entity.AuditEvent, error) {
+	events, err := a.audit.GetAuditEvents(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("get audit events: %w", err)
+	}
+	return events, nil
+}
+
+func (a *AdminUseCase) VerifyAuditChain(ctx context.Context) (*entity.AuditIntegrityReport, error) {
+	report, err := a.audit.VerifyAuditChain(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("verify audit chain: %w", err)
+	}
+	return report, nil
+}
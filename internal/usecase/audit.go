@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"tourism-backend/internal/entity"
+)
+
+type AuditUseCase struct {
+	repo AuditRepo
+}
+
+// NewAuditUseCase -.
+func NewAuditUseCase(r AuditRepo) *AuditUseCase {
+	return &AuditUseCase{
+		repo: r,
+	}
+}
+
+// Record appends an audit event. Before and after are serialized to JSON and
+// the top-level fields that changed between them are stored as the diff.
+func (a *AuditUseCase) Record(ctx context.Context, actor entity.AuditActor, action, resource, resourceID string, before, after interface{}) error {
+	beforeJSON, beforeFields, err := auditSnapshot(before)
+	if err != nil {
+		return fmt.Errorf("audit before snapshot: %w", err)
+	}
+	afterJSON, afterFields, err := auditSnapshot(after)
+	if err != nil {
+		return fmt.Errorf("audit after snapshot: %w", err)
+	}
+	diff, err := json.Marshal(auditDiff(beforeFields, afterFields))
+	if err != nil {
+		return fmt.Errorf("audit diff: %w", err)
+	}
+
+	event := &entity.AuditEvent{
+		ActorID:    actor.UserID,
+		ActorRole:  actor.Role,
+		Action:     action,
+		Resource:   resource,
+		ResourceID: resourceID,
+		Before:     beforeJSON,
+		After:      afterJSON,
+		Diff:       string(diff),
+		IP:         actor.IP,
+		RequestID:  actor.RequestID,
+	}
+	if err := a.repo.Append(ctx, event); err != nil {
+		return fmt.Errorf("record audit event %s: %w", action, err)
+	}
+	return nil
+}
+
+func (a *AuditUseCase) GetAuditEvents(ctx context.Context, filter *entity.AuditEventFilter) ([]*entity.AuditEvent, error) {
+	return a.repo.GetAuditEvents(ctx, filter)
+}
+
+func (a *AuditUseCase) VerifyAuditChain(ctx context.Context) (*entity.AuditIntegrityReport, error) {
+	return a.repo.VerifyChain(ctx)
+}
+
+type auditChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+func auditSnapshot(v interface{}) (string, map[string]interface{}, error) {
+	if v == nil {
+		return "null", nil, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", nil, err
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		// Not an object, keep the raw value without a field breakdown.
+		return string(raw), nil, nil
+	}
+	return string(raw), fields, nil
+}
+
+func auditDiff(before, after map[string]interface{}) map[string]auditChange {
+	diff := make(map[string]auditChange)
+	for key, to := range after {
+		from, ok := before[key]
+		if !ok || !reflect.DeepEqual(from, to) {
+			diff[key] = auditChange{From: from, To: to}
+		}
+	}
+	for key, from := range before {
+		if _, ok := after[key]; !ok {
+			diff[key] = auditChange{From: from, To: nil}
+		}
+	}
+	return diff
+}
@@ -0,0 +1,310 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"tourism-backend/internal/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidSlug is returned for slugs other than lowercase latin words and digits joined by dashes.
+	ErrInvalidSlug = entity.NewValidationError("invalid_slug", "slug must be lowercase latin letters and digits separated by dashes")
+	// ErrSlugTaken -.
+	ErrSlugTaken = entity.NewConflictError("slug_taken", "slug is already used by another category")
+	// ErrParentCategoryNotFound -.
+	ErrParentCategoryNotFound = entity.NewValidationError("parent_category_not_found", "parent category not found")
+	// ErrCategoryCycle is returned when a category would become its own ancestor.
+	ErrCategoryCycle = entity.NewValidationError("category_cycle", "category cannot be moved under itself or its descendants")
+	// ErrCategoryHasChildren is returned when deleting a category that still has subcategories.
+	ErrCategoryHasChildren = entity.NewConflictError("category_has_children", "category has subcategories")
+)
+
+var _slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// CategoryUseCase manages the category hierarchy.
+type CategoryUseCase struct {
+	repo TourismRepo
+	// tx commits the changes together with their audit events.
+	tx           Transactor
+	audit        *AuditUseCase
+	translations *TranslationUseCase
+}
+
+// NewCategoryUseCase -.
+func NewCategoryUseCase(r TourismRepo, tx Transactor, audit *AuditUseCase, translations *TranslationUseCase) *CategoryUseCase {
+	return &CategoryUseCase{
+		repo:         r,
+		tx:           tx,
+		audit:        audit,
+		translations: translations,
+	}
+}
+
+// Languages returns the supported content languages, the default first.
+func (u *CategoryUseCase) Languages() []string {
+	return u.translations.Languages()
+}
+
+// CreateCategory adds a category, under dto.ParentID when it is set.
+func (u *CategoryUseCase) CreateCategory(ctx context.Context, actor entity.AuditActor, dto *entity.CreateCategoryDTO) (*entity.Category, error) {
+	category := &entity.Category{
+		Name:      dto.Name,
+		Slug:      dto.Slug,
+		Icon:      dto.Icon,
+		ParentID:  dto.ParentID,
+		SortOrder: dto.SortOrder,
+	}
+	if category.Slug == "" {
+		category.Slug = slugify(dto.Name)
+	}
+	if category.Slug == "" {
+		// Nothing of the name can be written in latin letters, the slug is taken from the ID instead.
+		category.ID = uuid.New()
+		category.Slug = "category-" + category.ID.String()[:8]
+	}
+
+	err := u.tx.Transaction(ctx, func(ctx context.Context) error {
+		categories, err := u.repo.LockCategories(ctx)
+		if err != nil {
+			return err
+		}
+		if err := checkSlug(categories, uuid.Nil, category.Slug); err != nil {
+			return err
+		}
+		if dto.ParentID != nil && findCategory(categories, *dto.ParentID) == nil {
+			return ErrParentCategoryNotFound
+		}
+
+		// A category committed after the lock was taken may have the same slug.
+		err = u.repo.CreateCategory(ctx, category)
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrSlugTaken
+		}
+		if err != nil {
+			return err
+		}
+		return u.audit.Record(ctx, actor, entity.AuditActionCategoryCreate, "category", category.ID.String(), nil, categorySnapshot(category))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// UpdateCategory changes the fields set in dto. Moving a category moves its whole subtree.
+func (u *CategoryUseCase) UpdateCategory(ctx context.Context, actor entity.AuditActor, categoryID uuid.UUID, dto *entity.UpdateCategoryDTO) (*entity.Category, error) {
+	var category *entity.Category
+	err := u.tx.Transaction(ctx, func(ctx context.Context) error {
+		// The hierarchy is checked under the lock, so a concurrent move cannot close a cycle.
+		categories, err := u.repo.LockCategories(ctx)
+		if err != nil {
+			return err
+		}
+		category = findCategory(categories, categoryID)
+		if category == nil {
+			return ErrCategoryNotFound
+		}
+		before := categorySnapshot(category)
+
+		if dto.Name != nil {
+			category.Name = *dto.Name
+		}
+		if dto.Slug != nil {
+			if err := checkSlug(categories, categoryID, *dto.Slug); err != nil {
+				return err
+			}
+			category.Slug = *dto.Slug
+		}
+		if dto.Icon != nil {
+			category.Icon = *dto.Icon
+		}
+		if dto.SortOrder != nil {
+			category.SortOrder = *dto.SortOrder
+		}
+		if dto.ParentID != nil {
+			category.ParentID = nil
+			if *dto.ParentID != "" {
+				parentID, err := uuid.Parse(*dto.ParentID)
+				if err != nil || findCategory(categories, parentID) == nil {
+					return ErrParentCategoryNotFound
+				}
+				if slices.Contains(descendantIDs(categories, categoryID), parentID) {
+					return ErrCategoryCycle
+				}
+				category.ParentID = &parentID
+			}
+		}
+
+		err = u.repo.UpdateCategory(ctx, category)
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrSlugTaken
+		}
+		if err != nil {
+			return err
+		}
+		return u.audit.Record(ctx, actor, entity.AuditActionCategoryUpdate, "category", categoryID.String(), before, categorySnapshot(category))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// DeleteCategory removes a category without subcategories, its tours lose the category.
+func (u *CategoryUseCase) DeleteCategory(ctx context.Context, actor entity.AuditActor, categoryID uuid.UUID) error {
+	return u.tx.Transaction(ctx, func(ctx context.Context) error {
+		// Under the lock no subcategory can be added before the category is gone.
+		categories, err := u.repo.LockCategories(ctx)
+		if err != nil {
+			return err
+		}
+		category := findCategory(categories, categoryID)
+		if category == nil {
+			return ErrCategoryNotFound
+		}
+		if len(descendantIDs(categories, categoryID)) > 1 {
+			return ErrCategoryHasChildren
+		}
+
+		err = u.repo.DeleteCategory(ctx, categoryID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCategoryNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return u.audit.Record(ctx, actor, entity.AuditActionCategoryDelete, "category", categoryID.String(), categorySnapshot(category), nil)
+	})
+}
+
+// GetCategoryTree returns the top-level categories with their subcategories, names in lang.
+// Siblings are ordered by sort order, then by name. On ErrTranslationUnavailable the tree
+// is returned untranslated.
+func (u *CategoryUseCase) GetCategoryTree(ctx context.Context, lang string) ([]*entity.CategoryNode, error) {
+	categories, err := u.repo.GetAllCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	localizeErr := u.translations.LocalizeCategories(ctx, categories, lang)
+	if localizeErr != nil && !errors.Is(localizeErr, ErrTranslationUnavailable) {
+		return nil, localizeErr
+	}
+	counts, err := u.repo.GetCategoryTourCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[uuid.UUID]*entity.CategoryNode, len(categories))
+	for _, category := range categories {
+		nodes[category.ID] = &entity.CategoryNode{
+			ID:        category.ID,
+			Name:      category.Name,
+			Slug:      category.Slug,
+			Icon:      category.Icon,
+			ParentID:  category.ParentID,
+			SortOrder: category.SortOrder,
+			TourCount: counts[category.ID],
+			Children:  []*entity.CategoryNode{},
+		}
+	}
+	// categories are already sorted, children keep that order.
+	roots := []*entity.CategoryNode{}
+	for _, category := range categories {
+		node := nodes[category.ID]
+		if category.ParentID != nil {
+			if parent, ok := nodes[*category.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+	return roots, localizeErr
+}
+
+func findCategory(categories []entity.Category, id uuid.UUID) *entity.Category {
+	for i := range categories {
+		if categories[i].ID == id {
+			return &categories[i]
+		}
+	}
+	return nil
+}
+
+// descendantIDs returns id and the IDs of all categories below it.
+func descendantIDs(categories []entity.Category, id uuid.UUID) []uuid.UUID {
+	ids := []uuid.UUID{id}
+	for i := 0; i < len(ids); i++ {
+		for _, category := range categories {
+			if category.ParentID != nil && *category.ParentID == ids[i] && !slices.Contains(ids, category.ID) {
+				ids = append(ids, category.ID)
+			}
+		}
+	}
+	return ids
+}
+
+func checkSlug(categories []entity.Category, id uuid.UUID, slug string) error {
+	if !_slugPattern.MatchString(slug) {
+		return ErrInvalidSlug
+	}
+	for _, category := range categories {
+		if category.Slug == slug && category.ID != id {
+			return ErrSlugTaken
+		}
+	}
+	return nil
+}
+
+// _transliterations spells the cyrillic letters of Russian and Kazakh in latin letters.
+// The hard and the soft sign have no sound of their own and are dropped.
+var _transliterations = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'ә': "a", 'ғ': "g", 'қ': "q", 'ң': "n", 'ө': "o", 'ұ': "u", 'ү': "u",
+	'һ': "h", 'і': "i",
+}
+
+// slugify keeps the latin letters and digits of name and transliterates the cyrillic ones,
+// the other runs of characters become dashes.
+func slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		latin, ok := _transliterations[r]
+		if !ok && (r >= 'a' && r <= 'z' || r >= '0' && r <= '9') {
+			latin, ok = string(r), true
+		}
+		if !ok {
+			dash = true
+			continue
+		}
+		if latin == "" {
+			continue
+		}
+		if dash && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		b.WriteString(latin)
+		dash = false
+	}
+	return b.String()
+}
+
+func categorySnapshot(category *entity.Category) map[string]interface{} {
+	return map[string]interface{}{
+		"name":       category.Name,
+		"slug":       category.Slug,
+		"icon":       category.Icon,
+		"parent_id":  category.ParentID,
+		"sort_order": category.SortOrder,
+	}
+}
@@ -0,0 +1,142 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase"
+	"tourism-backend/internal/usecase/repo/inmemory"
+)
+
+func categories(t *testing.T) *usecase.CategoryUseCase {
+	t.Helper()
+
+	return usecase.NewCategoryUseCase(inmemory.NewTourismRepo(), inmemory.Transactor{}, usecase.NewAuditUseCase(inmemory.NewAuditRepo()), nil)
+}
+
+func TestCreateCategorySlug(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		dto      entity.CreateCategoryDTO
+		wantSlug string
+		err      error
+	}{
+		{name: "slug from name", dto: entity.CreateCategoryDTO{Name: "Mountain Hiking"}, wantSlug: "mountain-hiking"},
+		{name: "punctuation runs become one dash", dto: entity.CreateCategoryDTO{Name: "  Wine & Food -- Tours! "}, wantSlug: "wine-food-tours"},
+		{name: "digits are kept", dto: entity.CreateCategoryDTO{Name: "Top 10 Beaches"}, wantSlug: "top-10-beaches"},
+		{name: "russian letters are transliterated", dto: entity.CreateCategoryDTO{Name: "Tours in Крым"}, wantSlug: "tours-in-krym"},
+		{name: "russian name", dto: entity.CreateCategoryDTO{Name: "Горные походы"}, wantSlug: "gornye-pokhody"},
+		{name: "kazakh name", dto: entity.CreateCategoryDTO{Name: "Тау шыңдары"}, wantSlug: "tau-shyndary"},
+		{name: "signs are dropped inside a word", dto: entity.CreateCategoryDTO{Name: "Подъём"}, wantSlug: "podem"},
+		{name: "explicit slug for a non-latin name", dto: entity.CreateCategoryDTO{Name: "Горные походы", Slug: "mountain-trips"}, wantSlug: "mountain-trips"},
+		{name: "invalid explicit slug", dto: entity.CreateCategoryDTO{Name: "Hiking", Slug: "Hiking Trips"}, err: usecase.ErrInvalidSlug},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			categories := categories(t)
+			category, err := categories.CreateCategory(context.Background(), entity.AuditActor{}, &tc.dto)
+			require.ErrorIs(t, err, tc.err)
+			if tc.err == nil {
+				require.Equal(t, tc.wantSlug, category.Slug)
+			}
+		})
+	}
+
+	// A name without latin or cyrillic letters gets a slug from the ID.
+	category, err := categories(t).CreateCategory(context.Background(), entity.AuditActor{}, &entity.CreateCategoryDTO{Name: "東京の旅"})
+	require.NoError(t, err)
+	require.Equal(t, "category-"+category.ID.String()[:8], category.Slug)
+}
+
+func TestCategoryHierarchy(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	categories := categories(t)
+	create := func(name string, parentID *uuid.UUID) *entity.Category {
+		category, err := categories.CreateCategory(ctx, entity.AuditActor{}, &entity.CreateCategoryDTO{Name: name, ParentID: parentID})
+		require.NoError(t, err)
+		return category
+	}
+	outdoor := create("Outdoor", nil)
+	hiking := create("Hiking", &outdoor.ID)
+	alpine := create("Alpine", &hiking.ID)
+	city := create("City", nil)
+
+	parent := func(category *entity.Category) *string {
+		id := category.ID.String()
+		return &id
+	}
+	moves := []struct {
+		name     string
+		category *entity.Category
+		parentID *string
+		err      error
+	}{
+		{name: "under itself", category: hiking, parentID: parent(hiking), err: usecase.ErrCategoryCycle},
+		{name: "under its child", category: outdoor, parentID: parent(hiking), err: usecase.ErrCategoryCycle},
+		{name: "under a deeper descendant", category: outdoor, parentID: parent(alpine), err: usecase.ErrCategoryCycle},
+		{name: "under a missing category", category: hiking, parentID: parent(&entity.Category{ID: uuid.New()}), err: usecase.ErrParentCategoryNotFound},
+		{name: "under another branch", category: hiking, parentID: parent(city)},
+	}
+	for _, tc := range moves {
+		_, err := categories.UpdateCategory(ctx, entity.AuditActor{}, tc.category.ID, &entity.UpdateCategoryDTO{ParentID: tc.parentID})
+		require.ErrorIs(t, err, tc.err, tc.name)
+	}
+
+	deletes := []struct {
+		name     string
+		category *entity.Category
+		err      error
+	}{
+		{name: "category with a subtree", category: city, err: usecase.ErrCategoryHasChildren},
+		{name: "category with a child", category: hiking, err: usecase.ErrCategoryHasChildren},
+		{name: "leaf", category: alpine},
+		{name: "former parent", category: hiking},
+		{name: "missing", category: alpine, err: usecase.ErrCategoryNotFound},
+	}
+	for _, tc := range deletes {
+		require.ErrorIs(t, categories.DeleteCategory(ctx, entity.AuditActor{}, tc.category.ID), tc.err, tc.name)
+	}
+}
+
+func TestCategorySlugTaken(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	categories := categories(t)
+	hiking, err := categories.CreateCategory(ctx, entity.AuditActor{}, &entity.CreateCategoryDTO{Name: "Hiking"})
+	require.NoError(t, err)
+	city, err := categories.CreateCategory(ctx, entity.AuditActor{}, &entity.CreateCategoryDTO{Name: "City"})
+	require.NoError(t, err)
+
+	_, err = categories.CreateCategory(ctx, entity.AuditActor{}, &entity.CreateCategoryDTO{Name: "Hiking!"})
+	require.ErrorIs(t, err, usecase.ErrSlugTaken)
+	_, err = categories.UpdateCategory(ctx, entity.AuditActor{}, city.ID, &entity.UpdateCategoryDTO{Slug: &hiking.Slug})
+	require.ErrorIs(t, err, usecase.ErrSlugTaken)
+
+	// A slug taken by a concurrent request after the check is reported by the unique index.
+	repo := NewMockTourismRepo(gomock.NewController(t))
+	categories = usecase.NewCategoryUseCase(repo, inmemory.Transactor{}, usecase.NewAuditUseCase(inmemory.NewAuditRepo()), nil)
+	repo.EXPECT().LockCategories(gomock.Any()).Return(nil, nil)
+	repo.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).Return(fmt.Errorf("create category: %w", gorm.ErrDuplicatedKey))
+	_, err = categories.CreateCategory(ctx, entity.AuditActor{}, &entity.CreateCategoryDTO{Name: "Hiking"})
+	require.ErrorIs(t, err, usecase.ErrSlugTaken)
+
+	stored := []entity.Category{{ID: city.ID, Name: "City", Slug: "city"}}
+	repo.EXPECT().LockCategories(gomock.Any()).Return(stored, nil)
+	repo.EXPECT().UpdateCategory(gomock.Any(), gomock.Any()).Return(fmt.Errorf("update category: %w", gorm.ErrDuplicatedKey))
+	_, err = categories.UpdateCategory(ctx, entity.AuditActor{}, city.ID, &entity.UpdateCategoryDTO{Slug: &hiking.Slug})
+	require.ErrorIs(t, err, usecase.ErrSlugTaken)
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/media"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrDocumentNotFound is returned for unknown documents and documents of other providers.
+var ErrDocumentNotFound = entity.NewNotFoundError("document_not_found", "document not found")
+
+type DocumentUseCase struct {
+	repo DocumentRepo
+	// tx commits the changes and the reads of documents together with their audit events.
+	tx      Transactor
+	tourism TourismRepo
+	audit   *AuditUseCase
+	uploads *media.Validator
+	signer  *media.Signer
+}
+
+// NewDocumentUseCase -.
+func NewDocumentUseCase(r DocumentRepo, tx Transactor, tourism TourismRepo, audit *AuditUseCase, uploads *media.Validator, signer *media.Signer) *DocumentUseCase {
+	return &DocumentUseCase{
+		repo:    r,
+		tx:      tx,
+		tourism: tourism,
+		audit:   audit,
+		uploads: uploads,
+		signer:  signer,
+	}
+}
+
+// UploadDocument stores a private document of the provider, optionally attached to one of their tours.
+func (d *DocumentUseCase) UploadDocument(ctx context.Context, actor entity.AuditActor, dto *entity.CreateDocumentDTO, file *multipart.FileHeader) (*entity.Document, error) {
+	document := &entity.Document{
+		ProviderID: actor.UserID,
+		Kind:       dto.Kind,
+	}
+	if dto.TourID != "" {
+		tourID, err := uuid.Parse(dto.TourID)
+		if err != nil {
+			return nil, ErrTourNotFound
+		}
+		if !d.tourism.CheckTourOwner(ctx, tourID, actor.UserID) {
+			return nil, ErrNotTourOwner
+		}
+		document.TourID = &tourID
+	}
+	if fileErrors := d.uploads.Validate("file", media.KindDocument, []*multipart.FileHeader{file}); len(fileErrors) > 0 {
+		return nil, &media.ValidationError{Files: fileErrors}
+	}
+	document.Filename = file.Filename
+	document.ContentType = file.Header.Get("Content-Type")
+	document.Size = file.Size
+
+	err := d.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := d.repo.CreateDocument(ctx, document, file); err != nil {
+			return fmt.Errorf("upload document: %w", err)
+		}
+		return d.audit.Record(ctx, actor, entity.AuditActionDocumentUpload, "document", document.ID.String(), nil, map[string]interface{}{
+			"kind":     document.Kind,
+			"tour_id":  document.TourID,
+			"filename": document.Filename,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	d.sign(document)
+	return document, nil
+}
+
+// GetDocuments returns the documents of the provider with fresh signed URLs.
+func (d *DocumentUseCase) GetDocuments(ctx context.Context, providerID uuid.UUID) ([]*entity.Document, error) {
+	documents, err := d.repo.GetDocuments(ctx, &providerID, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, document := range documents {
+		d.sign(document)
+	}
+	return documents, nil
+}
+
+// GetDocument returns a document of the provider with a fresh signed URL.
+func (d *DocumentUseCase) GetDocument(ctx context.Context, providerID, id uuid.UUID) (*entity.Document, error) {
+	document, err := d.getOwnDocument(ctx, providerID, id)
+	if err != nil {
+		return nil, err
+	}
+	d.sign(document)
+	return document, nil
+}
+
+func (d *DocumentUseCase) DeleteDocument(ctx context.Context, actor entity.AuditActor, id uuid.UUID) error {
+	document, err := d.getOwnDocument(ctx, actor.UserID, id)
+	if err != nil {
+		return err
+	}
+	return d.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := d.repo.DeleteDocument(ctx, document); err != nil {
+			return err
+		}
+		return d.audit.Record(ctx, actor, entity.AuditActionDocumentDelete, "document", document.ID.String(), map[string]interface{}{
+			"kind":     document.Kind,
+			"filename": document.Filename,
+		}, nil)
+	})
+}
+
+// ReviewDocuments lets admins read provider documents, e.g. for verification.
+// Every access is audited, since the signed URLs grant download access.
+func (d *DocumentUseCase) ReviewDocuments(ctx context.Context, actor entity.AuditActor, filter *entity.DocumentFilter) ([]*entity.Document, error) {
+	var providerID *uuid.UUID
+	if filter.ProviderID != "" {
+		id, err := uuid.Parse(filter.ProviderID)
+		if err != nil {
+			return nil, fmt.Errorf("review documents: %w", err)
+		}
+		providerID = &id
+	}
+	var documents []*entity.Document
+	err := d.tx.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		documents, err = d.repo.GetDocuments(ctx, providerID, filter.Kind)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]uuid.UUID, 0, len(documents))
+		for _, document := range documents {
+			ids = append(ids, document.ID)
+		}
+		return d.audit.Record(ctx, actor, entity.AuditActionDocumentView, "document", "", nil, map[string]interface{}{
+			"provider_id": filter.ProviderID,
+			"kind":        filter.Kind,
+			"documents":   ids,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	// The URLs are signed only once the access is on record.
+	for _, document := range documents {
+		d.sign(document)
+	}
+	return documents, nil
+}
+
+func (d *DocumentUseCase) getOwnDocument(ctx context.Context, providerID, id uuid.UUID) (*entity.Document, error) {
+	document, err := d.repo.GetDocument(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrDocumentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if document.ProviderID != providerID {
+		return nil, ErrDocumentNotFound
+	}
+	return document, nil
+}
+
+func (d *DocumentUseCase) sign(document *entity.Document) {
+	url, expires := d.signer.URL(document.Key)
+	document.URL = url
+	document.URLExpiresAt = &expires
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/imaging"
+	"tourism-backend/pkg/media"
+
+	"github.com/google/uuid"
+)
+
+type ImageUseCase struct {
+	repo    ImageRepo
+	options imaging.Options
+}
+
+// NewImageUseCase -.
+func NewImageUseCase(r ImageRepo, options imaging.Options) *ImageUseCase {
+	return &ImageUseCase{
+		repo:    r,
+		options: options,
+	}
+}
+
+// ProcessImage strips the metadata of an uploaded tour image and stores its resized variants.
+// Images that are not pending are skipped, so the same ID can be queued more than once.
+// Missing, undecodable and oversized images are marked failed and lose their original;
+// other errors leave the image pending for a retry.
+func (u *ImageUseCase) ProcessImage(ctx context.Context, imageID uuid.UUID) error {
+	image, err := u.repo.GetImageByID(ctx, imageID)
+	if err != nil {
+		return fmt.Errorf("process image: %w", err)
+	}
+	if image.Status != entity.ImageStatusPending {
+		return nil
+	}
+
+	src, err := u.repo.OpenMedia(ctx, image.ImageURL)
+	if err != nil {
+		if errors.Is(err, media.ErrNotFound) {
+			err = errors.Join(err, u.repo.FailImage(ctx, imageID))
+		}
+		return fmt.Errorf("process image %s: %w", imageID, err)
+	}
+	result, err := imaging.Process(src, u.options)
+	src.Close()
+	if errors.Is(err, imaging.ErrUnsupportedFormat) || errors.Is(err, imaging.ErrTooManyPixels) {
+		if statusErr := u.repo.FailImage(ctx, imageID); statusErr != nil {
+			return statusErr
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("process image %s: %w", imageID, err)
+	}
+
+	variants := make([]entity.ImageVariant, 0, len(result.Variants))
+	for _, v := range result.Variants {
+		key := path.Join("images", image.ID.String(), v.Kind+"-"+strconv.Itoa(v.Width)+imaging.Extension(v.Format))
+		if err := u.repo.PutMedia(ctx, key, v.Data, v.ContentType); err != nil {
+			return fmt.Errorf("process image %s: %w", imageID, err)
+		}
+		variants = append(variants, entity.ImageVariant{
+			ImageID: image.ID,
+			Kind:    v.Kind,
+			Format:  v.Format,
+			Width:   v.Width,
+			Height:  v.Height,
+			Key:     key,
+		})
+	}
+
+	image.Width = result.Width
+	image.Height = result.Height
+	image.Blurhash = result.Blurhash
+	// The upload is replaced with the copy that has no EXIF or GPS data.
+	return u.repo.SaveProcessedImage(ctx, image, result.Original, result.OriginalContentType, variants)
+}
+
+func (u *ImageUseCase) GetPendingImageIDs(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	return u.repo.GetPendingImageIDs(ctx, limit)
+}
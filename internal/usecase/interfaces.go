@@ -2,9 +2,15 @@
 package usecase
 
 import (
+	"context"
 	"github.com/google/uuid"
+	"io"
 	"mime/multipart"
+	"time"
 	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/geo"
+	"tourism-backend/pkg/media"
+	"tourism-backend/pkg/tus"
 )
 
 //go:generate mockgen -source=interfaces.go -destination=./mocks_test.go -package=usecase_test
@@ -13,24 +19,222 @@ type (
 	// Tourism -.
 	TourismInterface interface {
 		// CreateTour GetTourByID(ctx context.Context, id uuid.UUID) (entity.Tour, error)
-		CreateTour(tour *entity.Tour, imageFiles []*multipart.FileHeader, videFiles []*multipart.FileHeader) (*entity.Tour, error)
-		GetTours() ([]entity.Tour, error)
-		GetTourByID(ID string) (*entity.Tour, error)
-		GetAllCategories() ([]entity.Category, error)
-		CreateTourEvent(tourEvent *entity.TourEvent) (*entity.TourEvent, error)
-		CheckTourOwner(tourID uuid.UUID, userID uuid.UUID) bool
-		PayTourEvent(purchase *entity.Purchase) error
-		CreatePurchase(purchase *entity.Purchase) (*entity.Purchase, error)
-		CreateTourCategory(tourCategory *entity.CreateTourCategoryDTO) (*entity.TourCategory, error)
-		CreateTourLocation(tourLocation *entity.CreateTourLocationDTO) (*entity.TourLocation, error)
-		GetTourLocationByID(id uuid.UUID) (*entity.TourLocation, error)
-		GetFilteredTourEvents(*entity.TourEventFilter) ([]*entity.TourEvent, error)
+		CreateTour(ctx context.Context, actor entity.AuditActor, tour *entity.Tour, imageFiles []*multipart.FileHeader, videFiles []*multipart.FileHeader) (*entity.Tour, error)
+		GetTours(ctx context.Context, lang string) ([]entity.Tour, error)
+		GetTourByID(ctx context.Context, ID string, lang string) (*entity.Tour, error)
+		GetAllCategories(ctx context.Context, lang string) ([]entity.Category, error)
+		Languages() []string
+		MaxCreateTourSize() int64
+		CreateTourEvent(ctx context.Context, actor entity.AuditActor, tourEvent *entity.TourEvent) (*entity.TourEvent, error)
+		CheckTourOwner(ctx context.Context, tourID uuid.UUID, userID uuid.UUID) bool
+		TourExists(ctx context.Context, tourID uuid.UUID) (bool, error)
+		TourEventExists(ctx context.Context, tourEventID uuid.UUID) (bool, error)
+		CategoryExists(ctx context.Context, categoryID uuid.UUID) (bool, error)
+		PayTourEvent(ctx context.Context, purchase *entity.Purchase) error
+		FailPurchase(ctx context.Context, purchase *entity.Purchase) error
+		CreatePurchase(ctx context.Context, actor entity.AuditActor, purchase *entity.Purchase) (*entity.Purchase, error)
+		CreateTourCategory(ctx context.Context, actor entity.AuditActor, tourCategory *entity.CreateTourCategoryDTO) (*entity.TourCategory, error)
+		CreateTourLocation(ctx context.Context, actor entity.AuditActor, tourLocation *entity.CreateTourLocationDTO) (*entity.TourLocation, error)
+		GetTourLocationByID(ctx context.Context, id uuid.UUID) (*entity.TourLocation, error)
+		GetFilteredTourEvents(ctx context.Context, filter *entity.TourEventFilter) ([]*entity.TourEvent, error)
+		SearchToursByLocation(ctx context.Context, filter *entity.TourGeoSearchFilter, lang string) (*geo.FeatureCollection, error)
 	}
 	UserInterface interface {
-		LoginUser(user *entity.LoginUserDTO) (string, error)
-		RegisterUser(user *entity.User) (*entity.User, error)
+		LoginUser(ctx context.Context, user *entity.LoginUserDTO) (string, error)
+		RegisterUser(ctx context.Context, user *entity.User) (*entity.User, error)
+	}
+	ReviewInterface interface {
+		CreateReview(ctx context.Context, review *entity.Review, photoFiles []*multipart.FileHeader) (*entity.Review, error)
+		GetTourReviews(ctx context.Context, tourID uuid.UUID, filter *entity.ReviewFilter) ([]*entity.Review, error)
+		ReplyToReview(ctx context.Context, providerID, reviewID uuid.UUID, reply string) (*entity.Review, error)
+		ModerateReview(ctx context.Context, actor entity.AuditActor, reviewID uuid.UUID, status string) (*entity.Review, error)
+		DeleteReview(ctx context.Context, actor entity.AuditActor, reviewID uuid.UUID) error
+	}
+	WishlistInterface interface {
+		AddFavorite(ctx context.Context, userID, tourID uuid.UUID) (*entity.Favorite, error)
+		RemoveFavorite(ctx context.Context, userID, tourID uuid.UUID) error
+		GetFavorites(ctx context.Context, userID uuid.UUID) ([]*entity.Favorite, error)
+		CreateSavedSearch(ctx context.Context, userID uuid.UUID, dto *entity.CreateSavedSearchDTO) (*entity.SavedSearch, error)
+		GetSavedSearches(ctx context.Context, userID uuid.UUID) ([]*entity.SavedSearch, error)
+		DeleteSavedSearch(ctx context.Context, userID, savedSearchID uuid.UUID) error
+		GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool) ([]*entity.Notification, error)
+		MarkNotificationRead(ctx context.Context, userID, notificationID uuid.UUID) error
+		RunSavedSearches(ctx context.Context) (int, error)
+	}
+	ItineraryInterface interface {
+		GetItinerary(ctx context.Context, tourID uuid.UUID) (*entity.Itinerary, error)
+		UpdateItinerary(ctx context.Context, actor entity.AuditActor, tourID uuid.UUID, dto *entity.UpdateItineraryDTO) (*entity.Itinerary, error)
+		ImportItinerary(ctx context.Context, actor entity.AuditActor, tourID uuid.UUID, file *multipart.FileHeader) (*entity.Itinerary, error)
+		ExportItineraryGPX(ctx context.Context, tourID uuid.UUID, w io.Writer) error
+		ExportItineraryGeoJSON(ctx context.Context, tourID uuid.UUID) (*geo.FeatureCollection, error)
+	}
+	TourMediaInterface interface {
+		AddTourImages(ctx context.Context, actor entity.AuditActor, tourID uuid.UUID, files []*multipart.FileHeader, meta []entity.MediaMeta) ([]entity.Image, error)
+		AddTourVideos(ctx context.Context, actor entity.AuditActor, tourID uuid.UUID, files []*multipart.FileHeader, meta []entity.MediaMeta) ([]entity.Video, error)
+		DeleteTourImage(ctx context.Context, actor entity.AuditActor, tourID, imageID uuid.UUID) error
+		DeleteTourVideo(ctx context.Context, actor entity.AuditActor, tourID, videoID uuid.UUID) error
+		ReorderTourImages(ctx context.Context, providerID, tourID uuid.UUID, ids []uuid.UUID) (*entity.Tour, error)
+		ReorderTourVideos(ctx context.Context, providerID, tourID uuid.UUID, ids []uuid.UUID) (*entity.Tour, error)
+		SetCoverImage(ctx context.Context, providerID, tourID, imageID uuid.UUID) (*entity.Tour, error)
+		UpdateTourImage(ctx context.Context, providerID, tourID, imageID uuid.UUID, dto *entity.UpdateMediaDTO) (*entity.Tour, error)
+		UpdateTourVideo(ctx context.Context, providerID, tourID, videoID uuid.UUID, dto *entity.UpdateMediaDTO) (*entity.Tour, error)
+		CollectOrphanedMedia(ctx context.Context, gracePeriod time.Duration) (int, error)
+	}
+	UploadInterface interface {
+		MaxSize() int64
+		CreateUpload(ctx context.Context, actor entity.AuditActor, dto *entity.CreateUploadDTO) (*entity.Upload, error)
+		GetUpload(ctx context.Context, userID, id uuid.UUID) (*entity.Upload, error)
+		WriteUploadChunk(ctx context.Context, actor entity.AuditActor, id uuid.UUID, offset int64, chunk io.Reader, checksum *tus.Checksum) (*entity.Upload, error)
+		DeleteUpload(ctx context.Context, userID, id uuid.UUID) error
+		DeleteExpiredUploads(ctx context.Context) (int, error)
+	}
+	DocumentInterface interface {
+		UploadDocument(ctx context.Context, actor entity.AuditActor, dto *entity.CreateDocumentDTO, file *multipart.FileHeader) (*entity.Document, error)
+		GetDocuments(ctx context.Context, providerID uuid.UUID) ([]*entity.Document, error)
+		GetDocument(ctx context.Context, providerID, id uuid.UUID) (*entity.Document, error)
+		DeleteDocument(ctx context.Context, actor entity.AuditActor, id uuid.UUID) error
+		ReviewDocuments(ctx context.Context, actor entity.AuditActor, filter *entity.DocumentFilter) ([]*entity.Document, error)
+	}
+	TranslationInterface interface {
+		GetTourLocales(ctx context.Context, providerID, tourID uuid.UUID) ([]entity.TourLocale, error)
+		SetTourLocale(ctx context.Context, actor entity.AuditActor, tourID uuid.UUID, lang string, dto *entity.UpdateTourLocaleDTO) (*entity.TourLocale, error)
+		DeleteTourLocale(ctx context.Context, actor entity.AuditActor, tourID uuid.UUID, lang string) error
+		SetCategoryLocale(ctx context.Context, actor entity.AuditActor, categoryID uuid.UUID, lang string, dto *entity.UpdateCategoryLocaleDTO) (*entity.CategoryLocale, error)
+	}
+	CategoryInterface interface {
+		CreateCategory(ctx context.Context, actor entity.AuditActor, dto *entity.CreateCategoryDTO) (*entity.Category, error)
+		UpdateCategory(ctx context.Context, actor entity.AuditActor, categoryID uuid.UUID, dto *entity.UpdateCategoryDTO) (*entity.Category, error)
+		DeleteCategory(ctx context.Context, actor entity.AuditActor, categoryID uuid.UUID) error
+		GetCategoryTree(ctx context.Context, lang string) ([]*entity.CategoryNode, error)
+		Languages() []string
+	}
+	ImageInterface interface {
+		ProcessImage(ctx context.Context, imageID uuid.UUID) error
+		GetPendingImageIDs(ctx context.Context, limit int) ([]uuid.UUID, error)
 	}
 	AdminInterface interface {
-		GetUsers() ([]*entity.User, error)
+		GetUsers(ctx context.Context, actor entity.AuditActor) ([]*entity.User, error)
+		GetAuditEvents(ctx context.Context, filter *entity.AuditEventFilter) ([]*entity.AuditEvent, error)
+		VerifyAuditChain(ctx context.Context) (*entity.AuditIntegrityReport, error)
+	}
+)
+
+// Repositories, implemented on Postgres by the repo package and in memory by repo/inmemory for tests.
+type (
+	// TourismRepo -.
+	TourismRepo interface {
+		GetFilteredTourEvents(ctx context.Context, filter *entity.TourEventFilter) ([]*entity.TourEvent, error)
+		SearchToursByLocation(ctx context.Context, filter *entity.TourGeoSearchFilter) ([]*entity.TourGeoResult, error)
+		GetTourLocationByID(ctx context.Context, tourID uuid.UUID) (*entity.TourLocation, error)
+		CreateTourLocation(ctx context.Context, tourLocation *entity.CreateTourLocationDTO) (*entity.TourLocation, error)
+		GetAllCategories(ctx context.Context) ([]entity.Category, error)
+		// LockCategories must be called in a transaction, the categories stay locked until it ends.
+		LockCategories(ctx context.Context) ([]entity.Category, error)
+		GetCategoryByID(ctx context.Context, categoryID uuid.UUID) (*entity.Category, error)
+		CreateCategory(ctx context.Context, category *entity.Category) error
+		UpdateCategory(ctx context.Context, category *entity.Category) error
+		DeleteCategory(ctx context.Context, categoryID uuid.UUID) error
+		GetCategoryTourCounts(ctx context.Context) (map[uuid.UUID]int64, error)
+		CreateTourCategory(ctx context.Context, tourCategory *entity.CreateTourCategoryDTO) (*entity.TourCategory, error)
+		CreatePurchase(ctx context.Context, purchase *entity.Purchase) (*entity.Purchase, error)
+		PayTourEvent(ctx context.Context, purchase *entity.Purchase) error
+		FailPurchase(ctx context.Context, purchase *entity.Purchase) error
+		CheckTourOwner(ctx context.Context, tourID uuid.UUID, userID uuid.UUID) bool
+		TourExists(ctx context.Context, tourID uuid.UUID) (bool, error)
+		TourEventExists(ctx context.Context, tourEventID uuid.UUID) (bool, error)
+		CreateTourEvent(ctx context.Context, tourEvent *entity.TourEvent) (*entity.TourEvent, error)
+		GetTourByID(ctx context.Context, tourID string) (*entity.Tour, error)
+		GetTours(ctx context.Context) ([]entity.Tour, error)
+		CreateTour(ctx context.Context, tour *entity.Tour, imageFiles []*multipart.FileHeader, videoFiles []*multipart.FileHeader) (*entity.Tour, error)
+	}
+	UserRepo interface {
+		LoginUser(ctx context.Context, user *entity.LoginUserDTO) (*entity.User, error)
+		RegisterUser(ctx context.Context, user *entity.User) (*entity.User, error)
+	}
+	AdminRepo interface {
+		GetUsers(ctx context.Context) ([]*entity.User, error)
+	}
+	ReviewRepo interface {
+		HasCompletedPurchase(ctx context.Context, tourID, userID uuid.UUID) (bool, error)
+		CreateReview(ctx context.Context, review *entity.Review, photoFiles []*multipart.FileHeader) (*entity.Review, error)
+		GetReviewByID(ctx context.Context, reviewID uuid.UUID) (*entity.Review, error)
+		GetTourReviews(ctx context.Context, tourID uuid.UUID, filter *entity.ReviewFilter) ([]*entity.Review, error)
+		ReplyToReview(ctx context.Context, reviewID uuid.UUID, reply string) (*entity.Review, error)
+		SetReviewStatus(ctx context.Context, reviewID uuid.UUID, status string) (*entity.Review, error)
+		DeleteReview(ctx context.Context, reviewID uuid.UUID) error
+	}
+	WishlistRepo interface {
+		AddFavorite(ctx context.Context, userID, tourID uuid.UUID) (*entity.Favorite, error)
+		RemoveFavorite(ctx context.Context, userID, tourID uuid.UUID) error
+		GetFavorites(ctx context.Context, userID uuid.UUID) ([]*entity.Favorite, error)
+		CreateSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch, current []*entity.TourEvent) (*entity.SavedSearch, error)
+		GetSavedSearches(ctx context.Context, userID uuid.UUID) ([]*entity.SavedSearch, error)
+		GetAllSavedSearches(ctx context.Context) ([]*entity.SavedSearch, error)
+		DeleteSavedSearch(ctx context.Context, userID, savedSearchID uuid.UUID) error
+		GetMatches(ctx context.Context, savedSearchID uuid.UUID) (map[uuid.UUID]float64, error)
+		RecordRun(ctx context.Context, savedSearch *entity.SavedSearch, seen []*entity.TourEvent, notifications []*entity.Notification) error
+		GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool) ([]*entity.Notification, error)
+		MarkNotificationRead(ctx context.Context, userID, notificationID uuid.UUID) error
+	}
+	ItineraryRepo interface {
+		GetItinerary(ctx context.Context, tourID uuid.UUID) ([]entity.RouteStop, *entity.RouteTrack, error)
+		ReplaceItinerary(ctx context.Context, tourID uuid.UUID, stops []entity.RouteStop, track *entity.RouteTrack, distanceKm, elevationGainM float64) error
+	}
+	TourMediaRepo interface {
+		AddTourImages(ctx context.Context, tourID uuid.UUID, files []*multipart.FileHeader, meta []entity.MediaMeta) ([]entity.Image, error)
+		AddTourVideos(ctx context.Context, tourID uuid.UUID, files []*multipart.FileHeader, meta []entity.MediaMeta) ([]entity.Video, error)
+		DeleteTourImage(ctx context.Context, tourID, imageID uuid.UUID) error
+		DeleteTourVideo(ctx context.Context, tourID, videoID uuid.UUID) error
+		ReorderTourImages(ctx context.Context, tourID uuid.UUID, ids []uuid.UUID) error
+		ReorderTourVideos(ctx context.Context, tourID uuid.UUID, ids []uuid.UUID) error
+		SetCoverImage(ctx context.Context, tourID, imageID uuid.UUID) error
+		UpdateTourImage(ctx context.Context, tourID, imageID uuid.UUID, dto *entity.UpdateMediaDTO) error
+		UpdateTourVideo(ctx context.Context, tourID, videoID uuid.UUID, dto *entity.UpdateMediaDTO) error
+		CountTourMedia(ctx context.Context, tourID uuid.UUID, kind media.Kind) (int64, error)
+		ReferencedMediaKeys(ctx context.Context) (map[string]struct{}, error)
+		DeleteUnreferencedMedia(ctx context.Context, referenced map[string]struct{}, olderThan time.Time) (int, error)
+	}
+	ImageRepo interface {
+		GetImageByID(ctx context.Context, imageID uuid.UUID) (*entity.Image, error)
+		GetPendingImageIDs(ctx context.Context, limit int) ([]uuid.UUID, error)
+		OpenMedia(ctx context.Context, key string) (io.ReadCloser, error)
+		PutMedia(ctx context.Context, key string, data []byte, contentType string) error
+		SaveProcessedImage(ctx context.Context, image *entity.Image, original []byte, originalContentType string, variants []entity.ImageVariant) error
+		FailImage(ctx context.Context, imageID uuid.UUID) error
+	}
+	UploadRepo interface {
+		CreateUpload(ctx context.Context, upload *entity.Upload) error
+		GetUpload(ctx context.Context, id uuid.UUID) (*entity.Upload, error)
+		// LockUpload must be called in a transaction, the lock is released when it ends.
+		LockUpload(ctx context.Context, id uuid.UUID) (bool, error)
+		SetUploadOffset(ctx context.Context, id uuid.UUID, from, to int64) error
+		CompleteUpload(ctx context.Context, upload *entity.Upload, src io.Reader, contentType string) (*entity.Video, error)
+		DeleteUpload(ctx context.Context, id uuid.UUID) error
+		GetExpiredUploadIDs(ctx context.Context, t time.Time, limit int) ([]uuid.UUID, error)
+	}
+	DocumentRepo interface {
+		CreateDocument(ctx context.Context, document *entity.Document, file *multipart.FileHeader) error
+		GetDocument(ctx context.Context, id uuid.UUID) (*entity.Document, error)
+		GetDocuments(ctx context.Context, providerID *uuid.UUID, kind string) ([]*entity.Document, error)
+		DeleteDocument(ctx context.Context, document *entity.Document) error
+	}
+	// Transactor runs fn in a transaction that the repositories called with the ctx given to fn
+	// take part in, e.g. so that a change and its audit event are committed together.
+	Transactor interface {
+		Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	}
+	AuditRepo interface {
+		Append(ctx context.Context, event *entity.AuditEvent) error
+		GetAuditEvents(ctx context.Context, filter *entity.AuditEventFilter) ([]*entity.AuditEvent, error)
+		VerifyChain(ctx context.Context) (*entity.AuditIntegrityReport, error)
+	}
+	TranslationRepo interface {
+		GetTranslations(ctx context.Context, language string, resourceIDs []uuid.UUID) ([]entity.TourTranslation, error)
+		SaveTranslations(ctx context.Context, translations []entity.TourTranslation) error
+		GetTourLocales(ctx context.Context, tourIDs []uuid.UUID, languages []string) ([]entity.TourLocale, error)
+		SaveTourLocale(ctx context.Context, locale *entity.TourLocale) error
+		DeleteTourLocale(ctx context.Context, tourID uuid.UUID, language string) error
+		GetCategoryLocales(ctx context.Context, categoryIDs []uuid.UUID, languages []string) ([]entity.CategoryLocale, error)
+		SaveCategoryLocale(ctx context.Context, locale *entity.CategoryLocale) error
 	}
 )
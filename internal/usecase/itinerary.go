@@ -0,0 +1,260 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/geo"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	_maxRouteStops       = 200
+	_maxRouteTrackPoints = 50000
+)
+
+var (
+	// ErrUnsupportedRouteFile is returned for uploads that are neither GPX nor KML.
+	ErrUnsupportedRouteFile = entity.NewValidationError("unsupported_route_file", "route file must be .gpx or .kml")
+	// ErrTourNotFound -.
+	ErrTourNotFound = entity.NewNotFoundError("tour_not_found", "tour not found")
+)
+
+type ItineraryUseCase struct {
+	repo ItineraryRepo
+	// tx commits the changes together with their audit events.
+	tx      Transactor
+	tourism TourismRepo
+	audit   *AuditUseCase
+}
+
+// NewItineraryUseCase -.
+func NewItineraryUseCase(r ItineraryRepo, tx Transactor, tourism TourismRepo, audit *AuditUseCase) *ItineraryUseCase {
+	return &ItineraryUseCase{
+		repo:    r,
+		tx:      tx,
+		tourism: tourism,
+		audit:   audit,
+	}
+}
+
+func (i *ItineraryUseCase) GetItinerary(ctx context.Context, tourID uuid.UUID) (*entity.Itinerary, error) {
+	tour, err := i.tourism.GetTourByID(ctx, tourID.String())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTourNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	stops, track, err := i.repo.GetItinerary(ctx, tourID)
+	if err != nil {
+		return nil, err
+	}
+
+	itinerary := &entity.Itinerary{
+		TourID:         tourID,
+		Name:           tour.Route,
+		Stops:          stops,
+		Track:          [][]float64{},
+		DistanceKm:     tour.RouteDistanceKm,
+		ElevationGainM: tour.RouteElevationGainM,
+	}
+	if track != nil {
+		if err := json.Unmarshal([]byte(track.Points), &itinerary.Track); err != nil {
+			return nil, fmt.Errorf("decode route track: %w", err)
+		}
+	}
+	return itinerary, nil
+}
+
+func (i *ItineraryUseCase) UpdateItinerary(ctx context.Context, actor entity.AuditActor, tourID uuid.UUID, dto *entity.UpdateItineraryDTO) (*entity.Itinerary, error) {
+	if !i.tourism.CheckTourOwner(ctx, tourID, actor.UserID) {
+		return nil, ErrNotTourOwner
+	}
+
+	route := &geo.Route{}
+	for _, stop := range dto.Stops {
+		route.Waypoints = append(route.Waypoints, geo.Waypoint{
+			Point:       geo.Point{Latitude: stop.Latitude, Longitude: stop.Longitude, Elevation: stop.Elevation},
+			Name:        stop.Name,
+			Description: stop.Description,
+		})
+	}
+	for _, position := range dto.Track {
+		point, ok := geo.PointFromPosition(position)
+		if !ok {
+			return nil, invalidItinerary("track position %v", position)
+		}
+		route.Track = append(route.Track, point)
+	}
+
+	durations := make([]int, len(dto.Stops))
+	for n, stop := range dto.Stops {
+		durations[n] = stop.DurationMinutes
+	}
+	return i.saveItinerary(ctx, actor, tourID, route, durations)
+}
+
+// ImportItinerary replaces the itinerary with the waypoints and track of a GPX or KML file.
+func (i *ItineraryUseCase) ImportItinerary(ctx context.Context, actor entity.AuditActor, tourID uuid.UUID, file *multipart.FileHeader) (*entity.Itinerary, error) {
+	if !i.tourism.CheckTourOwner(ctx, tourID, actor.UserID) {
+		return nil, ErrNotTourOwner
+	}
+
+	var parse func(io.Reader) (*geo.Route, error)
+	switch {
+	case geo.IsGPX(file.Filename):
+		parse = geo.ParseGPX
+	case geo.IsKML(file.Filename):
+		parse = geo.ParseKML
+	default:
+		return nil, ErrUnsupportedRouteFile
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	route, err := parse(src)
+	if err != nil {
+		return nil, invalidItinerary("%s", err.Error())
+	}
+	return i.saveItinerary(ctx, actor, tourID, route, make([]int, len(route.Waypoints)))
+}
+
+// ExportItineraryGPX writes the itinerary as a GPX document.
+func (i *ItineraryUseCase) ExportItineraryGPX(ctx context.Context, tourID uuid.UUID, w io.Writer) error {
+	itinerary, err := i.GetItinerary(ctx, tourID)
+	if err != nil {
+		return err
+	}
+	return geo.WriteGPX(w, itineraryToRoute(itinerary))
+}
+
+// ExportItineraryGeoJSON returns stops as Point features and the track as a LineString feature.
+func (i *ItineraryUseCase) ExportItineraryGeoJSON(ctx context.Context, tourID uuid.UUID) (*geo.FeatureCollection, error) {
+	itinerary, err := i.GetItinerary(ctx, tourID)
+	if err != nil {
+		return nil, err
+	}
+
+	route := itineraryToRoute(itinerary)
+	features := make([]*geo.Feature, 0, len(itinerary.Stops)+1)
+	for n, stop := range itinerary.Stops {
+		features = append(features, &geo.Feature{
+			Type:     geo.TypeFeature,
+			ID:       stop.ID.String(),
+			Geometry: geo.NewPointGeometry(route.Waypoints[n].Point),
+			Properties: map[string]interface{}{
+				"position":         stop.Position,
+				"name":             stop.Name,
+				"description":      stop.Description,
+				"duration_minutes": stop.DurationMinutes,
+			},
+		})
+	}
+	if len(route.Track) > 0 {
+		features = append(features, &geo.Feature{
+			Type:     geo.TypeFeature,
+			Geometry: geo.NewLineStringGeometry(route.Track),
+			Properties: map[string]interface{}{
+				"tour_id":          itinerary.TourID,
+				"distance_km":      itinerary.DistanceKm,
+				"elevation_gain_m": itinerary.ElevationGainM,
+			},
+		})
+	}
+	return geo.NewFeatureCollection(features...), nil
+}
+
+func (i *ItineraryUseCase) saveItinerary(ctx context.Context, actor entity.AuditActor, tourID uuid.UUID, route *geo.Route, durations []int) (*entity.Itinerary, error) {
+	if len(route.Waypoints) > _maxRouteStops || len(route.Track) > _maxRouteTrackPoints {
+		return nil, invalidItinerary("at most %d stops and %d track points are allowed", _maxRouteStops, _maxRouteTrackPoints)
+	}
+
+	stops := make([]entity.RouteStop, 0, len(route.Waypoints))
+	stopPoints := make([]geo.Point, 0, len(route.Waypoints))
+	for n, wpt := range route.Waypoints {
+		if !geo.ValidPoint(wpt.Point) {
+			return nil, invalidItinerary("stop %d has invalid coordinates", n+1)
+		}
+		stops = append(stops, entity.RouteStop{
+			TourID:          tourID,
+			Position:        n + 1,
+			Name:            wpt.Name,
+			Description:     wpt.Description,
+			Latitude:        wpt.Latitude,
+			Longitude:       wpt.Longitude,
+			Elevation:       wpt.Elevation,
+			DurationMinutes: durations[n],
+		})
+		stopPoints = append(stopPoints, wpt.Point)
+	}
+
+	// Totals follow the track when there is one, otherwise the straight legs between stops.
+	path := route.Track
+	if len(path) == 0 {
+		path = stopPoints
+	}
+	distanceKm := geo.PathDistanceKm(path)
+	elevationGainM := geo.ElevationGainM(path)
+
+	var track *entity.RouteTrack
+	if len(route.Track) > 0 {
+		positions := make([][]float64, 0, len(route.Track))
+		for _, p := range route.Track {
+			positions = append(positions, geo.Position(p))
+		}
+		points, err := json.Marshal(positions)
+		if err != nil {
+			return nil, fmt.Errorf("encode route track: %w", err)
+		}
+		track = &entity.RouteTrack{TourID: tourID, Points: string(points)}
+	}
+
+	err := i.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := i.repo.ReplaceItinerary(ctx, tourID, stops, track, distanceKm, elevationGainM); err != nil {
+			return err
+		}
+		return i.audit.Record(ctx, actor, entity.AuditActionItineraryUpdate, "tour", tourID.String(), nil, map[string]interface{}{
+			"stops":            len(stops),
+			"track_points":     len(route.Track),
+			"distance_km":      distanceKm,
+			"elevation_gain_m": elevationGainM,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return i.GetItinerary(ctx, tourID)
+}
+
+func itineraryToRoute(itinerary *entity.Itinerary) *geo.Route {
+	route := &geo.Route{Name: itinerary.Name}
+	for _, stop := range itinerary.Stops {
+		route.Waypoints = append(route.Waypoints, geo.Waypoint{
+			Point:       geo.Point{Latitude: stop.Latitude, Longitude: stop.Longitude, Elevation: stop.Elevation},
+			Name:        stop.Name,
+			Description: stop.Description,
+		})
+	}
+	for _, position := range itinerary.Track {
+		if point, ok := geo.PointFromPosition(position); ok {
+			route.Track = append(route.Track, point)
+		}
+	}
+	return route
+}
+
+// invalidItinerary is returned when stops or track points are out of range or too many.
+func invalidItinerary(format string, args ...interface{}) error {
+	return entity.NewValidationError("invalid_itinerary", "invalid itinerary: "+fmt.Sprintf(format, args...))
+}
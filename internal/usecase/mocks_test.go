@@ -1,68 +1,2822 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: ./internal/usecase/interfaces.go
+// Source: interfaces.go
 
 // Package usecase_test is a generated GoMock package.
 package usecase_test
 
 import (
 	context "context"
+	io "io"
+	multipart "mime/multipart"
 	reflect "reflect"
+	time "time"
+	entity "tourism-backend/internal/entity"
+	geo "tourism-backend/pkg/geo"
+	media "tourism-backend/pkg/media"
+	tus "tourism-backend/pkg/tus"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockTourismInterface is a mock of TourismInterface interface.
+type MockTourismInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTourismInterfaceMockRecorder
+}
+
+// MockTourismInterfaceMockRecorder is the mock recorder for MockTourismInterface.
+type MockTourismInterfaceMockRecorder struct {
+	mock *MockTourismInterface
+}
+
+// NewMockTourismInterface creates a new mock instance.
+func NewMockTourismInterface(ctrl *gomock.Controller) *MockTourismInterface {
+	mock := &MockTourismInterface{ctrl: ctrl}
+	mock.recorder = &MockTourismInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTourismInterface) EXPECT() *MockTourismInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CategoryExists mocks base method.
+func (m *MockTourismInterface) CategoryExists(ctx context.Context, categoryID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CategoryExists", ctx, categoryID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CategoryExists indicates an expected call of CategoryExists.
+func (mr *MockTourismInterfaceMockRecorder) CategoryExists(ctx, categoryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CategoryExists", reflect.TypeOf((*MockTourismInterface)(nil).CategoryExists), ctx, categoryID)
+}
+
+// CheckTourOwner mocks base method.
+func (m *MockTourismInterface) CheckTourOwner(ctx context.Context, tourID, userID uuid.UUID) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckTourOwner", ctx, tourID, userID)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CheckTourOwner indicates an expected call of CheckTourOwner.
+func (mr *MockTourismInterfaceMockRecorder) CheckTourOwner(ctx, tourID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckTourOwner", reflect.TypeOf((*MockTourismInterface)(nil).CheckTourOwner), ctx, tourID, userID)
+}
+
+// CreatePurchase mocks base method.
+func (m *MockTourismInterface) CreatePurchase(ctx context.Context, actor entity.AuditActor, purchase *entity.Purchase) (*entity.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePurchase", ctx, actor, purchase)
+	ret0, _ := ret[0].(*entity.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePurchase indicates an expected call of CreatePurchase.
+func (mr *MockTourismInterfaceMockRecorder) CreatePurchase(ctx, actor, purchase interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchase", reflect.TypeOf((*MockTourismInterface)(nil).CreatePurchase), ctx, actor, purchase)
+}
+
+// CreateTour mocks base method.
+func (m *MockTourismInterface) CreateTour(ctx context.Context, actor entity.AuditActor, tour *entity.Tour, imageFiles, videFiles []*multipart.FileHeader) (*entity.Tour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTour", ctx, actor, tour, imageFiles, videFiles)
+	ret0, _ := ret[0].(*entity.Tour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTour indicates an expected call of CreateTour.
+func (mr *MockTourismInterfaceMockRecorder) CreateTour(ctx, actor, tour, imageFiles, videFiles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTour", reflect.TypeOf((*MockTourismInterface)(nil).CreateTour), ctx, actor, tour, imageFiles, videFiles)
+}
+
+// CreateTourCategory mocks base method.
+func (m *MockTourismInterface) CreateTourCategory(ctx context.Context, actor entity.AuditActor, tourCategory *entity.CreateTourCategoryDTO) (*entity.TourCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTourCategory", ctx, actor, tourCategory)
+	ret0, _ := ret[0].(*entity.TourCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTourCategory indicates an expected call of CreateTourCategory.
+func (mr *MockTourismInterfaceMockRecorder) CreateTourCategory(ctx, actor, tourCategory interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTourCategory", reflect.TypeOf((*MockTourismInterface)(nil).CreateTourCategory), ctx, actor, tourCategory)
+}
+
+// CreateTourEvent mocks base method.
+func (m *MockTourismInterface) CreateTourEvent(ctx context.Context, actor entity.AuditActor, tourEvent *entity.TourEvent) (*entity.TourEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTourEvent", ctx, actor, tourEvent)
+	ret0, _ := ret[0].(*entity.TourEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTourEvent indicates an expected call of CreateTourEvent.
+func (mr *MockTourismInterfaceMockRecorder) CreateTourEvent(ctx, actor, tourEvent interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTourEvent", reflect.TypeOf((*MockTourismInterface)(nil).CreateTourEvent), ctx, actor, tourEvent)
+}
+
+// CreateTourLocation mocks base method.
+func (m *MockTourismInterface) CreateTourLocation(ctx context.Context, actor entity.AuditActor, tourLocation *entity.CreateTourLocationDTO) (*entity.TourLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTourLocation", ctx, actor, tourLocation)
+	ret0, _ := ret[0].(*entity.TourLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTourLocation indicates an expected call of CreateTourLocation.
+func (mr *MockTourismInterfaceMockRecorder) CreateTourLocation(ctx, actor, tourLocation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTourLocation", reflect.TypeOf((*MockTourismInterface)(nil).CreateTourLocation), ctx, actor, tourLocation)
+}
+
+// FailPurchase mocks base method.
+func (m *MockTourismInterface) FailPurchase(ctx context.Context, purchase *entity.Purchase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailPurchase", ctx, purchase)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailPurchase indicates an expected call of FailPurchase.
+func (mr *MockTourismInterfaceMockRecorder) FailPurchase(ctx, purchase interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailPurchase", reflect.TypeOf((*MockTourismInterface)(nil).FailPurchase), ctx, purchase)
+}
+
+// GetAllCategories mocks base method.
+func (m *MockTourismInterface) GetAllCategories(ctx context.Context, lang string) ([]entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCategories", ctx, lang)
+	ret0, _ := ret[0].([]entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCategories indicates an expected call of GetAllCategories.
+func (mr *MockTourismInterfaceMockRecorder) GetAllCategories(ctx, lang interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCategories", reflect.TypeOf((*MockTourismInterface)(nil).GetAllCategories), ctx, lang)
+}
+
+// GetFilteredTourEvents mocks base method.
+func (m *MockTourismInterface) GetFilteredTourEvents(ctx context.Context, filter *entity.TourEventFilter) ([]*entity.TourEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilteredTourEvents", ctx, filter)
+	ret0, _ := ret[0].([]*entity.TourEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilteredTourEvents indicates an expected call of GetFilteredTourEvents.
+func (mr *MockTourismInterfaceMockRecorder) GetFilteredTourEvents(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilteredTourEvents", reflect.TypeOf((*MockTourismInterface)(nil).GetFilteredTourEvents), ctx, filter)
+}
+
+// GetTourByID mocks base method.
+func (m *MockTourismInterface) GetTourByID(ctx context.Context, ID, lang string) (*entity.Tour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTourByID", ctx, ID, lang)
+	ret0, _ := ret[0].(*entity.Tour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTourByID indicates an expected call of GetTourByID.
+func (mr *MockTourismInterfaceMockRecorder) GetTourByID(ctx, ID, lang interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTourByID", reflect.TypeOf((*MockTourismInterface)(nil).GetTourByID), ctx, ID, lang)
+}
+
+// GetTourLocationByID mocks base method.
+func (m *MockTourismInterface) GetTourLocationByID(ctx context.Context, id uuid.UUID) (*entity.TourLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTourLocationByID", ctx, id)
+	ret0, _ := ret[0].(*entity.TourLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTourLocationByID indicates an expected call of GetTourLocationByID.
+func (mr *MockTourismInterfaceMockRecorder) GetTourLocationByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTourLocationByID", reflect.TypeOf((*MockTourismInterface)(nil).GetTourLocationByID), ctx, id)
+}
+
+// GetTours mocks base method.
+func (m *MockTourismInterface) GetTours(ctx context.Context, lang string) ([]entity.Tour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTours", ctx, lang)
+	ret0, _ := ret[0].([]entity.Tour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTours indicates an expected call of GetTours.
+func (mr *MockTourismInterfaceMockRecorder) GetTours(ctx, lang interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTours", reflect.TypeOf((*MockTourismInterface)(nil).GetTours), ctx, lang)
+}
+
+// Languages mocks base method.
+func (m *MockTourismInterface) Languages() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Languages")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// Languages indicates an expected call of Languages.
+func (mr *MockTourismInterfaceMockRecorder) Languages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Languages", reflect.TypeOf((*MockTourismInterface)(nil).Languages))
+}
+
+// MaxCreateTourSize mocks base method.
+func (m *MockTourismInterface) MaxCreateTourSize() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxCreateTourSize")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// MaxCreateTourSize indicates an expected call of MaxCreateTourSize.
+func (mr *MockTourismInterfaceMockRecorder) MaxCreateTourSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxCreateTourSize", reflect.TypeOf((*MockTourismInterface)(nil).MaxCreateTourSize))
+}
+
+// PayTourEvent mocks base method.
+func (m *MockTourismInterface) PayTourEvent(ctx context.Context, purchase *entity.Purchase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayTourEvent", ctx, purchase)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PayTourEvent indicates an expected call of PayTourEvent.
+func (mr *MockTourismInterfaceMockRecorder) PayTourEvent(ctx, purchase interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayTourEvent", reflect.TypeOf((*MockTourismInterface)(nil).PayTourEvent), ctx, purchase)
+}
+
+// SearchToursByLocation mocks base method.
+func (m *MockTourismInterface) SearchToursByLocation(ctx context.Context, filter *entity.TourGeoSearchFilter, lang string) (*geo.FeatureCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchToursByLocation", ctx, filter, lang)
+	ret0, _ := ret[0].(*geo.FeatureCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchToursByLocation indicates an expected call of SearchToursByLocation.
+func (mr *MockTourismInterfaceMockRecorder) SearchToursByLocation(ctx, filter, lang interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchToursByLocation", reflect.TypeOf((*MockTourismInterface)(nil).SearchToursByLocation), ctx, filter, lang)
+}
+
+// TourEventExists mocks base method.
+func (m *MockTourismInterface) TourEventExists(ctx context.Context, tourEventID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TourEventExists", ctx, tourEventID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TourEventExists indicates an expected call of TourEventExists.
+func (mr *MockTourismInterfaceMockRecorder) TourEventExists(ctx, tourEventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TourEventExists", reflect.TypeOf((*MockTourismInterface)(nil).TourEventExists), ctx, tourEventID)
+}
+
+// TourExists mocks base method.
+func (m *MockTourismInterface) TourExists(ctx context.Context, tourID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TourExists", ctx, tourID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TourExists indicates an expected call of TourExists.
+func (mr *MockTourismInterfaceMockRecorder) TourExists(ctx, tourID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TourExists", reflect.TypeOf((*MockTourismInterface)(nil).TourExists), ctx, tourID)
+}
+
+// MockUserInterface is a mock of UserInterface interface.
+type MockUserInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserInterfaceMockRecorder
+}
+
+// MockUserInterfaceMockRecorder is the mock recorder for MockUserInterface.
+type MockUserInterfaceMockRecorder struct {
+	mock *MockUserInterface
+}
+
+// NewMockUserInterface creates a new mock instance.
+func NewMockUserInterface(ctrl *gomock.Controller) *MockUserInterface {
+	mock := &MockUserInterface{ctrl: ctrl}
+	mock.recorder = &MockUserInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserInterface) EXPECT() *MockUserInterfaceMockRecorder {
+	return m.recorder
+}
+
+// LoginUser mocks base method.
+func (m *MockUserInterface) LoginUser(ctx context.Context, user *entity.LoginUserDTO) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginUser", ctx, user)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginUser indicates an expected call of LoginUser.
+func (mr *MockUserInterfaceMockRecorder) LoginUser(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockUserInterface)(nil).LoginUser), ctx, user)
+}
+
+// RegisterUser mocks base method.
+func (m *MockUserInterface) RegisterUser(ctx context.Context, user *entity.User) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterUser", ctx, user)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterUser indicates an expected call of RegisterUser.
+func (mr *MockUserInterfaceMockRecorder) RegisterUser(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockUserInterface)(nil).RegisterUser), ctx, user)
+}
+
+// MockReviewInterface is a mock of ReviewInterface interface.
+type MockReviewInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewInterfaceMockRecorder
+}
+
+// MockReviewInterfaceMockRecorder is the mock recorder for MockReviewInterface.
+type MockReviewInterfaceMockRecorder struct {
+	mock *MockReviewInterface
+}
+
+// NewMockReviewInterface creates a new mock instance.
+func NewMockReviewInterface(ctrl *gomock.Controller) *MockReviewInterface {
+	mock := &MockReviewInterface{ctrl: ctrl}
+	mock.recorder = &MockReviewInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewInterface) EXPECT() *MockReviewInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateReview mocks base method.
+func (m *MockReviewInterface) CreateReview(ctx context.Context, review *entity.Review, photoFiles []*multipart.FileHeader) (*entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReview", ctx, review, photoFiles)
+	ret0, _ := ret[0].(*entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockReviewInterfaceMockRecorder) CreateReview(ctx, review, photoFiles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockReviewInterface)(nil).CreateReview), ctx, review, photoFiles)
+}
+
+// DeleteReview mocks base method.
+func (m *MockReviewInterface) DeleteReview(ctx context.Context, actor entity.AuditActor, reviewID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReview", ctx, actor, reviewID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReview indicates an expected call of DeleteReview.
+func (mr *MockReviewInterfaceMockRecorder) DeleteReview(ctx, actor, reviewID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReview", reflect.TypeOf((*MockReviewInterface)(nil).DeleteReview), ctx, actor, reviewID)
+}
+
+// GetTourReviews mocks base method.
+func (m *MockReviewInterface) GetTourReviews(ctx context.Context, tourID uuid.UUID, filter *entity.ReviewFilter) ([]*entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTourReviews", ctx, tourID, filter)
+	ret0, _ := ret[0].([]*entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTourReviews indicates an expected call of GetTourReviews.
+func (mr *MockReviewInterfaceMockRecorder) GetTourReviews(ctx, tourID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTourReviews", reflect.TypeOf((*MockReviewInterface)(nil).GetTourReviews), ctx, tourID, filter)
+}
+
+// ModerateReview mocks base method.
+func (m *MockReviewInterface) ModerateReview(ctx context.Context, actor entity.AuditActor, reviewID uuid.UUID, status string) (*entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerateReview", ctx, actor, reviewID, status)
+	ret0, _ := ret[0].(*entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModerateReview indicates an expected call of ModerateReview.
+func (mr *MockReviewInterfaceMockRecorder) ModerateReview(ctx, actor, reviewID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerateReview", reflect.TypeOf((*MockReviewInterface)(nil).ModerateReview), ctx, actor, reviewID, status)
+}
+
+// ReplyToReview mocks base method.
+func (m *MockReviewInterface) ReplyToReview(ctx context.Context, providerID, reviewID uuid.UUID, reply string) (*entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplyToReview", ctx, providerID, reviewID, reply)
+	ret0, _ := ret[0].(*entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplyToReview indicates an expected call of ReplyToReview.
+func (mr *MockReviewInterfaceMockRecorder) ReplyToReview(ctx, providerID, reviewID, reply interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplyToReview", reflect.TypeOf((*MockReviewInterface)(nil).ReplyToReview), ctx, providerID, reviewID, reply)
+}
+
+// MockWishlistInterface is a mock of WishlistInterface interface.
+type MockWishlistInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockWishlistInterfaceMockRecorder
+}
+
+// MockWishlistInterfaceMockRecorder is the mock recorder for MockWishlistInterface.
+type MockWishlistInterfaceMockRecorder struct {
+	mock *MockWishlistInterface
+}
+
+// NewMockWishlistInterface creates a new mock instance.
+func NewMockWishlistInterface(ctrl *gomock.Controller) *MockWishlistInterface {
+	mock := &MockWishlistInterface{ctrl: ctrl}
+	mock.recorder = &MockWishlistInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWishlistInterface) EXPECT() *MockWishlistInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddFavorite mocks base method.
+func (m *MockWishlistInterface) AddFavorite(ctx context.Context, userID, tourID uuid.UUID) (*entity.Favorite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFavorite", ctx, userID, tourID)
+	ret0, _ := ret[0].(*entity.Favorite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFavorite indicates an expected call of AddFavorite.
+func (mr *MockWishlistInterfaceMockRecorder) AddFavorite(ctx, userID, tourID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFavorite", reflect.TypeOf((*MockWishlistInterface)(nil).AddFavorite), ctx, userID, tourID)
+}
+
+// CreateSavedSearch mocks base method.
+func (m *MockWishlistInterface) CreateSavedSearch(ctx context.Context, userID uuid.UUID, dto *entity.CreateSavedSearchDTO) (*entity.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSavedSearch", ctx, userID, dto)
+	ret0, _ := ret[0].(*entity.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSavedSearch indicates an expected call of CreateSavedSearch.
+func (mr *MockWishlistInterfaceMockRecorder) CreateSavedSearch(ctx, userID, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavedSearch", reflect.TypeOf((*MockWishlistInterface)(nil).CreateSavedSearch), ctx, userID, dto)
+}
+
+// DeleteSavedSearch mocks base method.
+func (m *MockWishlistInterface) DeleteSavedSearch(ctx context.Context, userID, savedSearchID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSavedSearch", ctx, userID, savedSearchID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSavedSearch indicates an expected call of DeleteSavedSearch.
+func (mr *MockWishlistInterfaceMockRecorder) DeleteSavedSearch(ctx, userID, savedSearchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSavedSearch", reflect.TypeOf((*MockWishlistInterface)(nil).DeleteSavedSearch), ctx, userID, savedSearchID)
+}
+
+// GetFavorites mocks base method.
+func (m *MockWishlistInterface) GetFavorites(ctx context.Context, userID uuid.UUID) ([]*entity.Favorite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFavorites", ctx, userID)
+	ret0, _ := ret[0].([]*entity.Favorite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFavorites indicates an expected call of GetFavorites.
+func (mr *MockWishlistInterfaceMockRecorder) GetFavorites(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFavorites", reflect.TypeOf((*MockWishlistInterface)(nil).GetFavorites), ctx, userID)
+}
+
+// GetNotifications mocks base method.
+func (m *MockWishlistInterface) GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool) ([]*entity.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", ctx, userID, unreadOnly)
+	ret0, _ := ret[0].([]*entity.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockWishlistInterfaceMockRecorder) GetNotifications(ctx, userID, unreadOnly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockWishlistInterface)(nil).GetNotifications), ctx, userID, unreadOnly)
+}
+
+// GetSavedSearches mocks base method.
+func (m *MockWishlistInterface) GetSavedSearches(ctx context.Context, userID uuid.UUID) ([]*entity.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavedSearches", ctx, userID)
+	ret0, _ := ret[0].([]*entity.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSavedSearches indicates an expected call of GetSavedSearches.
+func (mr *MockWishlistInterfaceMockRecorder) GetSavedSearches(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedSearches", reflect.TypeOf((*MockWishlistInterface)(nil).GetSavedSearches), ctx, userID)
+}
+
+// MarkNotificationRead mocks base method.
+func (m *MockWishlistInterface) MarkNotificationRead(ctx context.Context, userID, notificationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationRead", ctx, userID, notificationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationRead indicates an expected call of MarkNotificationRead.
+func (mr *MockWishlistInterfaceMockRecorder) MarkNotificationRead(ctx, userID, notificationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockWishlistInterface)(nil).MarkNotificationRead), ctx, userID, notificationID)
+}
+
+// RemoveFavorite mocks base method.
+func (m *MockWishlistInterface) RemoveFavorite(ctx context.Context, userID, tourID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFavorite", ctx, userID, tourID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFavorite indicates an expected call of RemoveFavorite.
+func (mr *MockWishlistInterfaceMockRecorder) RemoveFavorite(ctx, userID, tourID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFavorite", reflect.TypeOf((*MockWishlistInterface)(nil).RemoveFavorite), ctx, userID, tourID)
+}
+
+// RunSavedSearches mocks base method.
+func (m *MockWishlistInterface) RunSavedSearches(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunSavedSearches", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunSavedSearches indicates an expected call of RunSavedSearches.
+func (mr *MockWishlistInterfaceMockRecorder) RunSavedSearches(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunSavedSearches", reflect.TypeOf((*MockWishlistInterface)(nil).RunSavedSearches), ctx)
+}
+
+// MockItineraryInterface is a mock of ItineraryInterface interface.
+type MockItineraryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockItineraryInterfaceMockRecorder
+}
+
+// MockItineraryInterfaceMockRecorder is the mock recorder for MockItineraryInterface.
+type MockItineraryInterfaceMockRecorder struct {
+	mock *MockItineraryInterface
+}
+
+// NewMockItineraryInterface creates a new mock instance.
+func NewMockItineraryInterface(ctrl *gomock.Controller) *MockItineraryInterface {
+	mock := &MockItineraryInterface{ctrl: ctrl}
+	mock.recorder = &MockItineraryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItineraryInterface) EXPECT() *MockItineraryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ExportItineraryGPX mocks base method.
+func (m *MockItineraryInterface) ExportItineraryGPX(ctx context.Context, tourID uuid.UUID, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportItineraryGPX", ctx, tourID, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportItineraryGPX indicates an expected call of ExportItineraryGPX.
+func (mr *MockItineraryInterfaceMockRecorder) ExportItineraryGPX(ctx, tourID, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportItineraryGPX", reflect.TypeOf((*MockItineraryInterface)(nil).ExportItineraryGPX), ctx, tourID, w)
+}
+
+// ExportItineraryGeoJSON mocks base method.
+func (m *MockItineraryInterface) ExportItineraryGeoJSON(ctx context.Context, tourID uuid.UUID) (*geo.FeatureCollection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportItineraryGeoJSON", ctx, tourID)
+	ret0, _ := ret[0].(*geo.FeatureCollection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportItineraryGeoJSON indicates an expected call of ExportItineraryGeoJSON.
+func (mr *MockItineraryInterfaceMockRecorder) ExportItineraryGeoJSON(ctx, tourID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportItineraryGeoJSON", reflect.TypeOf((*MockItineraryInterface)(nil).ExportItineraryGeoJSON), ctx, tourID)
+}
+
+// GetItinerary mocks base method.
+func (m *MockItineraryInterface) GetItinerary(ctx context.Context, tourID uuid.UUID) (*entity.Itinerary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItinerary", ctx, tourID)
+	ret0, _ := ret[0].(*entity.Itinerary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItinerary indicates an expected call of GetItinerary.
+func (mr *MockItineraryInterfaceMockRecorder) GetItinerary(ctx, tourID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItinerary", reflect.TypeOf((*MockItineraryInterface)(nil).GetItinerary), ctx, tourID)
+}
+
+// ImportItinerary mocks base method.
+func (m *MockItineraryInterface) ImportItinerary(ctx context.Context, actor entity.AuditActor, tourID uuid.UUID, file *multipart.FileHeader) (*entity.Itinerary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportItinerary", ctx, actor, tourID, file)
+	ret0, _ := ret[0].(*entity.Itinerary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportItinerary indicates an expected call of ImportItinerary.
+func (mr *MockItineraryInterfaceMockRecorder) ImportItinerary(ctx, actor, tourID, file interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportItinerary", reflect.TypeOf((*MockItineraryInterface)(nil).ImportItinerary), ctx, actor, tourID, file)
+}
+
+// UpdateItinerary mocks base method.
+func (m *MockItineraryInterface) UpdateItinerary(ctx context.Context, actor entity.AuditActor, tourID uuid.UUID, dto *entity.UpdateItineraryDTO) (*entity.Itinerary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItinerary", ctx, actor, tourID, dto)
+	ret0, _ := ret[0].(*entity.Itinerary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateItinerary indicates an expected call of UpdateItinerary.
+func (mr *MockItineraryInterfaceMockRecorder) UpdateItinerary(ctx, actor, tourID, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItinerary", reflect.TypeOf((*MockItineraryInterface)(nil).UpdateItinerary), ctx, actor, tourID, dto)
+}
+
+// MockTourMediaInterface is a mock of TourMediaInterface interface.
+type MockTourMediaInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTourMediaInterfaceMockRecorder
+}
+
+// MockTourMediaInterfaceMockRecorder is the mock recorder for MockTourMediaInterface.
+type MockTourMediaInterfaceMockRecorder struct {
+	mock *MockTourMediaInterface
+}
+
+// NewMockTourMediaInterface creates a new mock instance.
+func NewMockTourMediaInterface(ctrl *gomock.Controller) *MockTourMediaInterface {
+	mock := &MockTourMediaInterface{ctrl: ctrl}
+	mock.recorder = &MockTourMediaInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTourMediaInterface) EXPECT() *MockTourMediaInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddTourImages mocks base method.
+func (m *MockTourMediaInterface) AddTourImages(ctx context.Context, actor entity.AuditActor, tourID uuid.UUID, files []*multipart.FileHeader, meta []entity.MediaMeta) ([]entity.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTourImages", ctx, actor, tourID, files, meta)
+	ret0, _ := ret[0].([]entity.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTourImages indicates an expected call of AddTourImages.
+func (mr *MockTourMediaInterfaceMockRecorder) AddTourImages(ctx, actor, tourID, files, meta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTourImages", reflect.TypeOf((*MockTourMediaInterface)(nil).AddTourImages), ctx, actor, tourID, files, meta)
+}
+
+// AddTourVideos mocks base method.
+func (m *MockTourMediaInterface) AddTourVideos(ctx context.Context, actor entity.AuditActor, tourID uuid.UUID, files []*multipart.FileHeader, meta []entity.MediaMeta) ([]entity.Video, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTourVideos", ctx, actor, tourID, files, meta)
+	ret0, _ := ret[0].([]entity.Video)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTourVideos indicates an expected call of AddTourVideos.
+func (mr *MockTourMediaInterfaceMockRecorder) AddTourVideos(ctx, actor, tourID, files, meta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTourVideos", reflect.TypeOf((*MockTourMediaInterface)(nil).AddTourVideos), ctx, actor, tourID, files, meta)
+}
+
+// CollectOrphanedMedia mocks base method.
+func (m *MockTourMediaInterface) CollectOrphanedMedia(ctx context.Context, gracePeriod time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectOrphanedMedia", ctx, gracePeriod)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectOrphanedMedia indicates an expected call of CollectOrphanedMedia.
+func (mr *MockTourMediaInterfaceMockRecorder) CollectOrphanedMedia(ctx, gracePeriod interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectOrphanedMedia", reflect.TypeOf((*MockTourMediaInterface)(nil).CollectOrphanedMedia), ctx, gracePeriod)
+}
+
+// DeleteTourImage mocks base method.
+func (m *MockTourMediaInterface) DeleteTourImage(ctx context.Context, actor entity.AuditActor, tourID, imageID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTourImage", ctx, actor, tourID, imageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTourImage indicates an expected call of DeleteTourImage.
+func (mr *MockTourMediaInterfaceMockRecorder) DeleteTourImage(ctx, actor, tourID, imageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTourImage", reflect.TypeOf((*MockTourMediaInterface)(nil).DeleteTourImage), ctx, actor, tourID, imageID)
+}
+
+// DeleteTourVideo mocks base method.
+func (m *MockTourMediaInterface) DeleteTourVideo(ctx context.Context, actor entity.AuditActor, tourID, videoID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTourVideo", ctx, actor, tourID, videoID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTourVideo indicates an expected call of DeleteTourVideo.
+func (mr *MockTourMediaInterfaceMockRecorder) DeleteTourVideo(ctx, actor, tourID, videoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTourVideo", reflect.TypeOf((*MockTourMediaInterface)(nil).DeleteTourVideo), ctx, actor, tourID, videoID)
+}
+
+// ReorderTourImages mocks base method.
+func (m *MockTourMediaInterface) ReorderTourImages(ctx context.Context, providerID, tourID uuid.UUID, ids []uuid.UUID) (*entity.Tour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderTourImages", ctx, providerID, tourID, ids)
+	ret0, _ := ret[0].(*entity.Tour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderTourImages indicates an expected call of ReorderTourImages.
+func (mr *MockTourMediaInterfaceMockRecorder) ReorderTourImages(ctx, providerID, tourID, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderTourImages", reflect.TypeOf((*MockTourMediaInterface)(nil).ReorderTourImages), ctx, providerID, tourID, ids)
+}
+
+// ReorderTourVideos mocks base method.
+func (m *MockTourMediaInterface) ReorderTourVideos(ctx context.Context, providerID, tourID uuid.UUID, ids []uuid.UUID) (*entity.Tour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderTourVideos", ctx, providerID, tourID, ids)
+	ret0, _ := ret[0].(*entity.Tour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderTourVideos indicates an expected call of ReorderTourVideos.
+func (mr *MockTourMediaInterfaceMockRecorder) ReorderTourVideos(ctx, providerID, tourID, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderTourVideos", reflect.TypeOf((*MockTourMediaInterface)(nil).ReorderTourVideos), ctx, providerID, tourID, ids)
+}
+
+// SetCoverImage mocks base method.
+func (m *MockTourMediaInterface) SetCoverImage(ctx context.Context, providerID, tourID, imageID uuid.UUID) (*entity.Tour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCoverImage", ctx, providerID, tourID, imageID)
+	ret0, _ := ret[0].(*entity.Tour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCoverImage indicates an expected call of SetCoverImage.
+func (mr *MockTourMediaInterfaceMockRecorder) SetCoverImage(ctx, providerID, tourID, imageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCoverImage", reflect.TypeOf((*MockTourMediaInterface)(nil).SetCoverImage), ctx, providerID, tourID, imageID)
+}
+
+// UpdateTourImage mocks base method.
+func (m *MockTourMediaInterface) UpdateTourImage(ctx context.Context, providerID, tourID, imageID uuid.UUID, dto *entity.UpdateMediaDTO) (*entity.Tour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTourImage", ctx, providerID, tourID, imageID, dto)
+	ret0, _ := ret[0].(*entity.Tour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTourImage indicates an expected call of UpdateTourImage.
+func (mr *MockTourMediaInterfaceMockRecorder) UpdateTourImage(ctx, providerID, tourID, imageID, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTourImage", reflect.TypeOf((*MockTourMediaInterface)(nil).UpdateTourImage), ctx, providerID, tourID, imageID, dto)
+}
+
+// UpdateTourVideo mocks base method.
+func (m *MockTourMediaInterface) UpdateTourVideo(ctx context.Context, providerID, tourID, videoID uuid.UUID, dto *entity.UpdateMediaDTO) (*entity.Tour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTourVideo", ctx, providerID, tourID, videoID, dto)
+	ret0, _ := ret[0].(*entity.Tour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTourVideo indicates an expected call of UpdateTourVideo.
+func (mr *MockTourMediaInterfaceMockRecorder) UpdateTourVideo(ctx, providerID, tourID, videoID, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTourVideo", reflect.TypeOf((*MockTourMediaInterface)(nil).UpdateTourVideo), ctx, providerID, tourID, videoID, dto)
+}
+
+// MockUploadInterface is a mock of UploadInterface interface.
+type MockUploadInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadInterfaceMockRecorder
+}
+
+// MockUploadInterfaceMockRecorder is the mock recorder for MockUploadInterface.
+type MockUploadInterfaceMockRecorder struct {
+	mock *MockUploadInterface
+}
+
+// NewMockUploadInterface creates a new mock instance.
+func NewMockUploadInterface(ctrl *gomock.Controller) *MockUploadInterface {
+	mock := &MockUploadInterface{ctrl: ctrl}
+	mock.recorder = &MockUploadInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadInterface) EXPECT() *MockUploadInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateUpload mocks base method.
+func (m *MockUploadInterface) CreateUpload(ctx context.Context, actor entity.AuditActor, dto *entity.CreateUploadDTO) (*entity.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUpload", ctx, actor, dto)
+	ret0, _ := ret[0].(*entity.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUpload indicates an expected call of CreateUpload.
+func (mr *MockUploadInterfaceMockRecorder) CreateUpload(ctx, actor, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUpload", reflect.TypeOf((*MockUploadInterface)(nil).CreateUpload), ctx, actor, dto)
+}
+
+// DeleteExpiredUploads mocks base method.
+func (m *MockUploadInterface) DeleteExpiredUploads(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredUploads", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredUploads indicates an expected call of DeleteExpiredUploads.
+func (mr *MockUploadInterfaceMockRecorder) DeleteExpiredUploads(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredUploads", reflect.TypeOf((*MockUploadInterface)(nil).DeleteExpiredUploads), ctx)
+}
+
+// DeleteUpload mocks base method.
+func (m *MockUploadInterface) DeleteUpload(ctx context.Context, userID, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUpload", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUpload indicates an expected call of DeleteUpload.
+func (mr *MockUploadInterfaceMockRecorder) DeleteUpload(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUpload", reflect.TypeOf((*MockUploadInterface)(nil).DeleteUpload), ctx, userID, id)
+}
+
+// GetUpload mocks base method.
+func (m *MockUploadInterface) GetUpload(ctx context.Context, userID, id uuid.UUID) (*entity.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpload", ctx, userID, id)
+	ret0, _ := ret[0].(*entity.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpload indicates an expected call of GetUpload.
+func (mr *MockUploadInterfaceMockRecorder) GetUpload(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpload", reflect.TypeOf((*MockUploadInterface)(nil).GetUpload), ctx, userID, id)
+}
+
+// MaxSize mocks base method.
+func (m *MockUploadInterface) MaxSize() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxSize")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// MaxSize indicates an expected call of MaxSize.
+func (mr *MockUploadInterfaceMockRecorder) MaxSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxSize", reflect.TypeOf((*MockUploadInterface)(nil).MaxSize))
+}
+
+// WriteUploadChunk mocks base method.
+func (m *MockUploadInterface) WriteUploadChunk(ctx context.Context, actor entity.AuditActor, id uuid.UUID, offset int64, chunk io.Reader, checksum *tus.Checksum) (*entity.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteUploadChunk", ctx, actor, id, offset, chunk, checksum)
+	ret0, _ := ret[0].(*entity.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WriteUploadChunk indicates an expected call of WriteUploadChunk.
+func (mr *MockUploadInterfaceMockRecorder) WriteUploadChunk(ctx, actor, id, offset, chunk, checksum interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteUploadChunk", reflect.TypeOf((*MockUploadInterface)(nil).WriteUploadChunk), ctx, actor, id, offset, chunk, checksum)
+}
+
+// MockDocumentInterface is a mock of DocumentInterface interface.
+type MockDocumentInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockDocumentInterfaceMockRecorder
+}
+
+// MockDocumentInterfaceMockRecorder is the mock recorder for MockDocumentInterface.
+type MockDocumentInterfaceMockRecorder struct {
+	mock *MockDocumentInterface
+}
+
+// NewMockDocumentInterface creates a new mock instance.
+func NewMockDocumentInterface(ctrl *gomock.Controller) *MockDocumentInterface {
+	mock := &MockDocumentInterface{ctrl: ctrl}
+	mock.recorder = &MockDocumentInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDocumentInterface) EXPECT() *MockDocumentInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteDocument mocks base method.
+func (m *MockDocumentInterface) DeleteDocument(ctx context.Context, actor entity.AuditActor, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDocument", ctx, actor, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDocument indicates an expected call of DeleteDocument.
+func (mr *MockDocumentInterfaceMockRecorder) DeleteDocument(ctx, actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDocument", reflect.TypeOf((*MockDocumentInterface)(nil).DeleteDocument), ctx, actor, id)
+}
+
+// GetDocument mocks base method.
+func (m *MockDocumentInterface) GetDocument(ctx context.Context, providerID, id uuid.UUID) (*entity.Document, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDocument", ctx, providerID, id)
+	ret0, _ := ret[0].(*entity.Document)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDocument indicates an expected call of GetDocument.
+func (mr *MockDocumentInterfaceMockRecorder) GetDocument(ctx, providerID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDocument", reflect.TypeOf((*MockDocumentInterface)(nil).GetDocument), ctx, providerID, id)
+}
+
+// GetDocuments mocks base method.
+func (m *MockDocumentInterface) GetDocuments(ctx context.Context, providerID uuid.UUID) ([]*entity.Document, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDocuments", ctx, providerID)
+	ret0, _ := ret[0].([]*entity.Document)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDocuments indicates an expected call of GetDocuments.
+func (mr *MockDocumentInterfaceMockRecorder) GetDocuments(ctx, providerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDocuments", reflect.TypeOf((*MockDocumentInterface)(nil).GetDocuments), ctx, providerID)
+}
+
+// ReviewDocuments mocks base method.
+func (m *MockDocumentInterface) ReviewDocuments(ctx context.Context, actor entity.AuditActor, filter *entity.DocumentFilter) ([]*entity.Document, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewDocuments", ctx, actor, filter)
+	ret0, _ := ret[0].([]*entity.Document)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewDocuments indicates an expected call of ReviewDocuments.
+func (mr *MockDocumentInterfaceMockRecorder) ReviewDocuments(ctx, actor, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewDocuments", reflect.TypeOf((*MockDocumentInterface)(nil).ReviewDocuments), ctx, actor, filter)
+}
+
+// UploadDocument mocks base method.
+func (m *MockDocumentInterface) UploadDocument(ctx context.Context, actor entity.AuditActor, dto *entity.CreateDocumentDTO, file *multipart.FileHeader) (*entity.Document, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadDocument", ctx, actor, dto, file)
+	ret0, _ := ret[0].(*entity.Document)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadDocument indicates an expected call of UploadDocument.
+func (mr *MockDocumentInterfaceMockRecorder) UploadDocument(ctx, actor, dto, file interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadDocument", reflect.TypeOf((*MockDocumentInterface)(nil).UploadDocument), ctx, actor, dto, file)
+}
+
+// MockTranslationInterface is a mock of TranslationInterface interface.
+type MockTranslationInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTranslationInterfaceMockRecorder
+}
+
+// MockTranslationInterfaceMockRecorder is the mock recorder for MockTranslationInterface.
+type MockTranslationInterfaceMockRecorder struct {
+	mock *MockTranslationInterface
+}
+
+// NewMockTranslationInterface creates a new mock instance.
+func NewMockTranslationInterface(ctrl *gomock.Controller) *MockTranslationInterface {
+	mock := &MockTranslationInterface{ctrl: ctrl}
+	mock.recorder = &MockTranslationInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTranslationInterface) EXPECT() *MockTranslationInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteTourLocale mocks base method.
+func (m *MockTranslationInterface) DeleteTourLocale(ctx context.Context, actor entity.AuditActor, tourID uuid.UUID, lang string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTourLocale", ctx, actor, tourID, lang)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTourLocale indicates an expected call of DeleteTourLocale.
+func (mr *MockTranslationInterfaceMockRecorder) DeleteTourLocale(ctx, actor, tourID, lang interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTourLocale", reflect.TypeOf((*MockTranslationInterface)(nil).DeleteTourLocale), ctx, actor, tourID, lang)
+}
+
+// GetTourLocales mocks base method.
+func (m *MockTranslationInterface) GetTourLocales(ctx context.Context, providerID, tourID uuid.UUID) ([]entity.TourLocale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTourLocales", ctx, providerID, tourID)
+	ret0, _ := ret[0].([]entity.TourLocale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTourLocales indicates an expected call of GetTourLocales.
+func (mr *MockTranslationInterfaceMockRecorder) GetTourLocales(ctx, providerID, tourID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTourLocales", reflect.TypeOf((*MockTranslationInterface)(nil).GetTourLocales), ctx, providerID, tourID)
+}
+
+// SetCategoryLocale mocks base method.
+func (m *MockTranslationInterface) SetCategoryLocale(ctx context.Context, actor entity.AuditActor, categoryID uuid.UUID, lang string, dto *entity.UpdateCategoryLocaleDTO) (*entity.CategoryLocale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCategoryLocale", ctx, actor, categoryID, lang, dto)
+	ret0, _ := ret[0].(*entity.CategoryLocale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCategoryLocale indicates an expected call of SetCategoryLocale.
+func (mr *MockTranslationInterfaceMockRecorder) SetCategoryLocale(ctx, actor, categoryID, lang, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCategoryLocale", reflect.TypeOf((*MockTranslationInterface)(nil).SetCategoryLocale), ctx, actor, categoryID, lang, dto)
+}
+
+// SetTourLocale mocks base method.
+func (m *MockTranslationInterface) SetTourLocale(ctx context.Context, actor entity.AuditActor, tourID uuid.UUID, lang string, dto *entity.UpdateTourLocaleDTO) (*entity.TourLocale, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTourLocale", ctx, actor, tourID, lang, dto)
+	ret0, _ := ret[0].(*entity.TourLocale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTourLocale indicates an expected call of SetTourLocale.
+func (mr *MockTranslationInterfaceMockRecorder) SetTourLocale(ctx, actor, tourID, lang, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTourLocale", reflect.TypeOf((*MockTranslationInterface)(nil).SetTourLocale), ctx, actor, tourID, lang, dto)
+}
+
+// MockCategoryInterface is a mock of CategoryInterface interface.
+type MockCategoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryInterfaceMockRecorder
+}
+
+// MockCategoryInterfaceMockRecorder is the mock recorder for MockCategoryInterface.
+type MockCategoryInterfaceMockRecorder struct {
+	mock *MockCategoryInterface
+}
+
+// NewMockCategoryInterface creates a new mock instance.
+func NewMockCategoryInterface(ctrl *gomock.Controller) *MockCategoryInterface {
+	mock := &MockCategoryInterface{ctrl: ctrl}
+	mock.recorder = &MockCategoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryInterface) EXPECT() *MockCategoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateCategory mocks base method.
+func (m *MockCategoryInterface) CreateCategory(ctx context.Context, actor entity.AuditActor, dto *entity.CreateCategoryDTO) (*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", ctx, actor, dto)
+	ret0, _ := ret[0].(*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockCategoryInterfaceMockRecorder) CreateCategory(ctx, actor, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockCategoryInterface)(nil).CreateCategory), ctx, actor, dto)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockCategoryInterface) DeleteCategory(ctx context.Context, actor entity.AuditActor, categoryID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, actor, categoryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockCategoryInterfaceMockRecorder) DeleteCategory(ctx, actor, categoryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryInterface)(nil).DeleteCategory), ctx, actor, categoryID)
+}
+
+// GetCategoryTree mocks base method.
+func (m *MockCategoryInterface) GetCategoryTree(ctx context.Context, lang string) ([]*entity.CategoryNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryTree", ctx, lang)
+	ret0, _ := ret[0].([]*entity.CategoryNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryTree indicates an expected call of GetCategoryTree.
+func (mr *MockCategoryInterfaceMockRecorder) GetCategoryTree(ctx, lang interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryTree", reflect.TypeOf((*MockCategoryInterface)(nil).GetCategoryTree), ctx, lang)
+}
+
+// Languages mocks base method.
+func (m *MockCategoryInterface) Languages() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Languages")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// Languages indicates an expected call of Languages.
+func (mr *MockCategoryInterfaceMockRecorder) Languages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Languages", reflect.TypeOf((*MockCategoryInterface)(nil).Languages))
+}
+
+// UpdateCategory mocks base method.
+func (m *MockCategoryInterface) UpdateCategory(ctx context.Context, actor entity.AuditActor, categoryID uuid.UUID, dto *entity.UpdateCategoryDTO) (*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", ctx, actor, categoryID, dto)
+	ret0, _ := ret[0].(*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockCategoryInterfaceMockRecorder) UpdateCategory(ctx, actor, categoryID, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategoryInterface)(nil).UpdateCategory), ctx, actor, categoryID, dto)
+}
+
+// MockImageInterface is a mock of ImageInterface interface.
+type MockImageInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageInterfaceMockRecorder
+}
+
+// MockImageInterfaceMockRecorder is the mock recorder for MockImageInterface.
+type MockImageInterfaceMockRecorder struct {
+	mock *MockImageInterface
+}
+
+// NewMockImageInterface creates a new mock instance.
+func NewMockImageInterface(ctrl *gomock.Controller) *MockImageInterface {
+	mock := &MockImageInterface{ctrl: ctrl}
+	mock.recorder = &MockImageInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImageInterface) EXPECT() *MockImageInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetPendingImageIDs mocks base method.
+func (m *MockImageInterface) GetPendingImageIDs(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingImageIDs", ctx, limit)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingImageIDs indicates an expected call of GetPendingImageIDs.
+func (mr *MockImageInterfaceMockRecorder) GetPendingImageIDs(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingImageIDs", reflect.TypeOf((*MockImageInterface)(nil).GetPendingImageIDs), ctx, limit)
+}
+
+// ProcessImage mocks base method.
+func (m *MockImageInterface) ProcessImage(ctx context.Context, imageID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessImage", ctx, imageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessImage indicates an expected call of ProcessImage.
+func (mr *MockImageInterfaceMockRecorder) ProcessImage(ctx, imageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessImage", reflect.TypeOf((*MockImageInterface)(nil).ProcessImage), ctx, imageID)
+}
+
+// MockAdminInterface is a mock of AdminInterface interface.
+type MockAdminInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminInterfaceMockRecorder
+}
+
+// MockAdminInterfaceMockRecorder is the mock recorder for MockAdminInterface.
+type MockAdminInterfaceMockRecorder struct {
+	mock *MockAdminInterface
+}
+
+// NewMockAdminInterface creates a new mock instance.
+func NewMockAdminInterface(ctrl *gomock.Controller) *MockAdminInterface {
+	mock := &MockAdminInterface{ctrl: ctrl}
+	mock.recorder = &MockAdminInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminInterface) EXPECT() *MockAdminInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetAuditEvents mocks base method.
+func (m *MockAdminInterface) GetAuditEvents(ctx context.Context, filter *entity.AuditEventFilter) ([]*entity.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditEvents", ctx, filter)
+	ret0, _ := ret[0].([]*entity.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditEvents indicates an expected call of GetAuditEvents.
+func (mr *MockAdminInterfaceMockRecorder) GetAuditEvents(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEvents", reflect.TypeOf((*MockAdminInterface)(nil).GetAuditEvents), ctx, filter)
+}
+
+// GetUsers mocks base method.
+func (m *MockAdminInterface) GetUsers(ctx context.Context, actor entity.AuditActor) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx, actor)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockAdminInterfaceMockRecorder) GetUsers(ctx, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockAdminInterface)(nil).GetUsers), ctx, actor)
+}
+
+// VerifyAuditChain mocks base method.
+func (m *MockAdminInterface) VerifyAuditChain(ctx context.Context) (*entity.AuditIntegrityReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyAuditChain", ctx)
+	ret0, _ := ret[0].(*entity.AuditIntegrityReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyAuditChain indicates an expected call of VerifyAuditChain.
+func (mr *MockAdminInterfaceMockRecorder) VerifyAuditChain(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAuditChain", reflect.TypeOf((*MockAdminInterface)(nil).VerifyAuditChain), ctx)
+}
+
+// MockTourismRepo is a mock of TourismRepo interface.
+type MockTourismRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTourismRepoMockRecorder
+}
+
+// MockTourismRepoMockRecorder is the mock recorder for MockTourismRepo.
+type MockTourismRepoMockRecorder struct {
+	mock *MockTourismRepo
+}
+
+// NewMockTourismRepo creates a new mock instance.
+func NewMockTourismRepo(ctrl *gomock.Controller) *MockTourismRepo {
+	mock := &MockTourismRepo{ctrl: ctrl}
+	mock.recorder = &MockTourismRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTourismRepo) EXPECT() *MockTourismRepoMockRecorder {
+	return m.recorder
+}
+
+// CheckTourOwner mocks base method.
+func (m *MockTourismRepo) CheckTourOwner(ctx context.Context, tourID, userID uuid.UUID) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckTourOwner", ctx, tourID, userID)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CheckTourOwner indicates an expected call of CheckTourOwner.
+func (mr *MockTourismRepoMockRecorder) CheckTourOwner(ctx, tourID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckTourOwner", reflect.TypeOf((*MockTourismRepo)(nil).CheckTourOwner), ctx, tourID, userID)
+}
+
+// CreateCategory mocks base method.
+func (m *MockTourismRepo) CreateCategory(ctx context.Context, category *entity.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", ctx, category)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockTourismRepoMockRecorder) CreateCategory(ctx, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockTourismRepo)(nil).CreateCategory), ctx, category)
+}
+
+// CreatePurchase mocks base method.
+func (m *MockTourismRepo) CreatePurchase(ctx context.Context, purchase *entity.Purchase) (*entity.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePurchase", ctx, purchase)
+	ret0, _ := ret[0].(*entity.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePurchase indicates an expected call of CreatePurchase.
+func (mr *MockTourismRepoMockRecorder) CreatePurchase(ctx, purchase interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchase", reflect.TypeOf((*MockTourismRepo)(nil).CreatePurchase), ctx, purchase)
+}
+
+// CreateTour mocks base method.
+func (m *MockTourismRepo) CreateTour(ctx context.Context, tour *entity.Tour, imageFiles, videoFiles []*multipart.FileHeader) (*entity.Tour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTour", ctx, tour, imageFiles, videoFiles)
+	ret0, _ := ret[0].(*entity.Tour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTour indicates an expected call of CreateTour.
+func (mr *MockTourismRepoMockRecorder) CreateTour(ctx, tour, imageFiles, videoFiles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTour", reflect.TypeOf((*MockTourismRepo)(nil).CreateTour), ctx, tour, imageFiles, videoFiles)
+}
+
+// CreateTourCategory mocks base method.
+func (m *MockTourismRepo) CreateTourCategory(ctx context.Context, tourCategory *entity.CreateTourCategoryDTO) (*entity.TourCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTourCategory", ctx, tourCategory)
+	ret0, _ := ret[0].(*entity.TourCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTourCategory indicates an expected call of CreateTourCategory.
+func (mr *MockTourismRepoMockRecorder) CreateTourCategory(ctx, tourCategory interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTourCategory", reflect.TypeOf((*MockTourismRepo)(nil).CreateTourCategory), ctx, tourCategory)
+}
+
+// CreateTourEvent mocks base method.
+func (m *MockTourismRepo) CreateTourEvent(ctx context.Context, tourEvent *entity.TourEvent) (*entity.TourEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTourEvent", ctx, tourEvent)
+	ret0, _ := ret[0].(*entity.TourEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTourEvent indicates an expected call of CreateTourEvent.
+func (mr *MockTourismRepoMockRecorder) CreateTourEvent(ctx, tourEvent interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTourEvent", reflect.TypeOf((*MockTourismRepo)(nil).CreateTourEvent), ctx, tourEvent)
+}
+
+// CreateTourLocation mocks base method.
+func (m *MockTourismRepo) CreateTourLocation(ctx context.Context, tourLocation *entity.CreateTourLocationDTO) (*entity.TourLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTourLocation", ctx, tourLocation)
+	ret0, _ := ret[0].(*entity.TourLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTourLocation indicates an expected call of CreateTourLocation.
+func (mr *MockTourismRepoMockRecorder) CreateTourLocation(ctx, tourLocation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTourLocation", reflect.TypeOf((*MockTourismRepo)(nil).CreateTourLocation), ctx, tourLocation)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockTourismRepo) DeleteCategory(ctx context.Context, categoryID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, categoryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockTourismRepoMockRecorder) DeleteCategory(ctx, categoryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockTourismRepo)(nil).DeleteCategory), ctx, categoryID)
+}
+
+// FailPurchase mocks base method.
+func (m *MockTourismRepo) FailPurchase(ctx context.Context, purchase *entity.Purchase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailPurchase", ctx, purchase)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailPurchase indicates an expected call of FailPurchase.
+func (mr *MockTourismRepoMockRecorder) FailPurchase(ctx, purchase interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailPurchase", reflect.TypeOf((*MockTourismRepo)(nil).FailPurchase), ctx, purchase)
+}
+
+// GetAllCategories mocks base method.
+func (m *MockTourismRepo) GetAllCategories(ctx context.Context) ([]entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllCategories", ctx)
+	ret0, _ := ret[0].([]entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllCategories indicates an expected call of GetAllCategories.
+func (mr *MockTourismRepoMockRecorder) GetAllCategories(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCategories", reflect.TypeOf((*MockTourismRepo)(nil).GetAllCategories), ctx)
+}
+
+// GetCategoryByID mocks base method.
+func (m *MockTourismRepo) GetCategoryByID(ctx context.Context, categoryID uuid.UUID) (*entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryByID", ctx, categoryID)
+	ret0, _ := ret[0].(*entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryByID indicates an expected call of GetCategoryByID.
+func (mr *MockTourismRepoMockRecorder) GetCategoryByID(ctx, categoryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryByID", reflect.TypeOf((*MockTourismRepo)(nil).GetCategoryByID), ctx, categoryID)
+}
+
+// GetCategoryTourCounts mocks base method.
+func (m *MockTourismRepo) GetCategoryTourCounts(ctx context.Context) (map[uuid.UUID]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryTourCounts", ctx)
+	ret0, _ := ret[0].(map[uuid.UUID]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryTourCounts indicates an expected call of GetCategoryTourCounts.
+func (mr *MockTourismRepoMockRecorder) GetCategoryTourCounts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryTourCounts", reflect.TypeOf((*MockTourismRepo)(nil).GetCategoryTourCounts), ctx)
+}
+
+// GetFilteredTourEvents mocks base method.
+func (m *MockTourismRepo) GetFilteredTourEvents(ctx context.Context, filter *entity.TourEventFilter) ([]*entity.TourEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilteredTourEvents", ctx, filter)
+	ret0, _ := ret[0].([]*entity.TourEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilteredTourEvents indicates an expected call of GetFilteredTourEvents.
+func (mr *MockTourismRepoMockRecorder) GetFilteredTourEvents(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilteredTourEvents", reflect.TypeOf((*MockTourismRepo)(nil).GetFilteredTourEvents), ctx, filter)
+}
+
+// GetTourByID mocks base method.
+func (m *MockTourismRepo) GetTourByID(ctx context.Context, tourID string) (*entity.Tour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTourByID", ctx, tourID)
+	ret0, _ := ret[0].(*entity.Tour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTourByID indicates an expected call of GetTourByID.
+func (mr *MockTourismRepoMockRecorder) GetTourByID(ctx, tourID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTourByID", reflect.TypeOf((*MockTourismRepo)(nil).GetTourByID), ctx, tourID)
+}
+
+// GetTourLocationByID mocks base method.
+func (m *MockTourismRepo) GetTourLocationByID(ctx context.Context, tourID uuid.UUID) (*entity.TourLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTourLocationByID", ctx, tourID)
+	ret0, _ := ret[0].(*entity.TourLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTourLocationByID indicates an expected call of GetTourLocationByID.
+func (mr *MockTourismRepoMockRecorder) GetTourLocationByID(ctx, tourID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTourLocationByID", reflect.TypeOf((*MockTourismRepo)(nil).GetTourLocationByID), ctx, tourID)
+}
+
+// GetTours mocks base method.
+func (m *MockTourismRepo) GetTours(ctx context.Context) ([]entity.Tour, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTours", ctx)
+	ret0, _ := ret[0].([]entity.Tour)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTours indicates an expected call of GetTours.
+func (mr *MockTourismRepoMockRecorder) GetTours(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTours", reflect.TypeOf((*MockTourismRepo)(nil).GetTours), ctx)
+}
+
+// LockCategories mocks base method.
+func (m *MockTourismRepo) LockCategories(ctx context.Context) ([]entity.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockCategories", ctx)
+	ret0, _ := ret[0].([]entity.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockCategories indicates an expected call of LockCategories.
+func (mr *MockTourismRepoMockRecorder) LockCategories(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockCategories", reflect.TypeOf((*MockTourismRepo)(nil).LockCategories), ctx)
+}
+
+// PayTourEvent mocks base method.
+func (m *MockTourismRepo) PayTourEvent(ctx context.Context, purchase *entity.Purchase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayTourEvent", ctx, purchase)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PayTourEvent indicates an expected call of PayTourEvent.
+func (mr *MockTourismRepoMockRecorder) PayTourEvent(ctx, purchase interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayTourEvent", reflect.TypeOf((*MockTourismRepo)(nil).PayTourEvent), ctx, purchase)
+}
+
+// SearchToursByLocation mocks base method.
+func (m *MockTourismRepo) SearchToursByLocation(ctx context.Context, filter *entity.TourGeoSearchFilter) ([]*entity.TourGeoResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchToursByLocation", ctx, filter)
+	ret0, _ := ret[0].([]*entity.TourGeoResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchToursByLocation indicates an expected call of SearchToursByLocation.
+func (mr *MockTourismRepoMockRecorder) SearchToursByLocation(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchToursByLocation", reflect.TypeOf((*MockTourismRepo)(nil).SearchToursByLocation), ctx, filter)
+}
+
+// TourEventExists mocks base method.
+func (m *MockTourismRepo) TourEventExists(ctx context.Context, tourEventID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TourEventExists", ctx, tourEventID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TourEventExists indicates an expected call of TourEventExists.
+func (mr *MockTourismRepoMockRecorder) TourEventExists(ctx, tourEventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TourEventExists", reflect.TypeOf((*MockTourismRepo)(nil).TourEventExists), ctx, tourEventID)
+}
+
+// TourExists mocks base method.
+func (m *MockTourismRepo) TourExists(ctx context.Context, tourID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TourExists", ctx, tourID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TourExists indicates an expected call of TourExists.
+func (mr *MockTourismRepoMockRecorder) TourExists(ctx, tourID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TourExists", reflect.TypeOf((*MockTourismRepo)(nil).TourExists), ctx, tourID)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockTourismRepo) UpdateCategory(ctx context.Context, category *entity.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", ctx, category)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockTourismRepoMockRecorder) UpdateCategory(ctx, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockTourismRepo)(nil).UpdateCategory), ctx, category)
+}
+
+// MockUserRepo is a mock of UserRepo interface.
+type MockUserRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserRepoMockRecorder
+}
+
+// MockUserRepoMockRecorder is the mock recorder for MockUserRepo.
+type MockUserRepoMockRecorder struct {
+	mock *MockUserRepo
+}
+
+// NewMockUserRepo creates a new mock instance.
+func NewMockUserRepo(ctrl *gomock.Controller) *MockUserRepo {
+	mock := &MockUserRepo{ctrl: ctrl}
+	mock.recorder = &MockUserRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserRepo) EXPECT() *MockUserRepoMockRecorder {
+	return m.recorder
+}
+
+// LoginUser mocks base method.
+func (m *MockUserRepo) LoginUser(ctx context.Context, user *entity.LoginUserDTO) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginUser", ctx, user)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginUser indicates an expected call of LoginUser.
+func (mr *MockUserRepoMockRecorder) LoginUser(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockUserRepo)(nil).LoginUser), ctx, user)
+}
+
+// RegisterUser mocks base method.
+func (m *MockUserRepo) RegisterUser(ctx context.Context, user *entity.User) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterUser", ctx, user)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterUser indicates an expected call of RegisterUser.
+func (mr *MockUserRepoMockRecorder) RegisterUser(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockUserRepo)(nil).RegisterUser), ctx, user)
+}
+
+// MockAdminRepo is a mock of AdminRepo interface.
+type MockAdminRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminRepoMockRecorder
+}
+
+// MockAdminRepoMockRecorder is the mock recorder for MockAdminRepo.
+type MockAdminRepoMockRecorder struct {
+	mock *MockAdminRepo
+}
+
+// NewMockAdminRepo creates a new mock instance.
+func NewMockAdminRepo(ctrl *gomock.Controller) *MockAdminRepo {
+	mock := &MockAdminRepo{ctrl: ctrl}
+	mock.recorder = &MockAdminRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminRepo) EXPECT() *MockAdminRepoMockRecorder {
+	return m.recorder
+}
+
+// GetUsers mocks base method.
+func (m *MockAdminRepo) GetUsers(ctx context.Context) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockAdminRepoMockRecorder) GetUsers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockAdminRepo)(nil).GetUsers), ctx)
+}
+
+// MockReviewRepo is a mock of ReviewRepo interface.
+type MockReviewRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewRepoMockRecorder
+}
+
+// MockReviewRepoMockRecorder is the mock recorder for MockReviewRepo.
+type MockReviewRepoMockRecorder struct {
+	mock *MockReviewRepo
+}
+
+// NewMockReviewRepo creates a new mock instance.
+func NewMockReviewRepo(ctrl *gomock.Controller) *MockReviewRepo {
+	mock := &MockReviewRepo{ctrl: ctrl}
+	mock.recorder = &MockReviewRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewRepo) EXPECT() *MockReviewRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateReview mocks base method.
+func (m *MockReviewRepo) CreateReview(ctx context.Context, review *entity.Review, photoFiles []*multipart.FileHeader) (*entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReview", ctx, review, photoFiles)
+	ret0, _ := ret[0].(*entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockReviewRepoMockRecorder) CreateReview(ctx, review, photoFiles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockReviewRepo)(nil).CreateReview), ctx, review, photoFiles)
+}
+
+// DeleteReview mocks base method.
+func (m *MockReviewRepo) DeleteReview(ctx context.Context, reviewID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReview", ctx, reviewID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReview indicates an expected call of DeleteReview.
+func (mr *MockReviewRepoMockRecorder) DeleteReview(ctx, reviewID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReview", reflect.TypeOf((*MockReviewRepo)(nil).DeleteReview), ctx, reviewID)
+}
+
+// GetReviewByID mocks base method.
+func (m *MockReviewRepo) GetReviewByID(ctx context.Context, reviewID uuid.UUID) (*entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewByID", ctx, reviewID)
+	ret0, _ := ret[0].(*entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewByID indicates an expected call of GetReviewByID.
+func (mr *MockReviewRepoMockRecorder) GetReviewByID(ctx, reviewID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewByID", reflect.TypeOf((*MockReviewRepo)(nil).GetReviewByID), ctx, reviewID)
+}
+
+// GetTourReviews mocks base method.
+func (m *MockReviewRepo) GetTourReviews(ctx context.Context, tourID uuid.UUID, filter *entity.ReviewFilter) ([]*entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTourReviews", ctx, tourID, filter)
+	ret0, _ := ret[0].([]*entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTourReviews indicates an expected call of GetTourReviews.
+func (mr *MockReviewRepoMockRecorder) GetTourReviews(ctx, tourID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTourReviews", reflect.TypeOf((*MockReviewRepo)(nil).GetTourReviews), ctx, tourID, filter)
+}
+
+// HasCompletedPurchase mocks base method.
+func (m *MockReviewRepo) HasCompletedPurchase(ctx context.Context, tourID, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasCompletedPurchase", ctx, tourID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasCompletedPurchase indicates an expected call of HasCompletedPurchase.
+func (mr *MockReviewRepoMockRecorder) HasCompletedPurchase(ctx, tourID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasCompletedPurchase", reflect.TypeOf((*MockReviewRepo)(nil).HasCompletedPurchase), ctx, tourID, userID)
+}
+
+// ReplyToReview mocks base method.
+func (m *MockReviewRepo) ReplyToReview(ctx context.Context, reviewID uuid.UUID, reply string) (*entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplyToReview", ctx, reviewID, reply)
+	ret0, _ := ret[0].(*entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplyToReview indicates an expected call of ReplyToReview.
+func (mr *MockReviewRepoMockRecorder) ReplyToReview(ctx, reviewID, reply interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplyToReview", reflect.TypeOf((*MockReviewRepo)(nil).ReplyToReview), ctx, reviewID, reply)
+}
+
+// SetReviewStatus mocks base method.
+func (m *MockReviewRepo) SetReviewStatus(ctx context.Context, reviewID uuid.UUID, status string) (*entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReviewStatus", ctx, reviewID, status)
+	ret0, _ := ret[0].(*entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetReviewStatus indicates an expected call of SetReviewStatus.
+func (mr *MockReviewRepoMockRecorder) SetReviewStatus(ctx, reviewID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReviewStatus", reflect.TypeOf((*MockReviewRepo)(nil).SetReviewStatus), ctx, reviewID, status)
+}
+
+// MockWishlistRepo is a mock of WishlistRepo interface.
+type MockWishlistRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockWishlistRepoMockRecorder
+}
+
+// MockWishlistRepoMockRecorder is the mock recorder for MockWishlistRepo.
+type MockWishlistRepoMockRecorder struct {
+	mock *MockWishlistRepo
+}
+
+// NewMockWishlistRepo creates a new mock instance.
+func NewMockWishlistRepo(ctrl *gomock.Controller) *MockWishlistRepo {
+	mock := &MockWishlistRepo{ctrl: ctrl}
+	mock.recorder = &MockWishlistRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWishlistRepo) EXPECT() *MockWishlistRepoMockRecorder {
+	return m.recorder
+}
+
+// AddFavorite mocks base method.
+func (m *MockWishlistRepo) AddFavorite(ctx context.Context, userID, tourID uuid.UUID) (*entity.Favorite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFavorite", ctx, userID, tourID)
+	ret0, _ := ret[0].(*entity.Favorite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFavorite indicates an expected call of AddFavorite.
+func (mr *MockWishlistRepoMockRecorder) AddFavorite(ctx, userID, tourID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFavorite", reflect.TypeOf((*MockWishlistRepo)(nil).AddFavorite), ctx, userID, tourID)
+}
+
+// CreateSavedSearch mocks base method.
+func (m *MockWishlistRepo) CreateSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch, current []*entity.TourEvent) (*entity.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSavedSearch", ctx, savedSearch, current)
+	ret0, _ := ret[0].(*entity.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSavedSearch indicates an expected call of CreateSavedSearch.
+func (mr *MockWishlistRepoMockRecorder) CreateSavedSearch(ctx, savedSearch, current interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavedSearch", reflect.TypeOf((*MockWishlistRepo)(nil).CreateSavedSearch), ctx, savedSearch, current)
+}
+
+// DeleteSavedSearch mocks base method.
+func (m *MockWishlistRepo) DeleteSavedSearch(ctx context.Context, userID, savedSearchID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSavedSearch", ctx, userID, savedSearchID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSavedSearch indicates an expected call of DeleteSavedSearch.
+func (mr *MockWishlistRepoMockRecorder) DeleteSavedSearch(ctx, userID, savedSearchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSavedSearch", reflect.TypeOf((*MockWishlistRepo)(nil).DeleteSavedSearch), ctx, userID, savedSearchID)
+}
+
+// GetAllSavedSearches mocks base method.
+func (m *MockWishlistRepo) GetAllSavedSearches(ctx context.Context) ([]*entity.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllSavedSearches", ctx)
+	ret0, _ := ret[0].([]*entity.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllSavedSearches indicates an expected call of GetAllSavedSearches.
+func (mr *MockWishlistRepoMockRecorder) GetAllSavedSearches(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSavedSearches", reflect.TypeOf((*MockWishlistRepo)(nil).GetAllSavedSearches), ctx)
+}
+
+// GetFavorites mocks base method.
+func (m *MockWishlistRepo) GetFavorites(ctx context.Context, userID uuid.UUID) ([]*entity.Favorite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFavorites", ctx, userID)
+	ret0, _ := ret[0].([]*entity.Favorite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFavorites indicates an expected call of GetFavorites.
+func (mr *MockWishlistRepoMockRecorder) GetFavorites(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFavorites", reflect.TypeOf((*MockWishlistRepo)(nil).GetFavorites), ctx, userID)
+}
+
+// GetMatches mocks base method.
+func (m *MockWishlistRepo) GetMatches(ctx context.Context, savedSearchID uuid.UUID) (map[uuid.UUID]float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMatches", ctx, savedSearchID)
+	ret0, _ := ret[0].(map[uuid.UUID]float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMatches indicates an expected call of GetMatches.
+func (mr *MockWishlistRepoMockRecorder) GetMatches(ctx, savedSearchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMatches", reflect.TypeOf((*MockWishlistRepo)(nil).GetMatches), ctx, savedSearchID)
+}
+
+// GetNotifications mocks base method.
+func (m *MockWishlistRepo) GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool) ([]*entity.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", ctx, userID, unreadOnly)
+	ret0, _ := ret[0].([]*entity.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockWishlistRepoMockRecorder) GetNotifications(ctx, userID, unreadOnly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockWishlistRepo)(nil).GetNotifications), ctx, userID, unreadOnly)
+}
+
+// GetSavedSearches mocks base method.
+func (m *MockWishlistRepo) GetSavedSearches(ctx context.Context, userID uuid.UUID) ([]*entity.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavedSearches", ctx, userID)
+	ret0, _ := ret[0].([]*entity.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSavedSearches indicates an expected call of GetSavedSearches.
+func (mr *MockWishlistRepoMockRecorder) GetSavedSearches(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedSearches", reflect.TypeOf((*MockWishlistRepo)(nil).GetSavedSearches), ctx, userID)
+}
+
+// MarkNotificationRead mocks base method.
+func (m *MockWishlistRepo) MarkNotificationRead(ctx context.Context, userID, notificationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationRead", ctx, userID, notificationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationRead indicates an expected call of MarkNotificationRead.
+func (mr *MockWishlistRepoMockRecorder) MarkNotificationRead(ctx, userID, notificationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockWishlistRepo)(nil).MarkNotificationRead), ctx, userID, notificationID)
+}
+
+// RecordRun mocks base method.
+func (m *MockWishlistRepo) RecordRun(ctx context.Context, savedSearch *entity.SavedSearch, seen []*entity.TourEvent, notifications []*entity.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordRun", ctx, savedSearch, seen, notifications)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordRun indicates an expected call of RecordRun.
+func (mr *MockWishlistRepoMockRecorder) RecordRun(ctx, savedSearch, seen, notifications interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordRun", reflect.TypeOf((*MockWishlistRepo)(nil).RecordRun), ctx, savedSearch, seen, notifications)
+}
+
+// RemoveFavorite mocks base method.
+func (m *MockWishlistRepo) RemoveFavorite(ctx context.Context, userID, tourID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFavorite", ctx, userID, tourID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFavorite indicates an expected call of RemoveFavorite.
+func (mr *MockWishlistRepoMockRecorder) RemoveFavorite(ctx, userID, tourID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFavorite", reflect.TypeOf((*MockWishlistRepo)(nil).RemoveFavorite), ctx, userID, tourID)
+}
+
+// MockItineraryRepo is a mock of ItineraryRepo interface.
+type MockItineraryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockItineraryRepoMockRecorder
+}
+
+// MockItineraryRepoMockRecorder is the mock recorder for MockItineraryRepo.
+type MockItineraryRepoMockRecorder struct {
+	mock *MockItineraryRepo
+}
+
+// NewMockItineraryRepo creates a new mock instance.
+func NewMockItineraryRepo(ctrl *gomock.Controller) *MockItineraryRepo {
+	mock := &MockItineraryRepo{ctrl: ctrl}
+	mock.recorder = &MockItineraryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItineraryRepo) EXPECT() *MockItineraryRepoMockRecorder {
+	return m.recorder
+}
+
+// GetItinerary mocks base method.
+func (m *MockItineraryRepo) GetItinerary(ctx context.Context, tourID uuid.UUID) ([]entity.RouteStop, *entity.RouteTrack, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItinerary", ctx, tourID)
+	ret0, _ := ret[0].([]entity.RouteStop)
+	ret1, _ := ret[1].(*entity.RouteTrack)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetItinerary indicates an expected call of GetItinerary.
+func (mr *MockItineraryRepoMockRecorder) GetItinerary(ctx, tourID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItinerary", reflect.TypeOf((*MockItineraryRepo)(nil).GetItinerary), ctx, tourID)
+}
+
+// ReplaceItinerary mocks base method.
+func (m *MockItineraryRepo) ReplaceItinerary(ctx context.Context, tourID uuid.UUID, stops []entity.RouteStop, track *entity.RouteTrack, distanceKm, elevationGainM float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceItinerary", ctx, tourID, stops, track, distanceKm, elevationGainM)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceItinerary indicates an expected call of ReplaceItinerary.
+func (mr *MockItineraryRepoMockRecorder) ReplaceItinerary(ctx, tourID, stops, track, distanceKm, elevationGainM interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceItinerary", reflect.TypeOf((*MockItineraryRepo)(nil).ReplaceItinerary), ctx, tourID, stops, track, distanceKm, elevationGainM)
+}
+
+// MockTourMediaRepo is a mock of TourMediaRepo interface.
+type MockTourMediaRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTourMediaRepoMockRecorder
+}
+
+// MockTourMediaRepoMockRecorder is the mock recorder for MockTourMediaRepo.
+type MockTourMediaRepoMockRecorder struct {
+	mock *MockTourMediaRepo
+}
+
+// NewMockTourMediaRepo creates a new mock instance.
+func NewMockTourMediaRepo(ctrl *gomock.Controller) *MockTourMediaRepo {
+	mock := &MockTourMediaRepo{ctrl: ctrl}
+	mock.recorder = &MockTourMediaRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTourMediaRepo) EXPECT() *MockTourMediaRepoMockRecorder {
+	return m.recorder
+}
+
+// AddTourImages mocks base method.
+func (m *MockTourMediaRepo) AddTourImages(ctx context.Context, tourID uuid.UUID, files []*multipart.FileHeader, meta []entity.MediaMeta) ([]entity.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTourImages", ctx, tourID, files, meta)
+	ret0, _ := ret[0].([]entity.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTourImages indicates an expected call of AddTourImages.
+func (mr *MockTourMediaRepoMockRecorder) AddTourImages(ctx, tourID, files, meta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTourImages", reflect.TypeOf((*MockTourMediaRepo)(nil).AddTourImages), ctx, tourID, files, meta)
+}
+
+// AddTourVideos mocks base method.
+func (m *MockTourMediaRepo) AddTourVideos(ctx context.Context, tourID uuid.UUID, files []*multipart.FileHeader, meta []entity.MediaMeta) ([]entity.Video, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTourVideos", ctx, tourID, files, meta)
+	ret0, _ := ret[0].([]entity.Video)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTourVideos indicates an expected call of AddTourVideos.
+func (mr *MockTourMediaRepoMockRecorder) AddTourVideos(ctx, tourID, files, meta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTourVideos", reflect.TypeOf((*MockTourMediaRepo)(nil).AddTourVideos), ctx, tourID, files, meta)
+}
+
+// CountTourMedia mocks base method.
+func (m *MockTourMediaRepo) CountTourMedia(ctx context.Context, tourID uuid.UUID, kind media.Kind) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTourMedia", ctx, tourID, kind)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTourMedia indicates an expected call of CountTourMedia.
+func (mr *MockTourMediaRepoMockRecorder) CountTourMedia(ctx, tourID, kind interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTourMedia", reflect.TypeOf((*MockTourMediaRepo)(nil).CountTourMedia), ctx, tourID, kind)
+}
+
+// DeleteTourImage mocks base method.
+func (m *MockTourMediaRepo) DeleteTourImage(ctx context.Context, tourID, imageID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTourImage", ctx, tourID, imageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTourImage indicates an expected call of DeleteTourImage.
+func (mr *MockTourMediaRepoMockRecorder) DeleteTourImage(ctx, tourID, imageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTourImage", reflect.TypeOf((*MockTourMediaRepo)(nil).DeleteTourImage), ctx, tourID, imageID)
+}
+
+// DeleteTourVideo mocks base method.
+func (m *MockTourMediaRepo) DeleteTourVideo(ctx context.Context, tourID, videoID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTourVideo", ctx, tourID, videoID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTourVideo indicates an expected call of DeleteTourVideo.
+func (mr *MockTourMediaRepoMockRecorder) DeleteTourVideo(ctx, tourID, videoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTourVideo", reflect.TypeOf((*MockTourMediaRepo)(nil).DeleteTourVideo), ctx, tourID, videoID)
+}
+
+// DeleteUnreferencedMedia mocks base method.
+func (m *MockTourMediaRepo) DeleteUnreferencedMedia(ctx context.Context, referenced map[string]struct{}, olderThan time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUnreferencedMedia", ctx, referenced, olderThan)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUnreferencedMedia indicates an expected call of DeleteUnreferencedMedia.
+func (mr *MockTourMediaRepoMockRecorder) DeleteUnreferencedMedia(ctx, referenced, olderThan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUnreferencedMedia", reflect.TypeOf((*MockTourMediaRepo)(nil).DeleteUnreferencedMedia), ctx, referenced, olderThan)
+}
+
+// ReferencedMediaKeys mocks base method.
+func (m *MockTourMediaRepo) ReferencedMediaKeys(ctx context.Context) (map[string]struct{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReferencedMediaKeys", ctx)
+	ret0, _ := ret[0].(map[string]struct{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReferencedMediaKeys indicates an expected call of ReferencedMediaKeys.
+func (mr *MockTourMediaRepoMockRecorder) ReferencedMediaKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReferencedMediaKeys", reflect.TypeOf((*MockTourMediaRepo)(nil).ReferencedMediaKeys), ctx)
+}
+
+// ReorderTourImages mocks base method.
+func (m *MockTourMediaRepo) ReorderTourImages(ctx context.Context, tourID uuid.UUID, ids []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderTourImages", ctx, tourID, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderTourImages indicates an expected call of ReorderTourImages.
+func (mr *MockTourMediaRepoMockRecorder) ReorderTourImages(ctx, tourID, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderTourImages", reflect.TypeOf((*MockTourMediaRepo)(nil).ReorderTourImages), ctx, tourID, ids)
+}
+
+// ReorderTourVideos mocks base method.
+func (m *MockTourMediaRepo) ReorderTourVideos(ctx context.Context, tourID uuid.UUID, ids []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderTourVideos", ctx, tourID, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderTourVideos indicates an expected call of ReorderTourVideos.
+func (mr *MockTourMediaRepoMockRecorder) ReorderTourVideos(ctx, tourID, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderTourVideos", reflect.TypeOf((*MockTourMediaRepo)(nil).ReorderTourVideos), ctx, tourID, ids)
+}
+
+// SetCoverImage mocks base method.
+func (m *MockTourMediaRepo) SetCoverImage(ctx context.Context, tourID, imageID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCoverImage", ctx, tourID, imageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCoverImage indicates an expected call of SetCoverImage.
+func (mr *MockTourMediaRepoMockRecorder) SetCoverImage(ctx, tourID, imageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCoverImage", reflect.TypeOf((*MockTourMediaRepo)(nil).SetCoverImage), ctx, tourID, imageID)
+}
 
-	entity "tourism-backend/internal/entity"
-	gomock "github.com/golang/mock/gomock"
-)
+// UpdateTourImage mocks base method.
+func (m *MockTourMediaRepo) UpdateTourImage(ctx context.Context, tourID, imageID uuid.UUID, dto *entity.UpdateMediaDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTourImage", ctx, tourID, imageID, dto)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTourImage indicates an expected call of UpdateTourImage.
+func (mr *MockTourMediaRepoMockRecorder) UpdateTourImage(ctx, tourID, imageID, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTourImage", reflect.TypeOf((*MockTourMediaRepo)(nil).UpdateTourImage), ctx, tourID, imageID, dto)
+}
+
+// UpdateTourVideo mocks base method.
+func (m *MockTourMediaRepo) UpdateTourVideo(ctx context.Context, tourID, videoID uuid.UUID, dto *entity.UpdateMediaDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTourVideo", ctx, tourID, videoID, dto)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTourVideo indicates an expected call of UpdateTourVideo.
+func (mr *MockTourMediaRepoMockRecorder) UpdateTourVideo(ctx, tourID, videoID, dto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTourVideo", reflect.TypeOf((*MockTourMediaRepo)(nil).UpdateTourVideo), ctx, tourID, videoID, dto)
+}
+
+// MockImageRepo is a mock of ImageRepo interface.
+type MockImageRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageRepoMockRecorder
+}
+
+// MockImageRepoMockRecorder is the mock recorder for MockImageRepo.
+type MockImageRepoMockRecorder struct {
+	mock *MockImageRepo
+}
+
+// NewMockImageRepo creates a new mock instance.
+func NewMockImageRepo(ctrl *gomock.Controller) *MockImageRepo {
+	mock := &MockImageRepo{ctrl: ctrl}
+	mock.recorder = &MockImageRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImageRepo) EXPECT() *MockImageRepoMockRecorder {
+	return m.recorder
+}
+
+// FailImage mocks base method.
+func (m *MockImageRepo) FailImage(ctx context.Context, imageID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailImage", ctx, imageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailImage indicates an expected call of FailImage.
+func (mr *MockImageRepoMockRecorder) FailImage(ctx, imageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailImage", reflect.TypeOf((*MockImageRepo)(nil).FailImage), ctx, imageID)
+}
+
+// GetImageByID mocks base method.
+func (m *MockImageRepo) GetImageByID(ctx context.Context, imageID uuid.UUID) (*entity.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImageByID", ctx, imageID)
+	ret0, _ := ret[0].(*entity.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImageByID indicates an expected call of GetImageByID.
+func (mr *MockImageRepoMockRecorder) GetImageByID(ctx, imageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImageByID", reflect.TypeOf((*MockImageRepo)(nil).GetImageByID), ctx, imageID)
+}
+
+// GetPendingImageIDs mocks base method.
+func (m *MockImageRepo) GetPendingImageIDs(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingImageIDs", ctx, limit)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingImageIDs indicates an expected call of GetPendingImageIDs.
+func (mr *MockImageRepoMockRecorder) GetPendingImageIDs(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingImageIDs", reflect.TypeOf((*MockImageRepo)(nil).GetPendingImageIDs), ctx, limit)
+}
+
+// OpenMedia mocks base method.
+func (m *MockImageRepo) OpenMedia(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenMedia", ctx, key)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenMedia indicates an expected call of OpenMedia.
+func (mr *MockImageRepoMockRecorder) OpenMedia(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenMedia", reflect.TypeOf((*MockImageRepo)(nil).OpenMedia), ctx, key)
+}
+
+// PutMedia mocks base method.
+func (m *MockImageRepo) PutMedia(ctx context.Context, key string, data []byte, contentType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutMedia", ctx, key, data, contentType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutMedia indicates an expected call of PutMedia.
+func (mr *MockImageRepoMockRecorder) PutMedia(ctx, key, data, contentType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutMedia", reflect.TypeOf((*MockImageRepo)(nil).PutMedia), ctx, key, data, contentType)
+}
+
+// SaveProcessedImage mocks base method.
+func (m *MockImageRepo) SaveProcessedImage(ctx context.Context, image *entity.Image, original []byte, originalContentType string, variants []entity.ImageVariant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveProcessedImage", ctx, image, original, originalContentType, variants)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveProcessedImage indicates an expected call of SaveProcessedImage.
+func (mr *MockImageRepoMockRecorder) SaveProcessedImage(ctx, image, original, originalContentType, variants interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveProcessedImage", reflect.TypeOf((*MockImageRepo)(nil).SaveProcessedImage), ctx, image, original, originalContentType, variants)
+}
+
+// MockUploadRepo is a mock of UploadRepo interface.
+type MockUploadRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadRepoMockRecorder
+}
+
+// MockUploadRepoMockRecorder is the mock recorder for MockUploadRepo.
+type MockUploadRepoMockRecorder struct {
+	mock *MockUploadRepo
+}
+
+// NewMockUploadRepo creates a new mock instance.
+func NewMockUploadRepo(ctrl *gomock.Controller) *MockUploadRepo {
+	mock := &MockUploadRepo{ctrl: ctrl}
+	mock.recorder = &MockUploadRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadRepo) EXPECT() *MockUploadRepoMockRecorder {
+	return m.recorder
+}
+
+// CompleteUpload mocks base method.
+func (m *MockUploadRepo) CompleteUpload(ctx context.Context, upload *entity.Upload, src io.Reader, contentType string) (*entity.Video, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteUpload", ctx, upload, src, contentType)
+	ret0, _ := ret[0].(*entity.Video)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteUpload indicates an expected call of CompleteUpload.
+func (mr *MockUploadRepoMockRecorder) CompleteUpload(ctx, upload, src, contentType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteUpload", reflect.TypeOf((*MockUploadRepo)(nil).CompleteUpload), ctx, upload, src, contentType)
+}
+
+// CreateUpload mocks base method.
+func (m *MockUploadRepo) CreateUpload(ctx context.Context, upload *entity.Upload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUpload", ctx, upload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUpload indicates an expected call of CreateUpload.
+func (mr *MockUploadRepoMockRecorder) CreateUpload(ctx, upload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUpload", reflect.TypeOf((*MockUploadRepo)(nil).CreateUpload), ctx, upload)
+}
+
+// DeleteUpload mocks base method.
+func (m *MockUploadRepo) DeleteUpload(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUpload", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUpload indicates an expected call of DeleteUpload.
+func (mr *MockUploadRepoMockRecorder) DeleteUpload(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUpload", reflect.TypeOf((*MockUploadRepo)(nil).DeleteUpload), ctx, id)
+}
+
+// GetExpiredUploadIDs mocks base method.
+func (m *MockUploadRepo) GetExpiredUploadIDs(ctx context.Context, t time.Time, limit int) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredUploadIDs", ctx, t, limit)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredUploadIDs indicates an expected call of GetExpiredUploadIDs.
+func (mr *MockUploadRepoMockRecorder) GetExpiredUploadIDs(ctx, t, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredUploadIDs", reflect.TypeOf((*MockUploadRepo)(nil).GetExpiredUploadIDs), ctx, t, limit)
+}
+
+// GetUpload mocks base method.
+func (m *MockUploadRepo) GetUpload(ctx context.Context, id uuid.UUID) (*entity.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpload", ctx, id)
+	ret0, _ := ret[0].(*entity.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpload indicates an expected call of GetUpload.
+func (mr *MockUploadRepoMockRecorder) GetUpload(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpload", reflect.TypeOf((*MockUploadRepo)(nil).GetUpload), ctx, id)
+}
+
+// LockUpload mocks base method.
+func (m *MockUploadRepo) LockUpload(ctx context.Context, id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUpload", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockUpload indicates an expected call of LockUpload.
+func (mr *MockUploadRepoMockRecorder) LockUpload(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUpload", reflect.TypeOf((*MockUploadRepo)(nil).LockUpload), ctx, id)
+}
+
+// SetUploadOffset mocks base method.
+func (m *MockUploadRepo) SetUploadOffset(ctx context.Context, id uuid.UUID, from, to int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUploadOffset", ctx, id, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUploadOffset indicates an expected call of SetUploadOffset.
+func (mr *MockUploadRepoMockRecorder) SetUploadOffset(ctx, id, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUploadOffset", reflect.TypeOf((*MockUploadRepo)(nil).SetUploadOffset), ctx, id, from, to)
+}
+
+// MockDocumentRepo is a mock of DocumentRepo interface.
+type MockDocumentRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockDocumentRepoMockRecorder
+}
+
+// MockDocumentRepoMockRecorder is the mock recorder for MockDocumentRepo.
+type MockDocumentRepoMockRecorder struct {
+	mock *MockDocumentRepo
+}
+
+// NewMockDocumentRepo creates a new mock instance.
+func NewMockDocumentRepo(ctrl *gomock.Controller) *MockDocumentRepo {
+	mock := &MockDocumentRepo{ctrl: ctrl}
+	mock.recorder = &MockDocumentRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDocumentRepo) EXPECT() *MockDocumentRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateDocument mocks base method.
+func (m *MockDocumentRepo) CreateDocument(ctx context.Context, document *entity.Document, file *multipart.FileHeader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDocument", ctx, document, file)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDocument indicates an expected call of CreateDocument.
+func (mr *MockDocumentRepoMockRecorder) CreateDocument(ctx, document, file interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDocument", reflect.TypeOf((*MockDocumentRepo)(nil).CreateDocument), ctx, document, file)
+}
+
+// DeleteDocument mocks base method.
+func (m *MockDocumentRepo) DeleteDocument(ctx context.Context, document *entity.Document) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDocument", ctx, document)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDocument indicates an expected call of DeleteDocument.
+func (mr *MockDocumentRepoMockRecorder) DeleteDocument(ctx, document interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDocument", reflect.TypeOf((*MockDocumentRepo)(nil).DeleteDocument), ctx, document)
+}
+
+// GetDocument mocks base method.
+func (m *MockDocumentRepo) GetDocument(ctx context.Context, id uuid.UUID) (*entity.Document, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDocument", ctx, id)
+	ret0, _ := ret[0].(*entity.Document)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDocument indicates an expected call of GetDocument.
+func (mr *MockDocumentRepoMockRecorder) GetDocument(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDocument", reflect.TypeOf((*MockDocumentRepo)(nil).GetDocument), ctx, id)
+}
+
+// GetDocuments mocks base method.
+func (m *MockDocumentRepo) GetDocuments(ctx context.Context, providerID *uuid.UUID, kind string) ([]*entity.Document, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDocuments", ctx, providerID, kind)
+	ret0, _ := ret[0].([]*entity.Document)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDocuments indicates an expected call of GetDocuments.
+func (mr *MockDocumentRepoMockRecorder) GetDocuments(ctx, providerID, kind interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDocuments", reflect.TypeOf((*MockDocumentRepo)(nil).GetDocuments), ctx, providerID, kind)
+}
 
-// MockTranslation is a mock of Translation interface.
-type MockTranslation struct {
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
 	ctrl     *gomock.Controller
-	recorder *MockTranslationMockRecorder
+	recorder *MockTransactorMockRecorder
 }
 
-// MockTranslationMockRecorder is the mock recorder for MockTranslation.
-type MockTranslationMockRecorder struct {
-	mock *MockTranslation
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
 }
 
-// NewMockTranslation creates a new mock instance.
-func NewMockTranslation(ctrl *gomock.Controller) *MockTranslation {
-	mock := &MockTranslation{ctrl: ctrl}
-	mock.recorder = &MockTranslationMockRecorder{mock}
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockTranslation) EXPECT() *MockTranslationMockRecorder {
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
 	return m.recorder
 }
 
-// History mocks base method.
-func (m *MockTranslation) History(arg0 context.Context) ([]entity.Translation, error) {
+// Transaction mocks base method.
+func (m *MockTransactor) Transaction(ctx context.Context, fn func(context.Context) error) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "History", arg0)
-	ret0, _ := ret[0].([]entity.Translation)
+	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MockTransactorMockRecorder) Transaction(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockTransactor)(nil).Transaction), ctx, fn)
+}
+
+// MockAuditRepo is a mock of AuditRepo interface.
+type MockAuditRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditRepoMockRecorder
+}
+
+// MockAuditRepoMockRecorder is the mock recorder for MockAuditRepo.
+type MockAuditRepoMockRecorder struct {
+	mock *MockAuditRepo
+}
+
+// NewMockAuditRepo creates a new mock instance.
+func NewMockAuditRepo(ctrl *gomock.Controller) *MockAuditRepo {
+	mock := &MockAuditRepo{ctrl: ctrl}
+	mock.recorder = &MockAuditRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRepo) EXPECT() *MockAuditRepoMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockAuditRepo) Append(ctx context.Context, event *entity.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockAuditRepoMockRecorder) Append(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockAuditRepo)(nil).Append), ctx, event)
+}
+
+// GetAuditEvents mocks base method.
+func (m *MockAuditRepo) GetAuditEvents(ctx context.Context, filter *entity.AuditEventFilter) ([]*entity.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditEvents", ctx, filter)
+	ret0, _ := ret[0].([]*entity.AuditEvent)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// History indicates an expected call of History.
-func (mr *MockTranslationMockRecorder) History(arg0 interface{}) *gomock.Call {
+// GetAuditEvents indicates an expected call of GetAuditEvents.
+func (mr *MockAuditRepoMockRecorder) GetAuditEvents(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockTranslation)(nil).History), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEvents", reflect.TypeOf((*MockAuditRepo)(nil).GetAuditEvents), ctx, filter)
 }
 
-// Translate mocks base method.
-func (m *MockTranslation) Translate(arg0 context.Context, arg1 entity.Translation) (entity.Translation, error) {
+// VerifyChain mocks base method.
+func (m *MockAuditRepo) VerifyChain(ctx context.Context) (*entity.AuditIntegrityReport, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Translate", arg0, arg1)
-	ret0, _ := ret[0].(entity.Translation)
+	ret := m.ctrl.Call(m, "VerifyChain", ctx)
+	ret0, _ := ret[0].(*entity.AuditIntegrityReport)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Translate indicates an expected call of Translate.
-func (mr *MockTranslationMockRecorder) Translate(arg0, arg1 interface{}) *gomock.Call {
+// VerifyChain indicates an expected call of VerifyChain.
+func (mr *MockAuditRepoMockRecorder) VerifyChain(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Translate", reflect.TypeOf((*MockTranslation)(nil).Translate), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyChain", reflect.TypeOf((*MockAuditRepo)(nil).VerifyChain), ctx)
 }
 
 // MockTranslationRepo is a mock of TranslationRepo interface.
@@ -88,69 +2842,103 @@ func (m *MockTranslationRepo) EXPECT() *MockTranslationRepoMockRecorder {
 	return m.recorder
 }
 
-// GetHistory mocks base method.
-func (m *MockTranslationRepo) GetHistory(arg0 context.Context) ([]entity.Translation, error) {
+// DeleteTourLocale mocks base method.
+func (m *MockTranslationRepo) DeleteTourLocale(ctx context.Context, tourID uuid.UUID, language string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTourLocale", ctx, tourID, language)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTourLocale indicates an expected call of DeleteTourLocale.
+func (mr *MockTranslationRepoMockRecorder) DeleteTourLocale(ctx, tourID, language interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTourLocale", reflect.TypeOf((*MockTranslationRepo)(nil).DeleteTourLocale), ctx, tourID, language)
+}
+
+// GetCategoryLocales mocks base method.
+func (m *MockTranslationRepo) GetCategoryLocales(ctx context.Context, categoryIDs []uuid.UUID, languages []string) ([]entity.CategoryLocale, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetHistory", arg0)
-	ret0, _ := ret[0].([]entity.Translation)
+	ret := m.ctrl.Call(m, "GetCategoryLocales", ctx, categoryIDs, languages)
+	ret0, _ := ret[0].([]entity.CategoryLocale)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetHistory indicates an expected call of GetHistory.
-func (mr *MockTranslationRepoMockRecorder) GetHistory(arg0 interface{}) *gomock.Call {
+// GetCategoryLocales indicates an expected call of GetCategoryLocales.
+func (mr *MockTranslationRepoMockRecorder) GetCategoryLocales(ctx, categoryIDs, languages interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockTranslationRepo)(nil).GetHistory), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryLocales", reflect.TypeOf((*MockTranslationRepo)(nil).GetCategoryLocales), ctx, categoryIDs, languages)
 }
 
-// Store mocks base method.
-func (m *MockTranslationRepo) Store(arg0 context.Context, arg1 entity.Translation) error {
+// GetTourLocales mocks base method.
+func (m *MockTranslationRepo) GetTourLocales(ctx context.Context, tourIDs []uuid.UUID, languages []string) ([]entity.TourLocale, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Store", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetTourLocales", ctx, tourIDs, languages)
+	ret0, _ := ret[0].([]entity.TourLocale)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Store indicates an expected call of Store.
-func (mr *MockTranslationRepoMockRecorder) Store(arg0, arg1 interface{}) *gomock.Call {
+// GetTourLocales indicates an expected call of GetTourLocales.
+func (mr *MockTranslationRepoMockRecorder) GetTourLocales(ctx, tourIDs, languages interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockTranslationRepo)(nil).Store), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTourLocales", reflect.TypeOf((*MockTranslationRepo)(nil).GetTourLocales), ctx, tourIDs, languages)
 }
 
-// MockTranslationWebAPI is a mock of TranslationWebAPI interface.
-type MockTranslationWebAPI struct {
-	ctrl     *gomock.Controller
-	recorder *MockTranslationWebAPIMockRecorder
+// GetTranslations mocks base method.
+func (m *MockTranslationRepo) GetTranslations(ctx context.Context, language string, resourceIDs []uuid.UUID) ([]entity.TourTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTranslations", ctx, language, resourceIDs)
+	ret0, _ := ret[0].([]entity.TourTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockTranslationWebAPIMockRecorder is the mock recorder for MockTranslationWebAPI.
-type MockTranslationWebAPIMockRecorder struct {
-	mock *MockTranslationWebAPI
+// GetTranslations indicates an expected call of GetTranslations.
+func (mr *MockTranslationRepoMockRecorder) GetTranslations(ctx, language, resourceIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTranslations", reflect.TypeOf((*MockTranslationRepo)(nil).GetTranslations), ctx, language, resourceIDs)
 }
 
-// NewMockTranslationWebAPI creates a new mock instance.
-func NewMockTranslationWebAPI(ctrl *gomock.Controller) *MockTranslationWebAPI {
-	mock := &MockTranslationWebAPI{ctrl: ctrl}
-	mock.recorder = &MockTranslationWebAPIMockRecorder{mock}
-	return mock
+// SaveCategoryLocale mocks base method.
+func (m *MockTranslationRepo) SaveCategoryLocale(ctx context.Context, locale *entity.CategoryLocale) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCategoryLocale", ctx, locale)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockTranslationWebAPI) EXPECT() *MockTranslationWebAPIMockRecorder {
-	return m.recorder
+// SaveCategoryLocale indicates an expected call of SaveCategoryLocale.
+func (mr *MockTranslationRepoMockRecorder) SaveCategoryLocale(ctx, locale interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCategoryLocale", reflect.TypeOf((*MockTranslationRepo)(nil).SaveCategoryLocale), ctx, locale)
 }
 
-// Translate mocks base method.
-func (m *MockTranslationWebAPI) Translate(arg0 entity.Translation) (entity.Translation, error) {
+// SaveTourLocale mocks base method.
+func (m *MockTranslationRepo) SaveTourLocale(ctx context.Context, locale *entity.TourLocale) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Translate", arg0)
-	ret0, _ := ret[0].(entity.Translation)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SaveTourLocale", ctx, locale)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTourLocale indicates an expected call of SaveTourLocale.
+func (mr *MockTranslationRepoMockRecorder) SaveTourLocale(ctx, locale interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTourLocale", reflect.TypeOf((*MockTranslationRepo)(nil).SaveTourLocale), ctx, locale)
+}
+
+// SaveTranslations mocks base method.
+func (m *MockTranslationRepo) SaveTranslations(ctx context.Context, translations []entity.TourTranslation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTranslations", ctx, translations)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// Translate indicates an expected call of Translate.
-func (mr *MockTranslationWebAPIMockRecorder) Translate(arg0 interface{}) *gomock.Call {
+// SaveTranslations indicates an expected call of SaveTranslations.
+func (mr *MockTranslationRepoMockRecorder) SaveTranslations(ctx, translations interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Translate", reflect.TypeOf((*MockTranslationWebAPI)(nil).Translate), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTranslations", reflect.TypeOf((*MockTranslationRepo)(nil).SaveTranslations), ctx, translations)
 }
@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"fmt"
 	"tourism-backend/internal/entity"
 	"tourism-backend/pkg/postgres"
@@ -15,10 +16,10 @@ func NewAdminRepo(pg *postgres.Postgres) *AdminRepo {
 	return &AdminRepo{pg}
 }
 
-func (r *AdminRepo) GetUsers() ([]*entity.User, error) {
+func (r *AdminRepo) GetUsers(ctx context.Context) ([]*entity.User, error) {
 	users := make([]*entity.User, 0)
 
-	err := r.PG.Conn.Find(&users).Error
+	err := r.PG.DB(ctx).Find(&users).Error
 	if err != nil {
 		return nil, fmt.Errorf("get users: %w", err)
 	}
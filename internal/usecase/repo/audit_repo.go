@@ -0,0 +1,141 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/postgres"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// _auditChainLockKey serializes appends so that every event links to its predecessor.
+	_auditChainLockKey = 0x61756469 // "audi"
+	_auditDefaultLimit = 50
+	_auditMaxLimit     = 500
+	_auditVerifyBatch  = 500
+)
+
+type AuditRepo struct {
+	PG *postgres.Postgres
+}
+
+// New -.
+func NewAuditRepo(pg *postgres.Postgres) *AuditRepo {
+	return &AuditRepo{pg}
+}
+
+// Append links the event to the tail of the chain and stores it, in the transaction of ctx when
+// there is one. The chain stays locked until that transaction ends, append last.
+func (r *AuditRepo) Append(ctx context.Context, event *entity.AuditEvent) error {
+	return r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", _auditChainLockKey).Error; err != nil {
+			return fmt.Errorf("lock audit chain: %w", err)
+		}
+
+		var last entity.AuditEvent
+		err := tx.Order("seq DESC").Limit(1).Take(&last).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			event.Seq = 1
+			event.PrevHash = ""
+		case err != nil:
+			return fmt.Errorf("get last audit event: %w", err)
+		default:
+			event.Seq = last.Seq + 1
+			event.PrevHash = last.Hash
+		}
+
+		// Postgres keeps microseconds, truncate so the stored value hashes the same.
+		event.ID = uuid.New()
+		event.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+		event.Hash = event.ComputeHash()
+
+		if err := tx.Create(event).Error; err != nil {
+			return fmt.Errorf("create audit event: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *AuditRepo) GetAuditEvents(ctx context.Context, filter *entity.AuditEventFilter) ([]*entity.AuditEvent, error) {
+	events := make([]*entity.AuditEvent, 0, _defaultEntityCap)
+
+	query := r.PG.Conn.WithContext(ctx).Model(&entity.AuditEvent{})
+	if filter.ActorID != uuid.Nil {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.Resource != "" {
+		query = query.Where("resource = ?", filter.Resource)
+	}
+	if filter.ResourceID != "" {
+		query = query.Where("resource_id = ?", filter.ResourceID)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at <= ?", filter.To)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = _auditDefaultLimit
+	}
+	if limit > _auditMaxLimit {
+		limit = _auditMaxLimit
+	}
+
+	err := query.Order("seq DESC").Limit(limit).Offset(filter.Offset).Find(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("get audit events: %w", err)
+	}
+	return events, nil
+}
+
+// VerifyChain walks the whole chain in order and recomputes every hash.
+func (r *AuditRepo) VerifyChain(ctx context.Context) (*entity.AuditIntegrityReport, error) {
+	report := &entity.AuditIntegrityReport{Valid: true}
+	prevHash := ""
+	var lastSeq int64
+
+	for {
+		batch := make([]*entity.AuditEvent, 0, _auditVerifyBatch)
+		err := r.PG.Conn.WithContext(ctx).Where("seq > ?", lastSeq).Order("seq ASC").Limit(_auditVerifyBatch).Find(&batch).Error
+		if err != nil {
+			return nil, fmt.Errorf("verify audit chain: %w", err)
+		}
+		if len(batch) == 0 {
+			return report, nil
+		}
+
+		for _, event := range batch {
+			report.Checked++
+			switch {
+			case event.Seq != lastSeq+1:
+				report.Reason = fmt.Sprintf("expected seq %d, found %d", lastSeq+1, event.Seq)
+			case event.PrevHash != prevHash:
+				report.Reason = "previous hash does not match"
+			case event.ComputeHash() != event.Hash:
+				report.Reason = "event hash does not match its contents"
+			}
+			if report.Reason != "" {
+				report.Valid = false
+				report.BrokenAtSeq = lastSeq + 1
+				return report, nil
+			}
+			prevHash = event.Hash
+			lastSeq = event.Seq
+		}
+	}
+}
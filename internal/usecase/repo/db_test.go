@@ -0,0 +1,63 @@
+package repo
+
+import (
+	"database/sql/driver"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+	"tourism-backend/pkg/postgres"
+
+	sqlite "github.com/glebarez/go-sqlite"
+	gormsqlite "github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var registerFunctions sync.Once
+
+// functionDefault matches column defaults that call a function, SQLite needs them in parentheses.
+var functionDefault = regexp.MustCompile(`DEFAULT (\w+\(\))`)
+
+// newTestDB opens an in-memory SQLite database with the tables of models. The Postgres functions the
+// repositories call are stubbed: advisory locks are not needed as the database has a single connection.
+func newTestDB(t *testing.T, models ...interface{}) *postgres.Postgres {
+	t.Helper()
+
+	registerFunctions.Do(func() {
+		sqlite.MustRegisterScalarFunction("uuid_generate_v4", 0, func(*sqlite.FunctionContext, []driver.Value) (driver.Value, error) {
+			return uuid.NewString(), nil
+		})
+		sqlite.MustRegisterScalarFunction("now", 0, func(*sqlite.FunctionContext, []driver.Value) (driver.Value, error) {
+			return time.Now().UTC().Format(time.RFC3339Nano), nil
+		})
+		sqlite.MustRegisterScalarFunction("hashtext", 1, func(*sqlite.FunctionContext, []driver.Value) (driver.Value, error) {
+			return int64(0), nil
+		})
+		sqlite.MustRegisterScalarFunction("pg_advisory_xact_lock", 1, func(*sqlite.FunctionContext, []driver.Value) (driver.Value, error) {
+			return nil, nil
+		})
+	})
+
+	db, err := gorm.Open(gormsqlite.Open(":memory:"), &gorm.Config{
+		TranslateError: true,
+		Logger:         logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	pool, err := db.DB()
+	require.NoError(t, err)
+	pool.SetMaxOpenConns(1)
+	t.Cleanup(func() { pool.Close() })
+
+	err = db.Callback().Raw().Before("gorm:raw").Register("test:function_defaults", func(db *gorm.DB) {
+		sql := functionDefault.ReplaceAllString(db.Statement.SQL.String(), "DEFAULT ($1)")
+		db.Statement.SQL.Reset()
+		db.Statement.SQL.WriteString(sql)
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(models...))
+
+	return &postgres.Postgres{Conn: db}
+}
@@ -0,0 +1,86 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"mime/multipart"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/media"
+	"tourism-backend/pkg/postgres"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// _mediaDocuments keeps documents under the private prefix, so they are never served without a signature.
+const _mediaDocuments = media.PrivatePrefix + "documents"
+
+type DocumentRepo struct {
+	PG    *postgres.Postgres
+	Media media.Store
+}
+
+// New -.
+func NewDocumentRepo(pg *postgres.Postgres, store media.Store) *DocumentRepo {
+	return &DocumentRepo{pg, store}
+}
+
+// CreateDocument stores the file and its document row.
+func (r *DocumentRepo) CreateDocument(ctx context.Context, document *entity.Document, file *multipart.FileHeader) error {
+	return r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		key, err := putMedia(tx, r.Media, _mediaDocuments, file)
+		if err != nil {
+			return err
+		}
+		document.Key = key
+		if err := tx.Create(document).Error; err != nil {
+			return fmt.Errorf("create document: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *DocumentRepo) GetDocument(ctx context.Context, id uuid.UUID) (*entity.Document, error) {
+	var document entity.Document
+	if err := r.PG.DB(ctx).Where("id = ?", id).First(&document).Error; err != nil {
+		return nil, fmt.Errorf("get document: %w", err)
+	}
+	return &document, nil
+}
+
+// GetDocuments returns the documents matching the non-empty filter fields, newest first.
+func (r *DocumentRepo) GetDocuments(ctx context.Context, providerID *uuid.UUID, kind string) ([]*entity.Document, error) {
+	query := r.PG.DB(ctx).Model(&entity.Document{})
+	if providerID != nil {
+		query = query.Where("provider_id = ?", *providerID)
+	}
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+
+	var documents []*entity.Document
+	if err := query.Order("created_at DESC").Find(&documents).Error; err != nil {
+		return nil, fmt.Errorf("get documents: %w", err)
+	}
+	return documents, nil
+}
+
+// DeleteDocument removes the document row and its file.
+func (r *DocumentRepo) DeleteDocument(ctx context.Context, document *entity.Document) error {
+	var last bool
+	err := r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Delete(document).Error; err != nil {
+			return fmt.Errorf("delete document: %w", err)
+		}
+		var err error
+		last, err = releaseBlob(tx, document.Key)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if last {
+		deleteAfterCommit(ctx, r.PG, r.Media, []string{document.Key}, nil)
+	}
+	return nil
+}
@@ -0,0 +1,4 @@
+package repo
+
+// NewTestDB lets the tests of the use cases built on these repositories use the in-memory database.
+var NewTestDB = newTestDB
@@ -0,0 +1,173 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/media"
+	"tourism-backend/pkg/postgres"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ImageRepo struct {
+	PG    *postgres.Postgres
+	Media media.Store
+}
+
+// New -.
+func NewImageRepo(pg *postgres.Postgres, store media.Store) *ImageRepo {
+	return &ImageRepo{pg, store}
+}
+
+// GetImageByID reads from the primary, the worker processes images right after they are uploaded.
+func (r *ImageRepo) GetImageByID(ctx context.Context, imageID uuid.UUID) (*entity.Image, error) {
+	var image entity.Image
+	err := postgres.Primary(r.PG.Conn.WithContext(ctx)).Preload("Variants").First(&image, "id = ?", imageID).Error
+	if err != nil {
+		return nil, fmt.Errorf("get image by id: %w", err)
+	}
+	return &image, nil
+}
+
+// GetPendingImageIDs returns the oldest images still waiting for processing.
+func (r *ImageRepo) GetPendingImageIDs(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := postgres.Primary(r.PG.Conn.WithContext(ctx)).Model(&entity.Image{}).
+		Where("status = ?", entity.ImageStatusPending).
+		Order("created_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("get pending images: %w", err)
+	}
+	return ids, nil
+}
+
+// OpenMedia opens a stored object for reading.
+func (r *ImageRepo) OpenMedia(ctx context.Context, key string) (io.ReadCloser, error) {
+	rc, err := r.Media.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("open media %s: %w", key, err)
+	}
+	return rc, nil
+}
+
+// PutMedia stores data under key, replacing any existing object.
+func (r *ImageRepo) PutMedia(ctx context.Context, key string, data []byte, contentType string) error {
+	err := r.Media.Put(ctx, key, bytes.NewReader(data), int64(len(data)), contentType)
+	if err != nil {
+		return fmt.Errorf("put media %s: %w", key, err)
+	}
+	return nil
+}
+
+// SaveProcessedImage replaces the original and the variants of the image and marks it ready.
+// The original is a shared blob, so the metadata-free copy is stored as a blob of its own
+// instead of overwriting the upload other images may still reference.
+// Objects left over from an earlier run or the replaced upload are deleted once the transaction commits.
+func (r *ImageRepo) SaveProcessedImage(ctx context.Context, image *entity.Image, original []byte, originalContentType string, variants []entity.ImageVariant) error {
+	var stale, released []string
+	err := r.PG.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if original != nil {
+			key, err := storeBlob(tx, r.Media, _mediaImages, bytes.NewReader(original), int64(len(original)), originalContentType)
+			if err != nil {
+				return err
+			}
+			last, err := releaseBlob(tx, image.ImageURL)
+			if err != nil {
+				return err
+			}
+			if last && key != image.ImageURL {
+				released = append(released, image.ImageURL)
+			}
+			if err := tx.Model(image).Update("image_url", key).Error; err != nil {
+				return fmt.Errorf("update image original: %w", err)
+			}
+		}
+
+		var previous []entity.ImageVariant
+		if err := tx.Where("image_id = ?", image.ID).Find(&previous).Error; err != nil {
+			return fmt.Errorf("get image variants: %w", err)
+		}
+		if err := tx.Unscoped().Where("image_id = ?", image.ID).Delete(&entity.ImageVariant{}).Error; err != nil {
+			return fmt.Errorf("delete image variants: %w", err)
+		}
+		if len(variants) > 0 {
+			if err := tx.Create(&variants).Error; err != nil {
+				return fmt.Errorf("create image variants: %w", err)
+			}
+		}
+
+		err := tx.Model(image).Updates(map[string]interface{}{
+			"status":   entity.ImageStatusReady,
+			"width":    image.Width,
+			"height":   image.Height,
+			"blurhash": image.Blurhash,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("update image: %w", err)
+		}
+
+		current := make(map[string]bool, len(variants))
+		for _, variant := range variants {
+			current[variant.Key] = true
+		}
+		for _, variant := range previous {
+			if !current[variant.Key] {
+				stale = append(stale, variant.Key)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	deleteAfterCommit(ctx, r.PG, r.Media, released, stale)
+	return nil
+}
+
+// FailImage marks a pending image failed and drops its original, which still carries the EXIF
+// and GPS data of the upload. The image is kept without a download URL until it is deleted.
+func (r *ImageRepo) FailImage(ctx context.Context, imageID uuid.UUID) error {
+	var released []string
+	err := r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		var image entity.Image
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&image, "id = ? AND status = ?", imageID, entity.ImageStatusPending).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Processed, failed or deleted in the meantime.
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("get image: %w", err)
+		}
+		key := image.ImageURL
+		err = tx.Model(&image).Updates(map[string]interface{}{
+			"status":    entity.ImageStatusFailed,
+			"image_url": "",
+		}).Error
+		if err != nil {
+			return fmt.Errorf("fail image: %w", err)
+		}
+
+		last, err := releaseBlob(tx, key)
+		if err != nil {
+			return err
+		}
+		if last {
+			released = append(released, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	deleteAfterCommit(ctx, r.PG, r.Media, released, nil)
+	return nil
+}
@@ -0,0 +1,47 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/media"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailImage(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r, store := newTestTourMediaRepo(t)
+	images := NewImageRepo(r.PG, r.Media)
+	tourID := uuid.New()
+	shared := addImages(t, r, tourID, "same", "same")
+	key := shared[0].ImageURL
+
+	// A pending image exposes no URL, its original still has the metadata of the upload.
+	image, err := images.GetImageByID(ctx, shared[0].ID)
+	require.NoError(t, err)
+	resolveImageMedia(store, image)
+	require.Empty(t, image.URL)
+
+	// The original is dropped once no other image references it.
+	require.NoError(t, images.FailImage(ctx, shared[0].ID))
+	image, err = images.GetImageByID(ctx, shared[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, entity.ImageStatusFailed, image.Status)
+	require.Empty(t, image.ImageURL)
+	_, err = store.Get(ctx, key)
+	require.NoError(t, err)
+
+	require.NoError(t, images.FailImage(ctx, shared[1].ID))
+	_, err = store.Get(ctx, key)
+	require.ErrorIs(t, err, media.ErrNotFound)
+
+	// Failing again changes nothing, and a failed image can still be deleted.
+	require.NoError(t, images.FailImage(ctx, shared[0].ID))
+	require.NoError(t, r.DeleteTourImage(ctx, tourID, shared[0].ID))
+	ids, _ := tourImages(t, r, tourID)
+	require.Equal(t, []uuid.UUID{shared[1].ID}, ids)
+}
@@ -0,0 +1,121 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase"
+
+	"github.com/google/uuid"
+)
+
+const (
+	_auditDefaultLimit = 50
+	_auditMaxLimit     = 500
+)
+
+var _ usecase.AuditRepo = (*AuditRepo)(nil)
+
+// AuditRepo keeps the audit events chained by hash like the Postgres repository.
+type AuditRepo struct {
+	mu     sync.RWMutex
+	events []entity.AuditEvent
+}
+
+// NewAuditRepo -.
+func NewAuditRepo() *AuditRepo {
+	return &AuditRepo{}
+}
+
+// Append links the event to the tail of the chain and stores it.
+func (r *AuditRepo) Append(_ context.Context, event *entity.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event.Seq = 1
+	event.PrevHash = ""
+	if n := len(r.events); n > 0 {
+		event.Seq = r.events[n-1].Seq + 1
+		event.PrevHash = r.events[n-1].Hash
+	}
+	event.ID = uuid.New()
+	event.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	event.Hash = event.ComputeHash()
+	r.events = append(r.events, *event)
+	return nil
+}
+
+// GetAuditEvents returns the events matching filter, the newest first.
+func (r *AuditRepo) GetAuditEvents(_ context.Context, filter *entity.AuditEventFilter) ([]*entity.AuditEvent, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = _auditDefaultLimit
+	}
+	if limit > _auditMaxLimit {
+		limit = _auditMaxLimit
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := make([]*entity.AuditEvent, 0)
+	skipped := 0
+	for i := len(r.events) - 1; i >= 0 && len(events) < limit; i-- {
+		event := r.events[i]
+		switch {
+		case filter.ActorID != uuid.Nil && event.ActorID != filter.ActorID,
+			filter.Action != "" && event.Action != filter.Action,
+			filter.Resource != "" && event.Resource != filter.Resource,
+			filter.ResourceID != "" && event.ResourceID != filter.ResourceID,
+			filter.RequestID != "" && event.RequestID != filter.RequestID,
+			!filter.From.IsZero() && event.CreatedAt.Before(filter.From),
+			!filter.To.IsZero() && event.CreatedAt.After(filter.To):
+			continue
+		}
+		if skipped < filter.Offset {
+			skipped++
+			continue
+		}
+		events = append(events, &event)
+	}
+	return events, nil
+}
+
+// VerifyChain walks the whole chain in order and recomputes every hash.
+func (r *AuditRepo) VerifyChain(_ context.Context) (*entity.AuditIntegrityReport, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	report := &entity.AuditIntegrityReport{Valid: true}
+	prevHash := ""
+	var lastSeq int64
+	for _, event := range r.events {
+		report.Checked++
+		switch {
+		case event.Seq != lastSeq+1:
+			report.Reason = fmt.Sprintf("expected seq %d, found %d", lastSeq+1, event.Seq)
+		case event.PrevHash != prevHash:
+			report.Reason = "previous hash does not match"
+		case event.ComputeHash() != event.Hash:
+			report.Reason = "event hash does not match its contents"
+		}
+		if report.Reason != "" {
+			report.Valid = false
+			report.BrokenAtSeq = lastSeq + 1
+			return report, nil
+		}
+		prevHash = event.Hash
+		lastSeq = event.Seq
+	}
+	return report, nil
+}
+
+// Events returns a copy of the stored events in order, so tests can check what was audited.
+func (r *AuditRepo) Events() []entity.AuditEvent {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]entity.AuditEvent(nil), r.events...)
+}
@@ -0,0 +1,556 @@
+// Package inmemory implements the use case repositories in memory, for tests that do not need Postgres.
+// Lookups of missing rows fail with gorm.ErrRecordNotFound like the Postgres repositories.
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase"
+	"tourism-backend/pkg/geo"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	_geoDefaultLimit = 100
+	_geoMaxLimit     = 500
+)
+
+// ErrMediaNotSupported is returned when a tour is created with media files.
+var ErrMediaNotSupported = errors.New("in-memory repository does not store media files")
+
+var _ usecase.TourismRepo = (*TourismRepo)(nil)
+
+type tourCategoryKey struct {
+	tourID     uuid.UUID
+	categoryID uuid.UUID
+}
+
+// TourismRepo -.
+// The text query of the filters matches the original tour texts only, not their locales.
+type TourismRepo struct {
+	mu             sync.RWMutex
+	tours          map[uuid.UUID]entity.Tour
+	tourEvents     map[uuid.UUID]entity.TourEvent
+	purchases      map[uuid.UUID]entity.Purchase
+	categories     map[uuid.UUID]entity.Category
+	tourCategories map[tourCategoryKey]struct{}
+	tourLocations  map[uuid.UUID]entity.TourLocation
+}
+
+// NewTourismRepo -.
+func NewTourismRepo() *TourismRepo {
+	return &TourismRepo{
+		tours:          make(map[uuid.UUID]entity.Tour),
+		tourEvents:     make(map[uuid.UUID]entity.TourEvent),
+		purchases:      make(map[uuid.UUID]entity.Purchase),
+		categories:     make(map[uuid.UUID]entity.Category),
+		tourCategories: make(map[tourCategoryKey]struct{}),
+		tourLocations:  make(map[uuid.UUID]entity.TourLocation),
+	}
+}
+
+func (r *TourismRepo) GetFilteredTourEvents(_ context.Context, filter *entity.TourEventFilter) ([]*entity.TourEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	categories := r.descendants(filter.CategoryIDs)
+	tourEvents := make([]*entity.TourEvent, 0)
+	for _, tourEvent := range r.tourEvents {
+		tour, ok := r.tours[tourEvent.TourID]
+		if !ok || !tourEvent.IsOpened || !matchesEvent(&tourEvent, filter) || !matchesText(&tour, filter) {
+			continue
+		}
+		// Like the join on tour_categories, tours without a matching category are skipped.
+		if !r.inCategories(tour.ID, categories, len(filter.CategoryIDs) > 0) {
+			continue
+		}
+		tourEvent.Tour = tour
+		tourEvents = append(tourEvents, &tourEvent)
+	}
+	sort.Slice(tourEvents, func(i, j int) bool { return tourEvents[i].Date.Before(tourEvents[j].Date) })
+	return tourEvents, nil
+}
+
+func (r *TourismRepo) SearchToursByLocation(_ context.Context, filter *entity.TourGeoSearchFilter) ([]*entity.TourGeoResult, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = _geoDefaultLimit
+	}
+	if limit > _geoMaxLimit {
+		limit = _geoMaxLimit
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	hasCenter := filter.Latitude != nil && filter.Longitude != nil
+	categories := r.descendants(filter.Events.CategoryIDs)
+	results := make([]*entity.TourGeoResult, 0)
+	for _, location := range r.tourLocations {
+		tour, ok := r.tours[location.TourID]
+		if !ok || !matchesText(&tour, &filter.Events) {
+			continue
+		}
+		point := geo.Point{Latitude: location.Latitude, Longitude: location.Longitude}
+		result := &entity.TourGeoResult{Tour: &tour, Latitude: location.Latitude, Longitude: location.Longitude}
+		if hasCenter {
+			distance := geo.Haversine(geo.Point{Latitude: *filter.Latitude, Longitude: *filter.Longitude}, point)
+			if filter.RadiusKm > 0 && distance > filter.RadiusKm {
+				continue
+			}
+			result.DistanceKm = &distance
+		}
+		if filter.HasBounds {
+			box := geo.BoundingBox{MinLatitude: filter.MinLat, MinLongitude: filter.MinLon, MaxLatitude: filter.MaxLat, MaxLongitude: filter.MaxLon}
+			if !box.Contains(point) {
+				continue
+			}
+		}
+		if !filter.Events.IsEmpty() && !r.hasOpenEvent(tour.ID, &filter.Events, categories) {
+			continue
+		}
+		results = append(results, result)
+	}
+
+	if hasCenter {
+		sort.Slice(results, func(i, j int) bool { return *results[i].DistanceKm < *results[j].DistanceKm })
+	} else {
+		sort.Slice(results, func(i, j int) bool { return results[i].Tour.CreatedAt.After(results[j].Tour.CreatedAt) })
+	}
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+func (r *TourismRepo) GetTourLocationByID(_ context.Context, tourID uuid.UUID) (*entity.TourLocation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	location, ok := r.tourLocations[tourID]
+	if !ok {
+		return nil, fmt.Errorf("get tour location by id: %w", gorm.ErrRecordNotFound)
+	}
+	return &location, nil
+}
+
+func (r *TourismRepo) CreateTourLocation(_ context.Context, tourLocation *entity.CreateTourLocationDTO) (*entity.TourLocation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tours[tourLocation.TourID]; !ok {
+		return nil, fmt.Errorf("create tour location: %w", gorm.ErrForeignKeyViolated)
+	}
+	location := entity.TourLocation{
+		ID:        uuid.New(),
+		TourID:    tourLocation.TourID,
+		Latitude:  tourLocation.Latitude,
+		Longitude: tourLocation.Longitude,
+	}
+	touch(&location.Model)
+	r.tourLocations[location.TourID] = location
+	return &location, nil
+}
+
+func (r *TourismRepo) GetAllCategories(_ context.Context) ([]entity.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	categories := make([]entity.Category, 0, len(r.categories))
+	for _, category := range r.categories {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].SortOrder != categories[j].SortOrder {
+			return categories[i].SortOrder < categories[j].SortOrder
+		}
+		return categories[i].Name < categories[j].Name
+	})
+	return categories, nil
+}
+
+// LockCategories -.
+// The changes of the repository are applied one at a time, so there is nothing to lock.
+func (r *TourismRepo) LockCategories(ctx context.Context) ([]entity.Category, error) {
+	return r.GetAllCategories(ctx)
+}
+
+func (r *TourismRepo) GetCategoryByID(_ context.Context, categoryID uuid.UUID) (*entity.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	category, ok := r.categories[categoryID]
+	if !ok {
+		return nil, fmt.Errorf("get category by id: %w", gorm.ErrRecordNotFound)
+	}
+	return &category, nil
+}
+
+// CreateCategory -.
+func (r *TourismRepo) CreateCategory(_ context.Context, category *entity.Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkCategory(category); err != nil {
+		return fmt.Errorf("create category: %w", err)
+	}
+	if category.ID == uuid.Nil {
+		category.ID = uuid.New()
+	}
+	touch(&category.Model)
+	r.categories[category.ID] = *category
+	return nil
+}
+
+// UpdateCategory saves the editable fields of a category.
+func (r *TourismRepo) UpdateCategory(_ context.Context, category *entity.Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.categories[category.ID]
+	if !ok {
+		return nil
+	}
+	if err := r.checkCategory(category); err != nil {
+		return fmt.Errorf("update category: %w", err)
+	}
+	stored.Name = category.Name
+	stored.Slug = category.Slug
+	stored.Icon = category.Icon
+	stored.ParentID = category.ParentID
+	stored.SortOrder = category.SortOrder
+	stored.UpdatedAt = time.Now()
+	r.categories[stored.ID] = stored
+	return nil
+}
+
+// DeleteCategory removes a category and its tour assignments.
+func (r *TourismRepo) DeleteCategory(_ context.Context, categoryID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.categories[categoryID]; !ok {
+		return fmt.Errorf("delete category: %w", gorm.ErrRecordNotFound)
+	}
+	for _, category := range r.categories {
+		if category.ParentID != nil && *category.ParentID == categoryID {
+			return fmt.Errorf("delete category: category %s has children", categoryID)
+		}
+	}
+	delete(r.categories, categoryID)
+	for key := range r.tourCategories {
+		if key.categoryID == categoryID {
+			delete(r.tourCategories, key)
+		}
+	}
+	return nil
+}
+
+// GetCategoryTourCounts returns the number of tours of every category including its descendants.
+// Categories without tours are missing from the result.
+func (r *TourismRepo) GetCategoryTourCounts(_ context.Context) (map[uuid.UUID]int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[uuid.UUID]int64)
+	for id := range r.categories {
+		subtree := r.descendants([]uuid.UUID{id})
+		tours := make(map[uuid.UUID]struct{})
+		for key := range r.tourCategories {
+			if _, ok := subtree[key.categoryID]; !ok {
+				continue
+			}
+			if _, ok := r.tours[key.tourID]; ok {
+				tours[key.tourID] = struct{}{}
+			}
+		}
+		if len(tours) > 0 {
+			counts[id] = int64(len(tours))
+		}
+	}
+	return counts, nil
+}
+
+func (r *TourismRepo) CreateTourCategory(_ context.Context, tourCategory *entity.CreateTourCategoryDTO) (*entity.TourCategory, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	category := &entity.TourCategory{TourID: tourCategory.TourID, CategoryID: tourCategory.CategoryID}
+	key := tourCategoryKey{tourCategory.TourID, tourCategory.CategoryID}
+	if _, ok := r.tourCategories[key]; ok {
+		return nil, fmt.Errorf("create tour category: %w", gorm.ErrDuplicatedKey)
+	}
+	_, tourExists := r.tours[key.tourID]
+	_, categoryExists := r.categories[key.categoryID]
+	if !tourExists || !categoryExists {
+		return nil, fmt.Errorf("create tour category: %w", gorm.ErrForeignKeyViolated)
+	}
+	r.tourCategories[key] = struct{}{}
+	return category, nil
+}
+
+// CreatePurchase takes a place of an open tour event. The purchase is returned with
+// its tour event and tour along with the categories of the tour, the user is not loaded.
+func (r *TourismRepo) CreatePurchase(_ context.Context, purchase *entity.Purchase) (*entity.Purchase, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tourEvent, ok := r.tourEvents[purchase.TourEventID]
+	if !ok {
+		return nil, fmt.Errorf("create purchase transaction failed: tour event not found: %w", gorm.ErrRecordNotFound)
+	}
+	if !tourEvent.IsOpened || tourEvent.AmountOfPlaces <= 0 {
+		return nil, fmt.Errorf("create purchase transaction failed: %w", entity.ErrTourEventSoldOut)
+	}
+	tourEvent.AmountOfPlaces--
+	r.tourEvents[tourEvent.ID] = tourEvent
+
+	if purchase.ID == uuid.Nil {
+		purchase.ID = uuid.New()
+	}
+	touch(&purchase.Model)
+	r.purchases[purchase.ID] = *purchase
+
+	purchase.TourEvent = tourEvent
+	purchase.TourEvent.Tour = r.tours[tourEvent.TourID]
+	purchase.TourEvent.Tour.TourCategories = nil
+	for key := range r.tourCategories {
+		if key.tourID == tourEvent.TourID {
+			purchase.TourEvent.Tour.TourCategories = append(purchase.TourEvent.Tour.TourCategories, entity.TourCategory{
+				CategoryID: key.categoryID,
+				Category:   r.categories[key.categoryID],
+				TourID:     key.tourID,
+			})
+		}
+	}
+	return purchase, nil
+}
+
+func (r *TourismRepo) PayTourEvent(_ context.Context, purchase *entity.Purchase) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.purchases[purchase.ID]
+	if !ok || stored.Status != entity.PurchaseStatusProcessing {
+		return fmt.Errorf("purchase %s is not in %s status", purchase.ID, entity.PurchaseStatusProcessing)
+	}
+	stored.Status = entity.PurchaseStatusPaid
+	stored.UpdatedAt = time.Now()
+	r.purchases[stored.ID] = stored
+	return nil
+}
+
+func (r *TourismRepo) FailPurchase(_ context.Context, purchase *entity.Purchase) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.purchases[purchase.ID]
+	if !ok || stored.Status != entity.PurchaseStatusProcessing {
+		return fmt.Errorf("purchase %s is not in %s status", purchase.ID, entity.PurchaseStatusProcessing)
+	}
+	stored.Status = entity.PurchaseStatusFailed
+	stored.UpdatedAt = time.Now()
+	r.purchases[stored.ID] = stored
+
+	if tourEvent, ok := r.tourEvents[stored.TourEventID]; ok {
+		tourEvent.AmountOfPlaces++
+		r.tourEvents[tourEvent.ID] = tourEvent
+	}
+	return nil
+}
+
+func (r *TourismRepo) CheckTourOwner(_ context.Context, tourID uuid.UUID, userID uuid.UUID) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tour, ok := r.tours[tourID]
+	return ok && tour.OwnerID == userID
+}
+
+func (r *TourismRepo) TourExists(_ context.Context, tourID uuid.UUID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.tours[tourID]
+	return ok, nil
+}
+
+func (r *TourismRepo) TourEventExists(_ context.Context, tourEventID uuid.UUID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.tourEvents[tourEventID]
+	return ok, nil
+}
+
+func (r *TourismRepo) CreateTourEvent(_ context.Context, tourEvent *entity.TourEvent) (*entity.TourEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tour, ok := r.tours[tourEvent.TourID]
+	if !ok {
+		return nil, fmt.Errorf("create tour event: tour with id %s does not exist: %w", tourEvent.TourID, gorm.ErrRecordNotFound)
+	}
+	if tourEvent.ID == uuid.Nil {
+		tourEvent.ID = uuid.New()
+	}
+	touch(&tourEvent.Model)
+	r.tourEvents[tourEvent.ID] = *tourEvent
+
+	tourEvent.Tour = tour
+	return tourEvent, nil
+}
+
+func (r *TourismRepo) GetTourByID(_ context.Context, tourID string) (*entity.Tour, error) {
+	id, err := uuid.Parse(tourID)
+	if err != nil {
+		return nil, fmt.Errorf("get tour by id: %w", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tour, ok := r.tours[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &tour, nil
+}
+
+func (r *TourismRepo) GetTours(_ context.Context) ([]entity.Tour, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tours := make([]entity.Tour, 0, len(r.tours))
+	for _, tour := range r.tours {
+		tours = append(tours, tour)
+	}
+	sort.Slice(tours, func(i, j int) bool { return tours[i].CreatedAt.Before(tours[j].CreatedAt) })
+	return tours, nil
+}
+
+// CreateTour stores a tour. Media files are not supported and fail with ErrMediaNotSupported.
+func (r *TourismRepo) CreateTour(_ context.Context, tour *entity.Tour, imageFiles []*multipart.FileHeader, videoFiles []*multipart.FileHeader) (*entity.Tour, error) {
+	if len(imageFiles) > 0 || len(videoFiles) > 0 {
+		return nil, ErrMediaNotSupported
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if tour.ID == uuid.Nil {
+		tour.ID = uuid.New()
+	}
+	touch(&tour.Model)
+	r.tours[tour.ID] = *tour
+	return tour, nil
+}
+
+// checkCategory enforces the unique slug and the parent foreign key.
+func (r *TourismRepo) checkCategory(category *entity.Category) error {
+	if category.ParentID != nil {
+		if _, ok := r.categories[*category.ParentID]; !ok {
+			return fmt.Errorf("parent category %s does not exist: %w", *category.ParentID, gorm.ErrForeignKeyViolated)
+		}
+	}
+	if category.Slug == "" {
+		return nil
+	}
+	for _, other := range r.categories {
+		if other.ID != category.ID && other.Slug == category.Slug {
+			return fmt.Errorf("duplicate category slug %q: %w", category.Slug, gorm.ErrDuplicatedKey)
+		}
+	}
+	return nil
+}
+
+// descendants returns the categories of ids and all of their descendants.
+func (r *TourismRepo) descendants(ids []uuid.UUID) map[uuid.UUID]struct{} {
+	found := make(map[uuid.UUID]struct{}, len(ids))
+	queue := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := r.categories[id]; ok {
+			found[id] = struct{}{}
+			queue = append(queue, id)
+		}
+	}
+	for len(queue) > 0 {
+		parentID := queue[0]
+		queue = queue[1:]
+		for _, category := range r.categories {
+			if category.ParentID == nil || *category.ParentID != parentID {
+				continue
+			}
+			if _, ok := found[category.ID]; !ok {
+				found[category.ID] = struct{}{}
+				queue = append(queue, category.ID)
+			}
+		}
+	}
+	return found
+}
+
+// inCategories reports whether the tour is in one of categories, or in any category when restrict is false.
+func (r *TourismRepo) inCategories(tourID uuid.UUID, categories map[uuid.UUID]struct{}, restrict bool) bool {
+	for key := range r.tourCategories {
+		if key.tourID != tourID {
+			continue
+		}
+		if _, ok := categories[key.categoryID]; ok || !restrict {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *TourismRepo) hasOpenEvent(tourID uuid.UUID, filter *entity.TourEventFilter, categories map[uuid.UUID]struct{}) bool {
+	if len(filter.CategoryIDs) > 0 && !r.inCategories(tourID, categories, true) {
+		return false
+	}
+	for _, tourEvent := range r.tourEvents {
+		if tourEvent.TourID == tourID && tourEvent.IsOpened && matchesEvent(&tourEvent, filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesEvent checks the date and budget conditions of filter.
+func matchesEvent(tourEvent *entity.TourEvent, filter *entity.TourEventFilter) bool {
+	switch {
+	case !filter.StartDate.IsZero() && tourEvent.Date.Before(filter.StartDate):
+		return false
+	case !filter.EndDate.IsZero() && tourEvent.Date.After(filter.EndDate):
+		return false
+	case filter.MinPrice > 0 && tourEvent.Price < filter.MinPrice:
+		return false
+	case filter.MaxPrice > 0 && tourEvent.Price > filter.MaxPrice:
+		return false
+	}
+	return true
+}
+
+// matchesText checks the text query of filter against the original tour texts, ignoring case.
+func matchesText(tour *entity.Tour, filter *entity.TourEventFilter) bool {
+	if filter.Query == "" {
+		return true
+	}
+	query := strings.ToLower(filter.Query)
+	return strings.Contains(strings.ToLower(tour.Description), query) || strings.Contains(strings.ToLower(tour.Route), query)
+}
+
+// touch sets the timestamps of a new row.
+func touch(model *gorm.Model) {
+	now := time.Now()
+	model.CreatedAt = now
+	model.UpdatedAt = now
+}
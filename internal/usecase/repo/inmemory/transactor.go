@@ -0,0 +1,17 @@
+package inmemory
+
+import (
+	"context"
+	"tourism-backend/internal/usecase"
+)
+
+var _ usecase.Transactor = Transactor{}
+
+// Transactor runs the functions directly. The in-memory repositories apply every change at once,
+// so changes made before an error are not rolled back.
+type Transactor struct{}
+
+// Transaction -.
+func (Transactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
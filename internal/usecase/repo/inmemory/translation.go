@@ -0,0 +1,163 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var _ usecase.TranslationRepo = (*TranslationRepo)(nil)
+
+type translationKey struct {
+	resource   string
+	resourceID uuid.UUID
+	field      string
+	language   string
+}
+
+type tourLocaleKey struct {
+	tourID   uuid.UUID
+	language string
+}
+
+type categoryLocaleKey struct {
+	categoryID uuid.UUID
+	language   string
+}
+
+// TranslationRepo keeps the cached machine translations and the provider locales.
+type TranslationRepo struct {
+	mu              sync.RWMutex
+	translations    map[translationKey]entity.TourTranslation
+	tourLocales     map[tourLocaleKey]entity.TourLocale
+	categoryLocales map[categoryLocaleKey]entity.CategoryLocale
+}
+
+// NewTranslationRepo -.
+func NewTranslationRepo() *TranslationRepo {
+	return &TranslationRepo{
+		translations:    make(map[translationKey]entity.TourTranslation),
+		tourLocales:     make(map[tourLocaleKey]entity.TourLocale),
+		categoryLocales: make(map[categoryLocaleKey]entity.CategoryLocale),
+	}
+}
+
+func (r *TranslationRepo) GetTranslations(_ context.Context, language string, resourceIDs []uuid.UUID) ([]entity.TourTranslation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var translations []entity.TourTranslation
+	for key, translation := range r.translations {
+		if key.language == language && slices.Contains(resourceIDs, key.resourceID) {
+			translations = append(translations, translation)
+		}
+	}
+	return translations, nil
+}
+
+// SaveTranslations caches machine translations, replacing the ones made from an earlier source text.
+func (r *TranslationRepo) SaveTranslations(_ context.Context, translations []entity.TourTranslation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, translation := range translations {
+		key := translationKey{translation.Resource, translation.ResourceID, translation.Field, translation.Language}
+		if stored, ok := r.translations[key]; ok {
+			translation.ID, translation.CreatedAt = stored.ID, stored.CreatedAt
+		} else {
+			translation.ID, translation.CreatedAt = uuid.New(), now
+		}
+		translation.UpdatedAt = now
+		r.translations[key] = translation
+	}
+	return nil
+}
+
+// GetTourLocales returns the locales of the tours in languages, or in every language when languages is empty.
+func (r *TranslationRepo) GetTourLocales(_ context.Context, tourIDs []uuid.UUID, languages []string) ([]entity.TourLocale, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var locales []entity.TourLocale
+	for key, locale := range r.tourLocales {
+		if slices.Contains(tourIDs, key.tourID) && (len(languages) == 0 || slices.Contains(languages, key.language)) {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Slice(locales, func(i, j int) bool { return locales[i].Language < locales[j].Language })
+	return locales, nil
+}
+
+// SaveTourLocale creates or replaces the locale of a tour.
+func (r *TranslationRepo) SaveTourLocale(_ context.Context, locale *entity.TourLocale) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	key := tourLocaleKey{locale.TourID, locale.Language}
+	if stored, ok := r.tourLocales[key]; ok {
+		locale.CreatedAt = stored.CreatedAt
+	} else {
+		locale.CreatedAt = now
+	}
+	locale.UpdatedAt = now
+	r.tourLocales[key] = *locale
+	return nil
+}
+
+// DeleteTourLocale removes the locale of a tour together with its cached machine translations.
+func (r *TranslationRepo) DeleteTourLocale(_ context.Context, tourID uuid.UUID, language string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := tourLocaleKey{tourID, language}
+	if _, ok := r.tourLocales[key]; !ok {
+		return fmt.Errorf("delete tour locale: %w", gorm.ErrRecordNotFound)
+	}
+	delete(r.tourLocales, key)
+	for key := range r.translations {
+		if key.resource == entity.TranslationResourceTour && key.resourceID == tourID && key.language == language {
+			delete(r.translations, key)
+		}
+	}
+	return nil
+}
+
+func (r *TranslationRepo) GetCategoryLocales(_ context.Context, categoryIDs []uuid.UUID, languages []string) ([]entity.CategoryLocale, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var locales []entity.CategoryLocale
+	for key, locale := range r.categoryLocales {
+		if slices.Contains(categoryIDs, key.categoryID) && slices.Contains(languages, key.language) {
+			locales = append(locales, locale)
+		}
+	}
+	return locales, nil
+}
+
+// SaveCategoryLocale creates or replaces the name of a category in one language.
+func (r *TranslationRepo) SaveCategoryLocale(_ context.Context, locale *entity.CategoryLocale) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	key := categoryLocaleKey{locale.CategoryID, locale.Language}
+	if stored, ok := r.categoryLocales[key]; ok {
+		locale.CreatedAt = stored.CreatedAt
+	} else {
+		locale.CreatedAt = now
+	}
+	locale.UpdatedAt = now
+	r.categoryLocales[key] = *locale
+	return nil
+}
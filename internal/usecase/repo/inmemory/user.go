@@ -0,0 +1,71 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	_ usecase.UserRepo  = (*UserRepo)(nil)
+	_ usecase.AdminRepo = (*UserRepo)(nil)
+)
+
+// UserRepo keeps the users for both the user and the admin use cases.
+type UserRepo struct {
+	mu    sync.RWMutex
+	users map[uuid.UUID]entity.User
+}
+
+// NewUserRepo -.
+func NewUserRepo() *UserRepo {
+	return &UserRepo{users: make(map[uuid.UUID]entity.User)}
+}
+
+func (u *UserRepo) LoginUser(_ context.Context, user *entity.LoginUserDTO) (*entity.User, error) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	for _, stored := range u.users {
+		if stored.Username == user.Username {
+			return &stored, nil
+		}
+	}
+	return nil, fmt.Errorf("Username Not Found: %w", gorm.ErrRecordNotFound)
+}
+
+// RegisterUser stores a user. Usernames and emails are unique.
+func (u *UserRepo) RegisterUser(_ context.Context, user *entity.User) (*entity.User, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for _, stored := range u.users {
+		if stored.Username == user.Username || stored.Email == user.Email {
+			return nil, fmt.Errorf("register user: %w", gorm.ErrDuplicatedKey)
+		}
+	}
+	if user.ID == uuid.Nil {
+		user.ID = uuid.New()
+	}
+	touch(&user.Model)
+	u.users[user.ID] = *user
+	return user, nil
+}
+
+func (u *UserRepo) GetUsers(_ context.Context) ([]*entity.User, error) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	users := make([]*entity.User, 0, len(u.users))
+	for _, stored := range u.users {
+		users = append(users, &stored)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].CreatedAt.Before(users[j].CreatedAt) })
+	return users, nil
+}
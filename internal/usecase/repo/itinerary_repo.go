@@ -0,0 +1,73 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/postgres"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ItineraryRepo struct {
+	PG *postgres.Postgres
+}
+
+// New -.
+func NewItineraryRepo(pg *postgres.Postgres) *ItineraryRepo {
+	return &ItineraryRepo{pg}
+}
+
+// GetItinerary returns the stops in order and the stored track, nil when the tour has none.
+func (r *ItineraryRepo) GetItinerary(ctx context.Context, tourID uuid.UUID) ([]entity.RouteStop, *entity.RouteTrack, error) {
+	stops := make([]entity.RouteStop, 0, _defaultEntityCap)
+	err := r.PG.DB(ctx).Where("tour_id = ?", tourID).Order("position ASC").Find(&stops).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("get route stops: %w", err)
+	}
+
+	var track entity.RouteTrack
+	err = r.PG.DB(ctx).Where("tour_id = ?", tourID).Take(&track).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return stops, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("get route track: %w", err)
+	}
+	return stops, &track, nil
+}
+
+// ReplaceItinerary swaps the whole itinerary of a tour and stores its totals on the tour.
+func (r *ItineraryRepo) ReplaceItinerary(ctx context.Context, tourID uuid.UUID, stops []entity.RouteStop, track *entity.RouteTrack, distanceKm, elevationGainM float64) error {
+	return r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("tour_id = ?", tourID).Delete(&entity.RouteStop{}).Error; err != nil {
+			return fmt.Errorf("delete route stops: %w", err)
+		}
+		if err := tx.Unscoped().Where("tour_id = ?", tourID).Delete(&entity.RouteTrack{}).Error; err != nil {
+			return fmt.Errorf("delete route track: %w", err)
+		}
+
+		if len(stops) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&stops).Error; err != nil {
+				return fmt.Errorf("create route stops: %w", err)
+			}
+		}
+		if track != nil {
+			if err := tx.Omit(clause.Associations).Create(track).Error; err != nil {
+				return fmt.Errorf("create route track: %w", err)
+			}
+		}
+
+		err := tx.Model(&entity.Tour{}).Where("id = ?", tourID).UpdateColumns(map[string]interface{}{
+			"route_distance_km":      distanceKm,
+			"route_elevation_gain_m": elevationGainM,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("update tour route totals: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,223 @@
+package repo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path"
+	"strconv"
+	"strings"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/media"
+	"tourism-backend/pkg/postgres"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Media key prefixes.
+const (
+	_mediaImages = "images"
+	_mediaVideos = "videos"
+	// _mediaStaging keeps uploads until their digest is known, it is private so they are never served.
+	_mediaStaging = media.PrivatePrefix + "staging"
+)
+
+// _mediaDirs are the directories this service writes to. Garbage collection only looks into them,
+// the store may hold objects of other services.
+var _mediaDirs = []string{_mediaImages, _mediaVideos, _mediaStaging, _mediaDocuments}
+
+// putMedia stores the uploaded file in dir and takes a reference to it in tx, see storeBlob.
+func putMedia(tx *gorm.DB, store media.Store, dir string, file *multipart.FileHeader) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("open upload: %w", err)
+	}
+	defer src.Close()
+
+	return storeBlob(tx, store, dir, src, file.Size, file.Header.Get("Content-Type"))
+}
+
+// storeBlob stores content once per SHA-256 digest under dir and takes a reference to it in tx.
+// The content is hashed while it is written to a staging key, then moved to the digest key, or
+// dropped when a blob with the same digest exists and only its reference count is incremented.
+// Keys have no extension, so the same content uploaded under different names is stored once,
+// the content type is kept in the blob row and given to the store.
+//
+// Blobs are locked by key until tx ends, so concurrent uploads of the same content wait
+// for the first one instead of reading a half-written object. An object written by a
+// transaction that rolls back is left to the orphaned media collection, deleting it here
+// could remove the object of a concurrent upload that already took the lock.
+func storeBlob(tx *gorm.DB, store media.Store, dir string, src io.Reader, size int64, contentType string) (string, error) {
+	ctx := tx.Statement.Context
+	staging := path.Join(_mediaStaging, uuid.NewString())
+	h := sha256.New()
+	if err := store.Put(ctx, staging, io.TeeReader(src, h), size, contentType); err != nil {
+		return "", fmt.Errorf("store upload: %w", err)
+	}
+	digest := hex.EncodeToString(h.Sum(nil))
+	key := path.Join(dir, digest[:2], digest)
+
+	refCount, err := referenceBlob(tx, key, digest, size, contentType)
+	if err != nil {
+		_ = store.Delete(ctx, staging)
+		return "", err
+	}
+	if refCount > 1 {
+		// The content is stored already.
+		_ = store.Delete(ctx, staging)
+		return key, nil
+	}
+	if err := store.Move(ctx, staging, key); err != nil {
+		_ = store.Delete(ctx, staging)
+		return "", fmt.Errorf("store upload: %w", err)
+	}
+	return key, nil
+}
+
+// referenceBlob takes a reference to the blob stored under key in tx and returns the reference count,
+// 1 when the blob is new and its object still has to be written.
+func referenceBlob(tx *gorm.DB, key, digest string, size int64, contentType string) (int, error) {
+	if err := lockBlob(tx, key); err != nil {
+		return 0, err
+	}
+	var refCount int
+	err := tx.Raw(`
+		INSERT INTO media_blobs (key, sha256, size, content_type, ref_count, created_at, updated_at)
+		VALUES (?, ?, ?, ?, 1, NOW(), NOW())
+		ON CONFLICT (key) DO UPDATE SET ref_count = media_blobs.ref_count + 1, updated_at = NOW()
+		RETURNING ref_count`, key, digest, size, contentType).Scan(&refCount).Error
+	if err != nil {
+		return 0, fmt.Errorf("reference media blob: %w", err)
+	}
+	return refCount, nil
+}
+
+// releaseBlob drops a reference taken by storeBlob in tx and reports whether it was the last one.
+// The object itself must be removed with deleteAfterCommit.
+// Keys without a blob row predate deduplication and had a single reference.
+func releaseBlob(tx *gorm.DB, key string) (bool, error) {
+	if err := lockBlob(tx, key); err != nil {
+		return false, err
+	}
+	var refCounts []int
+	err := tx.Raw(`UPDATE media_blobs SET ref_count = ref_count - 1, updated_at = NOW() WHERE key = ? RETURNING ref_count`, key).
+		Scan(&refCounts).Error
+	if err != nil {
+		return false, fmt.Errorf("release media blob: %w", err)
+	}
+	if len(refCounts) == 0 {
+		return true, nil
+	}
+	if refCounts[0] > 0 {
+		return false, nil
+	}
+	if err := tx.Where("key = ?", key).Delete(&entity.MediaBlob{}).Error; err != nil {
+		return false, fmt.Errorf("delete media blob: %w", err)
+	}
+	return true, nil
+}
+
+// deleteBlobs removes the objects released by a committed transaction, best effort.
+// Callers detach db from the request context, so a client disconnect does not skip the cleanup.
+// A key referenced again in the meantime is kept.
+func deleteBlobs(db *gorm.DB, store media.Store, keys []string) {
+	for _, key := range keys {
+		_ = db.Transaction(func(tx *gorm.DB) error {
+			if err := lockBlob(tx, key); err != nil {
+				return err
+			}
+			var count int64
+			if err := tx.Model(&entity.MediaBlob{}).Where("key = ?", key).Count(&count).Error; err != nil || count > 0 {
+				return err
+			}
+			return store.Delete(tx.Statement.Context, key)
+		})
+	}
+}
+
+// deleteAfterCommit removes the released blobs and the unshared objects once the transaction
+// of ctx commits, right away outside a transaction. Nothing is removed when it rolls back.
+// deleteBlobs runs on a connection of its own, so it must not wait for the blob locks of the open transaction.
+func deleteAfterCommit(ctx context.Context, pg *postgres.Postgres, store media.Store, released, unshared []string) {
+	pg.AfterCommit(ctx, func() {
+		ctx := context.WithoutCancel(ctx)
+		deleteBlobs(pg.Conn.WithContext(ctx), store, released)
+		deleteMedia(ctx, store, unshared)
+	})
+}
+
+// lockBlob serializes storeBlob, releaseBlob and deleteBlobs on key until tx ends.
+// A transaction-level advisory lock also covers keys that have no blob row yet.
+func lockBlob(tx *gorm.DB, key string) error {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", key).Error; err != nil {
+		return fmt.Errorf("lock media blob: %w", err)
+	}
+	return nil
+}
+
+// deleteMedia removes objects that are not shared, such as image variants, best effort.
+func deleteMedia(ctx context.Context, store media.Store, keys []string) {
+	for _, key := range keys {
+		_ = store.Delete(ctx, key)
+	}
+}
+
+// resolveTourMedia fills the download URLs of the tour images and videos.
+func resolveTourMedia(store media.Store, tour *entity.Tour) {
+	for i := range tour.TourImages {
+		resolveImageMedia(store, &tour.TourImages[i])
+	}
+	for i := range tour.TourVideos {
+		tour.TourVideos[i].URL = store.URL(tour.TourVideos[i].VideoURL)
+	}
+}
+
+// resolveReviewMedia fills the download URLs of the review photos.
+func resolveReviewMedia(store media.Store, review *entity.Review) {
+	for i := range review.ReviewPhotos {
+		review.ReviewPhotos[i].URL = store.URL(review.ReviewPhotos[i].ImageURL)
+	}
+}
+
+// resolveImageMedia fills the download URLs of the image and its variants and builds its srcset.
+// Until the image is ready its original still carries the EXIF and GPS data of the upload,
+// so it gets no URL.
+func resolveImageMedia(store media.Store, image *entity.Image) {
+	if image.Status == entity.ImageStatusReady {
+		image.URL = store.URL(image.ImageURL)
+	}
+
+	var srcset []string
+	for i := range image.Variants {
+		variant := &image.Variants[i]
+		variant.URL = store.URL(variant.Key)
+		if variant.Kind == entity.ImageVariantResponsive {
+			srcset = append(srcset, variant.URL+" "+strconv.Itoa(variant.Width)+"w")
+		}
+	}
+	if image.Status == entity.ImageStatusReady && image.Width > 0 {
+		srcset = append(srcset, image.URL+" "+strconv.Itoa(image.Width)+"w")
+	}
+	image.SrcSet = strings.Join(srcset, ", ")
+}
+
+// preloadTourMedia loads the ordered images with their variants and the ordered videos of tours.
+func preloadTourMedia(db *gorm.DB) *gorm.DB {
+	return db.Preload("TourImages", orderMedia).
+		Preload("TourImages.Variants", orderVariants).
+		Preload("TourVideos", orderMedia)
+}
+
+// orderMedia lists tour images and videos in the order chosen by the provider.
+func orderMedia(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC").Order("created_at ASC")
+}
+
+// orderVariants lists image variants from the smallest to the largest.
+func orderVariants(db *gorm.DB) *gorm.DB {
+	return db.Order("kind ASC").Order("width ASC").Order("format ASC")
+}
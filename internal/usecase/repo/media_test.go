@@ -0,0 +1,156 @@
+package repo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/media"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// recordingStore is a local store that records the keys objects are written to.
+type recordingStore struct {
+	*media.LocalStore
+	written []string
+}
+
+func newRecordingStore(t *testing.T) *recordingStore {
+	t.Helper()
+	return &recordingStore{LocalStore: media.NewLocalStore(t.TempDir(), "/uploads")}
+}
+
+func (s *recordingStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	s.written = append(s.written, key)
+	return s.LocalStore.Put(ctx, key, r, size, contentType)
+}
+
+func (s *recordingStore) Move(ctx context.Context, from, to string) error {
+	s.written = append(s.written, to)
+	return s.LocalStore.Move(ctx, from, to)
+}
+
+// keys lists the stored objects.
+func (s *recordingStore) keys(t *testing.T) []string {
+	t.Helper()
+	var keys []string
+	err := s.List(context.Background(), "", func(object media.Object) error {
+		keys = append(keys, object.Key)
+		return nil
+	})
+	require.NoError(t, err)
+	return keys
+}
+
+func TestStoreBlobDeduplicates(t *testing.T) {
+	t.Parallel()
+
+	pg := newTestDB(t, &entity.MediaBlob{})
+	store := newRecordingStore(t)
+	db := pg.Conn.WithContext(context.Background())
+
+	put := func(content string) string {
+		var key string
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			key, err = storeBlob(tx, store, _mediaImages, strings.NewReader(content), int64(len(content)), "image/jpeg")
+			return err
+		})
+		require.NoError(t, err)
+		return key
+	}
+
+	key := put("jpeg bytes")
+	sum := sha256.Sum256([]byte("jpeg bytes"))
+	digest := hex.EncodeToString(sum[:])
+	require.Equal(t, "images/"+digest[:2]+"/"+digest, key, "keys are the digest without extension")
+	require.Equal(t, []string{key}, store.keys(t))
+
+	written := len(store.written)
+	require.Equal(t, key, put("jpeg bytes"))
+	require.NotContains(t, store.written[written:], key, "an identical upload does not write the object again")
+	require.Equal(t, []string{key}, store.keys(t), "the staged copy of the duplicate is removed")
+
+	var blob entity.MediaBlob
+	require.NoError(t, db.First(&blob, "key = ?", key).Error)
+	require.Equal(t, 2, blob.RefCount)
+	require.Equal(t, "image/jpeg", blob.ContentType)
+	require.Equal(t, int64(len("jpeg bytes")), blob.Size)
+}
+
+func TestReleaseBlob(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		references int
+		last       bool
+		kept       bool
+	}{
+		{name: "shared blob is kept", references: 2, last: false, kept: true},
+		{name: "last reference deletes the object", references: 1, last: true, kept: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pg := newTestDB(t, &entity.MediaBlob{})
+			store := newRecordingStore(t)
+			db := pg.Conn.WithContext(context.Background())
+
+			var key string
+			for range tt.references {
+				err := db.Transaction(func(tx *gorm.DB) error {
+					var err error
+					key, err = storeBlob(tx, store, _mediaVideos, strings.NewReader("mp4 bytes"), 9, "video/mp4")
+					return err
+				})
+				require.NoError(t, err)
+			}
+
+			var last bool
+			err := db.Transaction(func(tx *gorm.DB) error {
+				var err error
+				last, err = releaseBlob(tx, key)
+				return err
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.last, last)
+			if last {
+				deleteBlobs(db, store, []string{key})
+			}
+
+			_, err = store.Get(context.Background(), key)
+			if tt.kept {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, media.ErrNotFound)
+			}
+		})
+	}
+}
+
+func TestDeleteBlobsKeepsReferencedKeys(t *testing.T) {
+	t.Parallel()
+
+	pg := newTestDB(t, &entity.MediaBlob{})
+	store := newRecordingStore(t)
+	db := pg.Conn.WithContext(context.Background())
+
+	var key string
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		key, err = storeBlob(tx, store, _mediaImages, strings.NewReader("png bytes"), 9, "image/png")
+		return err
+	})
+	require.NoError(t, err)
+
+	// A key uploaded again between its release and the cleanup is still referenced and kept.
+	deleteBlobs(db, store, []string{key})
+	require.Equal(t, []string{key}, store.keys(t))
+}
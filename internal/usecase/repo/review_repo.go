@@ -0,0 +1,215 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"mime/multipart"
+	"time"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/media"
+	"tourism-backend/pkg/postgres"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	_reviewDefaultLimit = 20
+	_reviewMaxLimit     = 100
+)
+
+type ReviewRepo struct {
+	PG    *postgres.Postgres
+	Media media.Store
+}
+
+// New -.
+func NewReviewRepo(pg *postgres.Postgres, store media.Store) *ReviewRepo {
+	return &ReviewRepo{pg, store}
+}
+
+// HasCompletedPurchase reports whether the user paid for an event of the tour that has already taken place.
+func (r *ReviewRepo) HasCompletedPurchase(ctx context.Context, tourID, userID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.PG.DB(ctx).Model(&entity.Purchase{}).
+		Joins("JOIN tour_events ON tour_events.id = purchases.tour_event_id").
+		Where("purchases.user_id = ? AND purchases.status = ?", userID, entity.PurchaseStatusPaid).
+		Where("tour_events.tour_id = ? AND tour_events.date < ?", tourID, time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("check completed purchase: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *ReviewRepo) CreateReview(ctx context.Context, review *entity.Review, photoFiles []*multipart.FileHeader) (*entity.Review, error) {
+	err := r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(review).Error; err != nil {
+			return fmt.Errorf("create review: %w", err)
+		}
+
+		for _, file := range photoFiles {
+			key, err := putMedia(tx, r.Media, _mediaImages, file)
+			if err != nil {
+				return err
+			}
+			photo := entity.ReviewPhoto{ReviewID: review.ID, ImageURL: key}
+			if err := tx.Create(&photo).Error; err != nil {
+				return fmt.Errorf("create review photo: %w", err)
+			}
+			review.ReviewPhotos = append(review.ReviewPhotos, photo)
+		}
+
+		return applyRating(tx, review.TourID, 1, review.Rating)
+	})
+	if err != nil {
+		return nil, err
+	}
+	resolveReviewMedia(r.Media, review)
+	return review, nil
+}
+
+func (r *ReviewRepo) GetReviewByID(ctx context.Context, reviewID uuid.UUID) (*entity.Review, error) {
+	var review entity.Review
+	err := r.PG.DB(ctx).Preload("ReviewPhotos").First(&review, "id = ?", reviewID).Error
+	if err != nil {
+		return nil, fmt.Errorf("get review by id: %w", err)
+	}
+	resolveReviewMedia(r.Media, &review)
+	return &review, nil
+}
+
+func (r *ReviewRepo) GetTourReviews(ctx context.Context, tourID uuid.UUID, filter *entity.ReviewFilter) ([]*entity.Review, error) {
+	reviews := make([]*entity.Review, 0, _defaultEntityCap)
+
+	query := r.PG.DB(ctx).Preload("ReviewPhotos").
+		Where("tour_id = ? AND status = ?", tourID, entity.ReviewStatusPublished)
+
+	switch filter.Sort {
+	case entity.ReviewSortOldest:
+		query = query.Order("created_at ASC")
+	case entity.ReviewSortRatingHigh:
+		query = query.Order("rating DESC").Order("created_at DESC")
+	case entity.ReviewSortRatingLow:
+		query = query.Order("rating ASC").Order("created_at DESC")
+	default:
+		query = query.Order("created_at DESC")
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = _reviewDefaultLimit
+	}
+	if limit > _reviewMaxLimit {
+		limit = _reviewMaxLimit
+	}
+
+	err := query.Limit(limit).Offset(filter.Offset).Find(&reviews).Error
+	if err != nil {
+		return nil, fmt.Errorf("get tour reviews: %w", err)
+	}
+	for _, review := range reviews {
+		resolveReviewMedia(r.Media, review)
+	}
+	return reviews, nil
+}
+
+func (r *ReviewRepo) ReplyToReview(ctx context.Context, reviewID uuid.UUID, reply string) (*entity.Review, error) {
+	now := time.Now()
+	result := r.PG.DB(ctx).Model(&entity.Review{}).
+		Where("id = ?", reviewID).
+		Updates(map[string]interface{}{"reply": reply, "replied_at": now})
+	if result.Error != nil {
+		return nil, fmt.Errorf("reply to review: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, fmt.Errorf("reply to review: %w", gorm.ErrRecordNotFound)
+	}
+	return r.GetReviewByID(ctx, reviewID)
+}
+
+// SetReviewStatus changes the moderation status and keeps the tour rating aggregates in sync.
+func (r *ReviewRepo) SetReviewStatus(ctx context.Context, reviewID uuid.UUID, status string) (*entity.Review, error) {
+	err := r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		var review entity.Review
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&review, "id = ?", reviewID).Error; err != nil {
+			return fmt.Errorf("get review: %w", err)
+		}
+		if review.Status == status {
+			return nil
+		}
+
+		if err := tx.Model(&review).Update("status", status).Error; err != nil {
+			return fmt.Errorf("update review status: %w", err)
+		}
+
+		switch status {
+		case entity.ReviewStatusHidden:
+			return applyRating(tx, review.TourID, -1, -review.Rating)
+		case entity.ReviewStatusPublished:
+			return applyRating(tx, review.TourID, 1, review.Rating)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetReviewByID(ctx, reviewID)
+}
+
+// DeleteReview removes the review with its photos. A published review is taken out of the tour rating.
+func (r *ReviewRepo) DeleteReview(ctx context.Context, reviewID uuid.UUID) error {
+	var released []string
+	err := r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		var review entity.Review
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("ReviewPhotos").First(&review, "id = ?", reviewID).Error
+		if err != nil {
+			return fmt.Errorf("get review: %w", err)
+		}
+		if err := tx.Unscoped().Where("review_id = ?", review.ID).Delete(&entity.ReviewPhoto{}).Error; err != nil {
+			return fmt.Errorf("delete review photos: %w", err)
+		}
+		if err := tx.Unscoped().Delete(&review).Error; err != nil {
+			return fmt.Errorf("delete review: %w", err)
+		}
+
+		for _, photo := range review.ReviewPhotos {
+			last, err := releaseBlob(tx, photo.ImageURL)
+			if err != nil {
+				return err
+			}
+			if last {
+				released = append(released, photo.ImageURL)
+			}
+		}
+
+		if review.Status != entity.ReviewStatusPublished {
+			return nil
+		}
+		return applyRating(tx, review.TourID, -1, -review.Rating)
+	})
+	if err != nil {
+		return err
+	}
+	deleteAfterCommit(ctx, r.PG, r.Media, released, nil)
+	return nil
+}
+
+// applyRating adjusts the review count and rating sum of a tour and recomputes the average from them.
+func applyRating(tx *gorm.DB, tourID uuid.UUID, countDelta, ratingDelta int) error {
+	err := tx.Model(&entity.Tour{}).
+		Where("id = ?", tourID).
+		UpdateColumns(map[string]interface{}{
+			"review_count": gorm.Expr("review_count + ?", countDelta),
+			"rating_sum":   gorm.Expr("rating_sum + ?", ratingDelta),
+			"average_rating": gorm.Expr(
+				"CASE WHEN review_count + ? > 0 THEN CAST(rating_sum + ? AS float) / (review_count + ?) ELSE 0 END",
+				countDelta, ratingDelta, countDelta,
+			),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("update tour rating: %w", err)
+	}
+	return nil
+}
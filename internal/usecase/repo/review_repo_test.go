@@ -0,0 +1,123 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+	"tourism-backend/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReviewRepo(t *testing.T) *ReviewRepo {
+	t.Helper()
+	pg := newTestDB(t, &entity.Tour{}, &entity.TourEvent{}, &entity.Purchase{}, &entity.Review{}, &entity.ReviewPhoto{}, &entity.MediaBlob{})
+	return NewReviewRepo(pg, newRecordingStore(t))
+}
+
+func TestHasCompletedPurchase(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := newTestReviewRepo(t)
+	db := r.PG.Conn.WithContext(ctx)
+
+	tour := entity.Tour{Description: "Old town walk"}
+	otherTour := entity.Tour{Description: "River cruise"}
+	require.NoError(t, db.Create(&tour).Error)
+	require.NoError(t, db.Create(&otherTour).Error)
+
+	event := func(tourID uuid.UUID, date time.Time) uuid.UUID {
+		tourEvent := entity.TourEvent{TourID: tourID, Date: date, Price: 10, Place: "Square", AmountOfPlaces: 5, IsOpened: true}
+		require.NoError(t, db.Create(&tourEvent).Error)
+		return tourEvent.ID
+	}
+	past := event(tour.ID, time.Now().Add(-24*time.Hour))
+	future := event(tour.ID, time.Now().Add(24*time.Hour))
+	otherPast := event(otherTour.ID, time.Now().Add(-24*time.Hour))
+
+	tests := []struct {
+		name    string
+		event   uuid.UUID
+		status  string
+		allowed bool
+	}{
+		{name: "paid past event", event: past, status: entity.PurchaseStatusPaid, allowed: true},
+		{name: "paid future event", event: future, status: entity.PurchaseStatusPaid},
+		{name: "unpaid past event", event: past, status: entity.PurchaseStatusProcessing},
+		{name: "failed past event", event: past, status: entity.PurchaseStatusFailed},
+		{name: "paid past event of another tour", event: otherPast, status: entity.PurchaseStatusPaid},
+	}
+	for _, tc := range tests {
+		userID := uuid.New()
+		require.NoError(t, db.Create(&entity.Purchase{UserID: userID, TourEventID: tc.event, Status: tc.status}).Error, tc.name)
+
+		allowed, err := r.HasCompletedPurchase(ctx, tour.ID, userID)
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.allowed, allowed, tc.name)
+	}
+
+	allowed, err := r.HasCompletedPurchase(ctx, tour.ID, uuid.New())
+	require.NoError(t, err)
+	require.False(t, allowed, "a user without purchases")
+}
+
+func TestReviewRating(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := newTestReviewRepo(t)
+	db := r.PG.Conn.WithContext(ctx)
+
+	tour := entity.Tour{Description: "Old town walk"}
+	require.NoError(t, db.Create(&tour).Error)
+
+	create := func(rating int) func() error {
+		return func() error {
+			_, err := r.CreateReview(ctx, &entity.Review{TourID: tour.ID, UserID: uuid.New(), Rating: rating, Status: entity.ReviewStatusPublished}, nil)
+			return err
+		}
+	}
+	review := func(rating int) uuid.UUID {
+		var reviews []entity.Review
+		require.NoError(t, db.Find(&reviews, "tour_id = ? AND rating = ?", tour.ID, rating).Error)
+		require.Len(t, reviews, 1)
+		return reviews[0].ID
+	}
+	setStatus := func(rating int, status string) func() error {
+		return func() error {
+			_, err := r.SetReviewStatus(ctx, review(rating), status)
+			return err
+		}
+	}
+	remove := func(rating int) func() error {
+		return func() error {
+			return r.DeleteReview(ctx, review(rating))
+		}
+	}
+
+	steps := []struct {
+		name    string
+		do      func() error
+		count   int64
+		average float64
+	}{
+		{name: "first review", do: create(5), count: 1, average: 5},
+		{name: "second review", do: create(2), count: 2, average: 3.5},
+		{name: "hidden review is taken out", do: setStatus(2, entity.ReviewStatusHidden), count: 1, average: 5},
+		{name: "hiding again changes nothing", do: setStatus(2, entity.ReviewStatusHidden), count: 1, average: 5},
+		{name: "republished review counts again", do: setStatus(2, entity.ReviewStatusPublished), count: 2, average: 3.5},
+		{name: "deleted published review is taken out", do: remove(5), count: 1, average: 2},
+		{name: "hide the last review", do: setStatus(2, entity.ReviewStatusHidden), count: 0, average: 0},
+		{name: "deleted hidden review was already out", do: remove(2), count: 0, average: 0},
+	}
+	for _, step := range steps {
+		require.NoError(t, step.do(), step.name)
+
+		var stored entity.Tour
+		require.NoError(t, db.First(&stored, "id = ?", tour.ID).Error, step.name)
+		require.Equal(t, step.count, stored.ReviewCount, step.name)
+		require.InDelta(t, step.average, stored.AverageRating, 1e-9, step.name)
+	}
+}
@@ -0,0 +1,351 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"time"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/media"
+	"tourism-backend/pkg/postgres"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrMediaOrderMismatch is returned when a reorder request does not list exactly the media of the tour.
+var ErrMediaOrderMismatch = errors.New("order must list every media item of the tour exactly once")
+
+type TourMediaRepo struct {
+	PG    *postgres.Postgres
+	Media media.Store
+}
+
+// New -.
+func NewTourMediaRepo(pg *postgres.Postgres, store media.Store) *TourMediaRepo {
+	return &TourMediaRepo{pg, store}
+}
+
+// AddTourImages stores the files and appends them after the existing images of the tour.
+// The first image becomes the cover when the tour has none.
+func (r *TourMediaRepo) AddTourImages(ctx context.Context, tourID uuid.UUID, files []*multipart.FileHeader, meta []entity.MediaMeta) ([]entity.Image, error) {
+	images := make([]entity.Image, 0, len(files))
+	err := r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		position, err := nextPosition(tx, &entity.Image{}, tourID)
+		if err != nil {
+			return err
+		}
+		var covers int64
+		if err := tx.Model(&entity.Image{}).Where("tour_id = ? AND is_cover", tourID).Count(&covers).Error; err != nil {
+			return fmt.Errorf("count cover images: %w", err)
+		}
+
+		for i, file := range files {
+			key, err := putMedia(tx, r.Media, _mediaImages, file)
+			if err != nil {
+				return err
+			}
+
+			image := entity.Image{
+				TourID:   tourID,
+				ImageURL: key,
+				Position: position + i,
+				IsCover:  covers == 0 && i == 0,
+				AltText:  meta[i].AltText,
+				Caption:  meta[i].Caption,
+			}
+			if err := tx.Create(&image).Error; err != nil {
+				return fmt.Errorf("create image: %w", err)
+			}
+			images = append(images, image)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range images {
+		resolveImageMedia(r.Media, &images[i])
+	}
+	return images, nil
+}
+
+// AddTourVideos stores the files and appends them after the existing videos of the tour.
+func (r *TourMediaRepo) AddTourVideos(ctx context.Context, tourID uuid.UUID, files []*multipart.FileHeader, meta []entity.MediaMeta) ([]entity.Video, error) {
+	videos := make([]entity.Video, 0, len(files))
+	err := r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		position, err := nextPosition(tx, &entity.Video{}, tourID)
+		if err != nil {
+			return err
+		}
+
+		for i, file := range files {
+			key, err := putMedia(tx, r.Media, _mediaVideos, file)
+			if err != nil {
+				return err
+			}
+
+			video := entity.Video{
+				TourID:   tourID,
+				VideoURL: key,
+				Position: position + i,
+				AltText:  meta[i].AltText,
+				Caption:  meta[i].Caption,
+			}
+			if err := tx.Create(&video).Error; err != nil {
+				return fmt.Errorf("create video: %w", err)
+			}
+			videos = append(videos, video)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range videos {
+		videos[i].URL = r.Media.URL(videos[i].VideoURL)
+	}
+	return videos, nil
+}
+
+// DeleteTourImage removes the image with its variants from the database and the store.
+// When the cover is deleted, the next image in order becomes the cover.
+func (r *TourMediaRepo) DeleteTourImage(ctx context.Context, tourID, imageID uuid.UUID) error {
+	var released, variants []string
+	err := r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		var image entity.Image
+		if err := tx.Preload("Variants").Where("id = ? AND tour_id = ?", imageID, tourID).First(&image).Error; err != nil {
+			return fmt.Errorf("get image: %w", err)
+		}
+		if err := tx.Unscoped().Where("image_id = ?", image.ID).Delete(&entity.ImageVariant{}).Error; err != nil {
+			return fmt.Errorf("delete image variants: %w", err)
+		}
+		if err := tx.Unscoped().Delete(&image).Error; err != nil {
+			return fmt.Errorf("delete image: %w", err)
+		}
+
+		// A failed image has no original left.
+		if image.ImageURL != "" {
+			last, err := releaseBlob(tx, image.ImageURL)
+			if err != nil {
+				return err
+			}
+			if last {
+				released = append(released, image.ImageURL)
+			}
+		}
+		for _, variant := range image.Variants {
+			variants = append(variants, variant.Key)
+		}
+
+		if !image.IsCover {
+			return nil
+		}
+		var next entity.Image
+		err := orderMedia(tx.Where("tour_id = ?", tourID)).First(&next).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("get next cover image: %w", err)
+		}
+		return tx.Model(&next).Update("is_cover", true).Error
+	})
+	if err != nil {
+		return err
+	}
+	deleteAfterCommit(ctx, r.PG, r.Media, released, variants)
+	return nil
+}
+
+// DeleteTourVideo removes the video from the database and the store.
+func (r *TourMediaRepo) DeleteTourVideo(ctx context.Context, tourID, videoID uuid.UUID) error {
+	var last bool
+	var video entity.Video
+	err := r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND tour_id = ?", videoID, tourID).First(&video).Error; err != nil {
+			return fmt.Errorf("get video: %w", err)
+		}
+		if err := tx.Unscoped().Delete(&video).Error; err != nil {
+			return fmt.Errorf("delete video: %w", err)
+		}
+		var err error
+		last, err = releaseBlob(tx, video.VideoURL)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if last {
+		deleteAfterCommit(ctx, r.PG, r.Media, []string{video.VideoURL}, nil)
+	}
+	return nil
+}
+
+func (r *TourMediaRepo) ReorderTourImages(ctx context.Context, tourID uuid.UUID, ids []uuid.UUID) error {
+	return r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		return reorderMedia(tx, &entity.Image{}, tourID, ids)
+	})
+}
+
+func (r *TourMediaRepo) ReorderTourVideos(ctx context.Context, tourID uuid.UUID, ids []uuid.UUID) error {
+	return r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		return reorderMedia(tx, &entity.Video{}, tourID, ids)
+	})
+}
+
+// SetCoverImage makes the image the only cover of its tour.
+func (r *TourMediaRepo) SetCoverImage(ctx context.Context, tourID, imageID uuid.UUID) error {
+	return r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Image{}).Where("id = ? AND tour_id = ?", imageID, tourID).Update("is_cover", true)
+		if result.Error != nil {
+			return fmt.Errorf("set cover image: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("set cover image: %w", gorm.ErrRecordNotFound)
+		}
+		err := tx.Model(&entity.Image{}).
+			Where("tour_id = ? AND id <> ? AND is_cover", tourID, imageID).
+			Update("is_cover", false).Error
+		if err != nil {
+			return fmt.Errorf("unset cover image: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *TourMediaRepo) UpdateTourImage(ctx context.Context, tourID, imageID uuid.UUID, dto *entity.UpdateMediaDTO) error {
+	return updateMediaTexts(r.PG.DB(ctx), &entity.Image{}, tourID, imageID, dto)
+}
+
+func (r *TourMediaRepo) UpdateTourVideo(ctx context.Context, tourID, videoID uuid.UUID, dto *entity.UpdateMediaDTO) error {
+	return updateMediaTexts(r.PG.DB(ctx), &entity.Video{}, tourID, videoID, dto)
+}
+
+// CountTourMedia returns the number of images or videos of the tour. It reads from the primary,
+// the count guards the upload limit.
+func (r *TourMediaRepo) CountTourMedia(ctx context.Context, tourID uuid.UUID, kind media.Kind) (int64, error) {
+	var model interface{} = &entity.Image{}
+	if kind == media.KindVideo {
+		model = &entity.Video{}
+	}
+	var count int64
+	if err := postgres.Primary(r.PG.DB(ctx)).Model(model).Where("tour_id = ?", tourID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("count tour media: %w", err)
+	}
+	return count, nil
+}
+
+// ReferencedMediaKeys returns every store key referenced by a row, soft-deleted rows included.
+func (r *TourMediaRepo) ReferencedMediaKeys(ctx context.Context) (map[string]struct{}, error) {
+	var keys []string
+	err := r.PG.DB(ctx).Raw(`
+		SELECT image_url FROM images
+		UNION SELECT video_url FROM videos
+		UNION SELECT image_url FROM review_photos
+		UNION SELECT key FROM image_variants
+		UNION SELECT key FROM documents
+		UNION SELECT key FROM media_blobs WHERE ref_count > 0`).Scan(&keys).Error
+	if err != nil {
+		return nil, fmt.Errorf("get referenced media keys: %w", err)
+	}
+	referenced := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		referenced[key] = struct{}{}
+	}
+	return referenced, nil
+}
+
+// DeleteUnreferencedMedia deletes the objects of the media directories older than olderThan that are
+// not in referenced. Recent objects are kept, since an upload is stored before its row is committed.
+func (r *TourMediaRepo) DeleteUnreferencedMedia(ctx context.Context, referenced map[string]struct{}, olderThan time.Time) (int, error) {
+	lister, ok := r.Media.(media.Lister)
+	if !ok {
+		return 0, fmt.Errorf("delete unreferenced media: store cannot list objects")
+	}
+
+	var orphans []string
+	for _, dir := range _mediaDirs {
+		err := lister.List(ctx, dir+"/", func(object media.Object) error {
+			if _, ok := referenced[object.Key]; !ok && object.ModTime.Before(olderThan) {
+				orphans = append(orphans, object.Key)
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, fmt.Errorf("list media: %w", err)
+		}
+	}
+
+	deleted := 0
+	for _, key := range orphans {
+		if err := r.Media.Delete(ctx, key); err != nil {
+			return deleted, fmt.Errorf("delete orphaned media %s: %w", key, err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+func nextPosition(tx *gorm.DB, model interface{}, tourID uuid.UUID) (int, error) {
+	var position int
+	err := tx.Model(model).Where("tour_id = ?", tourID).Select("COALESCE(MAX(position) + 1, 0)").Scan(&position).Error
+	if err != nil {
+		return 0, fmt.Errorf("get next media position: %w", err)
+	}
+	return position, nil
+}
+
+// reorderMedia sets the positions of the tour media to their index in ids.
+func reorderMedia(tx *gorm.DB, model interface{}, tourID uuid.UUID, ids []uuid.UUID) error {
+	var existing []uuid.UUID
+	if err := tx.Model(model).Where("tour_id = ?", tourID).Pluck("id", &existing).Error; err != nil {
+		return fmt.Errorf("get tour media: %w", err)
+	}
+	if len(existing) != len(ids) {
+		return ErrMediaOrderMismatch
+	}
+	remaining := make(map[uuid.UUID]bool, len(existing))
+	for _, id := range existing {
+		remaining[id] = true
+	}
+	for _, id := range ids {
+		if !remaining[id] {
+			return ErrMediaOrderMismatch
+		}
+		delete(remaining, id)
+	}
+
+	for position, id := range ids {
+		if err := tx.Model(model).Where("id = ?", id).Update("position", position).Error; err != nil {
+			return fmt.Errorf("update media position: %w", err)
+		}
+	}
+	return nil
+}
+
+func updateMediaTexts(db *gorm.DB, model interface{}, tourID, id uuid.UUID, dto *entity.UpdateMediaDTO) error {
+	updates := map[string]interface{}{}
+	if dto.AltText != nil {
+		updates["alt_text"] = *dto.AltText
+	}
+	if dto.Caption != nil {
+		updates["caption"] = *dto.Caption
+	}
+
+	var count int64
+	if err := db.Model(model).Where("id = ? AND tour_id = ?", id, tourID).Count(&count).Error; err != nil {
+		return fmt.Errorf("get media: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("get media: %w", gorm.ErrRecordNotFound)
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+	if err := db.Model(model).Where("id = ?", id).Updates(updates).Error; err != nil {
+		return fmt.Errorf("update media: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/media"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestTourMediaRepo(t *testing.T) (*TourMediaRepo, *recordingStore) {
+	t.Helper()
+	pg := newTestDB(t, &entity.Image{}, &entity.ImageVariant{}, &entity.Video{}, &entity.ReviewPhoto{}, &entity.Document{}, &entity.MediaBlob{})
+	store := newRecordingStore(t)
+	return NewTourMediaRepo(pg, store), store
+}
+
+// addImages stores one image per content for the tour, the first one is the cover.
+func addImages(t *testing.T, r *TourMediaRepo, tourID uuid.UUID, contents ...string) []entity.Image {
+	t.Helper()
+	images := make([]entity.Image, 0, len(contents))
+	err := r.PG.Conn.Transaction(func(tx *gorm.DB) error {
+		for i, content := range contents {
+			key, err := storeBlob(tx, r.Media, _mediaImages, strings.NewReader(content), int64(len(content)), "image/jpeg")
+			if err != nil {
+				return err
+			}
+			image := entity.Image{TourID: tourID, ImageURL: key, Position: i, IsCover: i == 0}
+			if err := tx.Create(&image).Error; err != nil {
+				return err
+			}
+			images = append(images, image)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	return images
+}
+
+// tourImages returns the IDs of the tour images in order and the ID of the cover.
+func tourImages(t *testing.T, r *TourMediaRepo, tourID uuid.UUID) ([]uuid.UUID, uuid.UUID) {
+	t.Helper()
+	var images []entity.Image
+	require.NoError(t, orderMedia(r.PG.Conn.Where("tour_id = ?", tourID)).Find(&images).Error)
+	ids := make([]uuid.UUID, 0, len(images))
+	var cover uuid.UUID
+	for _, image := range images {
+		ids = append(ids, image.ID)
+		if image.IsCover {
+			require.Equal(t, uuid.Nil, cover, "a tour has a single cover")
+			cover = image.ID
+		}
+	}
+	return ids, cover
+}
+
+func TestReorderTourImages(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r, _ := newTestTourMediaRepo(t)
+	tourID := uuid.New()
+	images := addImages(t, r, tourID, "first", "second", "third")
+	other := addImages(t, r, uuid.New(), "other")
+	a, b, c := images[0].ID, images[1].ID, images[2].ID
+
+	tests := []struct {
+		name string
+		ids  []uuid.UUID
+		err  error
+	}{
+		{name: "new order", ids: []uuid.UUID{c, a, b}},
+		{name: "missing image", ids: []uuid.UUID{c, a}, err: ErrMediaOrderMismatch},
+		{name: "repeated image", ids: []uuid.UUID{c, a, a}, err: ErrMediaOrderMismatch},
+		{name: "image of another tour", ids: []uuid.UUID{c, a, other[0].ID}, err: ErrMediaOrderMismatch},
+	}
+	for _, tc := range tests {
+		require.ErrorIs(t, r.ReorderTourImages(ctx, tourID, tc.ids), tc.err, tc.name)
+
+		// A rejected order leaves the previous one in place.
+		ids, _ := tourImages(t, r, tourID)
+		require.Equal(t, []uuid.UUID{c, a, b}, ids, tc.name)
+	}
+}
+
+func TestSetCoverImage(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r, _ := newTestTourMediaRepo(t)
+	tourID := uuid.New()
+	images := addImages(t, r, tourID, "first", "second")
+	other := addImages(t, r, uuid.New(), "other")
+
+	require.NoError(t, r.SetCoverImage(ctx, tourID, images[1].ID))
+	_, cover := tourImages(t, r, tourID)
+	require.Equal(t, images[1].ID, cover)
+
+	require.ErrorIs(t, r.SetCoverImage(ctx, tourID, other[0].ID), gorm.ErrRecordNotFound)
+	_, cover = tourImages(t, r, tourID)
+	require.Equal(t, images[1].ID, cover, "an image of another tour is not made the cover")
+}
+
+func TestDeleteTourImage(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r, store := newTestTourMediaRepo(t)
+	tourID := uuid.New()
+	images := addImages(t, r, tourID, "cover", "shared", "shared")
+	variant := entity.ImageVariant{ImageID: images[0].ID, Kind: entity.ImageVariantThumbnail, Key: "images/" + images[0].ID.String() + "/thumbnail-320.webp"}
+	require.NoError(t, store.Put(ctx, variant.Key, strings.NewReader("webp"), 4, "image/webp"))
+	require.NoError(t, r.PG.Conn.Create(&variant).Error)
+
+	// Objects are kept while the deletion can still roll back with the transaction it takes part in.
+	errRollback := errors.New("rollback")
+	err := r.PG.Transaction(ctx, func(ctx context.Context) error {
+		require.NoError(t, r.DeleteTourImage(ctx, tourID, images[0].ID))
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+	_, err = store.Get(ctx, images[0].ImageURL)
+	require.NoError(t, err)
+	_, err = store.Get(ctx, variant.Key)
+	require.NoError(t, err)
+
+	// The cover goes with its variants and the next image becomes the cover.
+	require.NoError(t, r.DeleteTourImage(ctx, tourID, images[0].ID))
+	ids, cover := tourImages(t, r, tourID)
+	require.Equal(t, []uuid.UUID{images[1].ID, images[2].ID}, ids)
+	require.Equal(t, images[1].ID, cover)
+	_, err = store.Get(ctx, images[0].ImageURL)
+	require.ErrorIs(t, err, media.ErrNotFound)
+	_, err = store.Get(ctx, variant.Key)
+	require.ErrorIs(t, err, media.ErrNotFound)
+
+	// An object shared with another image is kept until its last image is deleted.
+	require.NoError(t, r.DeleteTourImage(ctx, tourID, images[1].ID))
+	_, err = store.Get(ctx, images[2].ImageURL)
+	require.NoError(t, err)
+	require.NoError(t, r.DeleteTourImage(ctx, tourID, images[2].ID))
+	_, err = store.Get(ctx, images[2].ImageURL)
+	require.ErrorIs(t, err, media.ErrNotFound)
+
+	require.ErrorIs(t, r.DeleteTourImage(ctx, tourID, images[2].ID), gorm.ErrRecordNotFound)
+}
+
+func TestDeleteUnreferencedMedia(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r, store := newTestTourMediaRepo(t)
+	db := r.PG.Conn.WithContext(ctx)
+	image := addImages(t, r, uuid.New(), "image")[0]
+
+	put := func(key string) string {
+		require.NoError(t, store.Put(ctx, key, strings.NewReader(key), int64(len(key)), "application/octet-stream"))
+		return key
+	}
+	video := entity.Video{TourID: uuid.New(), VideoURL: put("videos/ab/abcd")}
+	require.NoError(t, db.Create(&video).Error)
+	photo := entity.ReviewPhoto{ReviewID: uuid.New(), ImageURL: put("images/cd/cdef")}
+	require.NoError(t, db.Create(&photo).Error)
+	document := entity.Document{ProviderID: uuid.New(), Kind: "license", Key: put("private/documents/license.pdf")}
+	require.NoError(t, db.Create(&document).Error)
+	// Soft-deleted rows still reference their objects.
+	require.NoError(t, db.Delete(&document).Error)
+
+	orphans := []string{
+		put("images/ef/orphan"),
+		put("videos/ef/orphan"),
+		put("private/staging/" + uuid.NewString()),
+		put("private/documents/orphan.pdf"),
+	}
+	others := []string{put("backups/db.dump"), put("private/exports/report.csv"), put("imagesets/other")}
+
+	referenced, err := r.ReferencedMediaKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{
+		image.ImageURL: {},
+		video.VideoURL: {},
+		photo.ImageURL: {},
+		document.Key:   {},
+	}, referenced)
+
+	// Objects newer than the cutoff may belong to uploads whose rows are not committed yet.
+	deleted, err := r.DeleteUnreferencedMedia(ctx, referenced, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+
+	deleted, err = r.DeleteUnreferencedMedia(ctx, referenced, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, len(orphans), deleted)
+	want := append([]string{image.ImageURL, video.VideoURL, photo.ImageURL, document.Key}, others...)
+	require.ElementsMatch(t, want, store.keys(t), "objects outside the media directories are left alone")
+}
@@ -0,0 +1,97 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"tourism-backend/internal/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// _categoryDescendantsSQL selects the categories in ? and all of their descendants.
+// UNION rather than UNION ALL stops the recursion on a cycle.
+const _categoryDescendantsSQL = `WITH RECURSIVE descendants AS (
+	SELECT id FROM categories WHERE id IN ? AND deleted_at IS NULL
+	UNION
+	SELECT categories.id FROM categories JOIN descendants ON categories.parent_id = descendants.id
+	WHERE categories.deleted_at IS NULL
+) SELECT id FROM descendants`
+
+// _categoryTourCountsSQL counts the distinct tours of every category and its descendants.
+const _categoryTourCountsSQL = `WITH RECURSIVE subtree AS (
+	SELECT id AS root_id, id FROM categories WHERE deleted_at IS NULL
+	UNION
+	SELECT subtree.root_id, categories.id FROM categories JOIN subtree ON categories.parent_id = subtree.id
+	WHERE categories.deleted_at IS NULL
+)
+SELECT subtree.root_id AS category_id, COUNT(DISTINCT tour_categories.tour_id) AS tour_count
+FROM subtree
+JOIN tour_categories ON tour_categories.category_id = subtree.id
+JOIN tours ON tours.id = tour_categories.tour_id AND tours.deleted_at IS NULL
+GROUP BY subtree.root_id`
+
+// LockCategories returns the categories and locks them until the transaction in ctx ends.
+// Changes of the hierarchy take the lock before their checks, so a change waits for the one
+// before it to commit and checks against its result, including the categories it created.
+func (r *TourismRepo) LockCategories(ctx context.Context) ([]entity.Category, error) {
+	db := r.PG.DB(ctx)
+	if err := db.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "categories").Error; err != nil {
+		return nil, fmt.Errorf("lock categories: %w", err)
+	}
+	var categories []entity.Category
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).Order("sort_order ASC, name ASC").Find(&categories).Error
+	if err != nil {
+		return nil, fmt.Errorf("lock categories: %w", err)
+	}
+	return categories, nil
+}
+
+// CreateCategory -.
+func (r *TourismRepo) CreateCategory(ctx context.Context, category *entity.Category) error {
+	if err := r.PG.DB(ctx).Omit("Parent").Create(category).Error; err != nil {
+		return fmt.Errorf("create category: %w", err)
+	}
+	return nil
+}
+
+// UpdateCategory saves the editable fields of a category.
+func (r *TourismRepo) UpdateCategory(ctx context.Context, category *entity.Category) error {
+	err := r.PG.DB(ctx).Model(category).
+		Select("name", "slug", "icon", "parent_id", "sort_order").
+		Updates(category).Error
+	if err != nil {
+		return fmt.Errorf("update category: %w", err)
+	}
+	return nil
+}
+
+// DeleteCategory removes a category and its tour assignments.
+func (r *TourismRepo) DeleteCategory(ctx context.Context, categoryID uuid.UUID) error {
+	result := r.PG.DB(ctx).Unscoped().Delete(&entity.Category{}, "id = ?", categoryID)
+	if result.Error != nil {
+		return fmt.Errorf("delete category: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("delete category: %w", gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+// GetCategoryTourCounts returns the number of tours of every category including its descendants.
+// Categories without tours are missing from the result.
+func (r *TourismRepo) GetCategoryTourCounts(ctx context.Context) (map[uuid.UUID]int64, error) {
+	var rows []struct {
+		CategoryID uuid.UUID
+		TourCount  int64
+	}
+	if err := r.PG.DB(ctx).Raw(_categoryTourCountsSQL).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("get category tour counts: %w", err)
+	}
+	counts := make(map[uuid.UUID]int64, len(rows))
+	for _, row := range rows {
+		counts[row.CategoryID] = row.TourCount
+	}
+	return counts, nil
+}
@@ -0,0 +1,184 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/geo"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	_geoDefaultLimit = 100
+	_geoMaxLimit     = 500
+)
+
+// _haversineSQL computes the distance in kilometers from (?, ?) to the tour location.
+// Arguments: latitude, latitude, longitude.
+const _haversineSQL = `2 * 6371.0 * asin(least(1, sqrt(
+	power(sin(radians(tour_locations.latitude - ?) / 2), 2) +
+	cos(radians(?)) * cos(radians(tour_locations.latitude)) *
+	power(sin(radians(tour_locations.longitude - ?) / 2), 2)
+)))`
+
+type geoRow struct {
+	TourID     uuid.UUID
+	Latitude   float64
+	Longitude  float64
+	DistanceKm *float64
+}
+
+// SearchToursByLocation finds located tours around a point or inside a bounding box,
+// optionally restricted to tours that have an open event matching filter.Events.
+// With a point the results are ordered by distance, nearest first.
+func (r *TourismRepo) SearchToursByLocation(ctx context.Context, filter *entity.TourGeoSearchFilter) ([]*entity.TourGeoResult, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = _geoDefaultLimit
+	}
+	if limit > _geoMaxLimit {
+		limit = _geoMaxLimit
+	}
+
+	inner := r.PG.Conn.WithContext(ctx).Table("tour_locations").
+		Joins("JOIN tours ON tours.id = tour_locations.tour_id AND tours.deleted_at IS NULL").
+		Where("tour_locations.deleted_at IS NULL")
+
+	hasCenter := filter.Latitude != nil && filter.Longitude != nil
+	if hasCenter {
+		lat, lon := *filter.Latitude, *filter.Longitude
+		inner = inner.Select("tour_locations.tour_id, tour_locations.latitude, tour_locations.longitude, "+
+			_haversineSQL+" AS distance_km", lat, lat, lon)
+		if filter.RadiusKm > 0 {
+			inner = whereInBox(inner, geo.BoundingBoxAround(geo.Point{Latitude: lat, Longitude: lon}, filter.RadiusKm))
+		}
+	} else {
+		inner = inner.Select("tour_locations.tour_id, tour_locations.latitude, tour_locations.longitude, " +
+			"NULL::float AS distance_km, tours.created_at")
+	}
+
+	if filter.HasBounds {
+		inner = whereInBox(inner, geo.BoundingBox{
+			MinLatitude:  filter.MinLat,
+			MinLongitude: filter.MinLon,
+			MaxLatitude:  filter.MaxLat,
+			MaxLongitude: filter.MaxLon,
+		})
+	}
+
+	if !filter.Events.IsEmpty() {
+		events := r.PG.Conn.WithContext(ctx).Table("tour_events").Select("1").
+			Where("tour_events.tour_id = tour_locations.tour_id").
+			Where("tour_events.is_opened = ? AND tour_events.deleted_at IS NULL", true)
+		events = applyTourEventFilter(events, &filter.Events)
+		if len(filter.Events.CategoryIDs) > 0 {
+			events = events.Where("tour_events.tour_id IN (?)",
+				r.PG.Conn.WithContext(ctx).Table("tour_categories").Select("tour_id").Where("category_id IN ("+_categoryDescendantsSQL+")", filter.Events.CategoryIDs))
+		}
+		inner = inner.Where("EXISTS (?)", events)
+	}
+	inner = applyTourTextFilter(inner, &filter.Events)
+
+	query := r.PG.Conn.WithContext(ctx).Table("(?) AS geo", inner).Select("tour_id, latitude, longitude, distance_km")
+	if hasCenter {
+		if filter.RadiusKm > 0 {
+			query = query.Where("distance_km <= ?", filter.RadiusKm)
+		}
+		query = query.Order("distance_km ASC")
+	} else {
+		query = query.Order("created_at DESC")
+	}
+
+	var rows []geoRow
+	if err := query.Limit(limit).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("search tours by location: %w", err)
+	}
+	if len(rows) == 0 {
+		return []*entity.TourGeoResult{}, nil
+	}
+
+	tourIDs := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		tourIDs = append(tourIDs, row.TourID)
+	}
+	var tours []*entity.Tour
+	if err := r.PG.Conn.WithContext(ctx).Preload("TourImages", orderMedia).Preload("TourImages.Variants", orderVariants).Where("id IN ?", tourIDs).Find(&tours).Error; err != nil {
+		return nil, fmt.Errorf("search tours by location - load tours: %w", err)
+	}
+	toursByID := make(map[uuid.UUID]*entity.Tour, len(tours))
+	for _, tour := range tours {
+		resolveTourMedia(r.Media, tour)
+		toursByID[tour.ID] = tour
+	}
+
+	results := make([]*entity.TourGeoResult, 0, len(rows))
+	for _, row := range rows {
+		tour, ok := toursByID[row.TourID]
+		if !ok {
+			continue
+		}
+		results = append(results, &entity.TourGeoResult{
+			Tour:       tour,
+			Latitude:   row.Latitude,
+			Longitude:  row.Longitude,
+			DistanceKm: row.DistanceKm,
+		})
+	}
+	return results, nil
+}
+
+func whereInBox(query *gorm.DB, box geo.BoundingBox) *gorm.DB {
+	query = query.Where("tour_locations.latitude BETWEEN ? AND ?", box.MinLatitude, box.MaxLatitude)
+	if box.CrossesAntimeridian() {
+		return query.Where("(tour_locations.longitude >= ? OR tour_locations.longitude <= ?)", box.MinLongitude, box.MaxLongitude)
+	}
+	return query.Where("tour_locations.longitude BETWEEN ? AND ?", box.MinLongitude, box.MaxLongitude)
+}
+
+// applyTourEventFilter adds the date and budget conditions of filter on the tour_events table.
+func applyTourEventFilter(query *gorm.DB, filter *entity.TourEventFilter) *gorm.DB {
+	// Filter by start date
+	if !filter.StartDate.IsZero() {
+		query = query.Where("tour_events.date >= ?", filter.StartDate)
+	}
+
+	// Filter by end date
+	if !filter.EndDate.IsZero() {
+		query = query.Where("tour_events.date <= ?", filter.EndDate)
+	}
+
+	// Filter by budget
+	if filter.MinPrice > 0 {
+		query = query.Where("tour_events.price >= ?", filter.MinPrice)
+	}
+	if filter.MaxPrice > 0 {
+		query = query.Where("tour_events.price <= ?", filter.MaxPrice)
+	}
+	return query
+}
+
+// _likeEscaper escapes the wildcards of a LIKE pattern.
+var _likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// applyTourTextFilter adds the text query of filter on the tours table. The query matches
+// the original texts, the provider locales and the cached machine translations in filter.Languages.
+func applyTourTextFilter(query *gorm.DB, filter *entity.TourEventFilter) *gorm.DB {
+	if filter.Query == "" {
+		return query
+	}
+	pattern := "%" + _likeEscaper.Replace(filter.Query) + "%"
+	if len(filter.Languages) == 0 {
+		return query.Where("(tours.description ILIKE @q OR tours.route ILIKE @q)", sql.Named("q", pattern))
+	}
+	return query.Where(`(tours.description ILIKE @q OR tours.route ILIKE @q
+		OR EXISTS (SELECT 1 FROM tour_locales WHERE tour_locales.tour_id = tours.id AND tour_locales.language IN @languages
+			AND (tour_locales.description ILIKE @q OR tour_locales.route ILIKE @q))
+		OR EXISTS (SELECT 1 FROM tour_translations WHERE tour_translations.resource = 'tour' AND tour_translations.resource_id = tours.id
+			AND tour_translations.language IN @languages
+			AND tour_translations.text ILIKE @q))`,
+		sql.Named("q", pattern), sql.Named("languages", filter.Languages))
+}
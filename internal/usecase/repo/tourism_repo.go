@@ -1,15 +1,14 @@
 package repo
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
-	"io"
 	"mime/multipart"
-	"os"
-	"path/filepath"
 	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/media"
 	"tourism-backend/pkg/postgres"
 )
 
@@ -17,85 +16,80 @@ const _defaultEntityCap = 64
 
 // TourismRepo -.
 type TourismRepo struct {
-	PG *postgres.Postgres
+	PG    *postgres.Postgres
+	Media media.Store
 }
 
 // New -.
-func NewTourismRepo(pg *postgres.Postgres) *TourismRepo {
-	return &TourismRepo{pg}
+func NewTourismRepo(pg *postgres.Postgres, store media.Store) *TourismRepo {
+	return &TourismRepo{pg, store}
 }
 
-func (r *TourismRepo) GetFilteredTourEvents(filter *entity.TourEventFilter) ([]*entity.TourEvent, error) {
+func (r *TourismRepo) GetFilteredTourEvents(ctx context.Context, filter *entity.TourEventFilter) ([]*entity.TourEvent, error) {
 	var tourEvents []*entity.TourEvent
 
-	query := r.PG.Conn.
+	query := r.PG.Conn.WithContext(ctx).
 		Joins("JOIN tours ON tours.id = tour_events.tour_id").
 		Joins("JOIN tour_categories ON tour_categories.tour_id = tours.id").
 		Where("tour_events.is_opened = ?", true) // Fetch only open tours
 
-	// Filter by categories
+	// Filter by categories, a category matches its descendants too
 	if len(filter.CategoryIDs) > 0 {
-		query = query.Where("tour_categories.category_id IN ?", filter.CategoryIDs)
+		query = query.Where("tour_categories.category_id IN ("+_categoryDescendantsSQL+")", filter.CategoryIDs)
 	}
 
-	// Filter by start date
-	if !filter.StartDate.IsZero() {
-		query = query.Where("tour_events.date >= ?", filter.StartDate)
-	}
-
-	// Filter by end date
-	if !filter.EndDate.IsZero() {
-		query = query.Where("tour_events.date <= ?", filter.EndDate)
-	}
-
-	// Filter by budget
-	if filter.MinPrice > 0 {
-		query = query.Where("tour_events.price >= ?", filter.MinPrice)
-	}
-	if filter.MaxPrice > 0 {
-		query = query.Where("tour_events.price <= ?", filter.MaxPrice)
-	}
-	fmt.Println(filter.MaxPrice)
+	// Filter by date and budget
+	query = applyTourEventFilter(query, filter)
+	query = applyTourTextFilter(query, filter)
 
 	// Execute the query
-	err := query.Preload("Tour").Find(&tourEvents).Error
+	// A tour can be in several matching categories
+	err := query.Distinct("tour_events.*").Preload("Tour").Find(&tourEvents).Error
 	return tourEvents, err
 }
 
-func (r *TourismRepo) GetTourLocationByID(tourLocationID uuid.UUID) (*entity.TourLocation, error) {
+func (r *TourismRepo) GetTourLocationByID(ctx context.Context, tourLocationID uuid.UUID) (*entity.TourLocation, error) {
 	var tourLocation entity.TourLocation
-	err := r.PG.Conn.Where("tour_id = ?", tourLocationID).First(&tourLocation).Error
+	err := r.PG.Conn.WithContext(ctx).Where("tour_id = ?", tourLocationID).First(&tourLocation).Error
 	if err != nil {
 		return nil, fmt.Errorf("get tour location by id: %w", err)
 	}
 	return &tourLocation, nil
 }
 
-func (r *TourismRepo) CreateTourLocation(tourLocation *entity.CreateTourLocationDTO) (*entity.TourLocation, error) {
+func (r *TourismRepo) CreateTourLocation(ctx context.Context, tourLocation *entity.CreateTourLocationDTO) (*entity.TourLocation, error) {
 	tourLocationEntity := &entity.TourLocation{
 		TourID:    tourLocation.TourID,
 		Longitude: tourLocation.Longitude,
 		Latitude:  tourLocation.Latitude,
 	}
 
-	err := r.PG.Conn.Create(&tourLocationEntity).Error
+	err := r.PG.DB(ctx).Create(&tourLocationEntity).Error
 	if err != nil {
-		return nil, fmt.Errorf("Tour Location not Found")
+		return nil, fmt.Errorf("create tour location: %w", err)
 	}
 	return tourLocationEntity, nil
 
 }
 
-func (r *TourismRepo) GetAllCategories() ([]entity.Category, error) {
+func (r *TourismRepo) GetAllCategories(ctx context.Context) ([]entity.Category, error) {
 	var categories []entity.Category
-	err := r.PG.Conn.Find(&categories).Error
+	err := r.PG.Conn.WithContext(ctx).Order("sort_order ASC, name ASC").Find(&categories).Error
 	if err != nil {
 		return nil, fmt.Errorf("GetAllCategories: %w", err)
 	}
 	return categories, nil
 }
 
-func (r *TourismRepo) CreateTourCategory(tourCategory *entity.CreateTourCategoryDTO) (*entity.TourCategory, error) {
+func (r *TourismRepo) GetCategoryByID(ctx context.Context, categoryID uuid.UUID) (*entity.Category, error) {
+	var category entity.Category
+	if err := r.PG.Conn.WithContext(ctx).First(&category, "id = ?", categoryID).Error; err != nil {
+		return nil, fmt.Errorf("get category by id: %w", err)
+	}
+	return &category, nil
+}
+
+func (r *TourismRepo) CreateTourCategory(ctx context.Context, tourCategory *entity.CreateTourCategoryDTO) (*entity.TourCategory, error) {
 
 	category := &entity.TourCategory{
 		TourID:     tourCategory.TourID,
@@ -104,36 +98,39 @@ func (r *TourismRepo) CreateTourCategory(tourCategory *entity.CreateTourCategory
 
 	// Check if the record already exists
 	existingCategory := &entity.TourCategory{}
-	err := r.PG.Conn.Where("tour_id = ? AND category_id = ?", category.TourID, category.CategoryID).First(existingCategory).Error
+	err := r.PG.DB(ctx).Where("tour_id = ? AND category_id = ?", category.TourID, category.CategoryID).First(existingCategory).Error
 	if err == nil {
-		return existingCategory, fmt.Errorf("tour Category already exists")
+		return nil, fmt.Errorf("create tour category: %w", gorm.ErrDuplicatedKey)
 	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, fmt.Errorf("Tour Category not Found")
+		return nil, fmt.Errorf("create tour category: %w", err)
 	}
 
-	err = r.PG.Conn.Create(&category).Error
+	err = r.PG.DB(ctx).Create(&category).Error
 	if err != nil {
-		return nil, fmt.Errorf("Tour Category or Tour not Found")
+		return nil, fmt.Errorf("create tour category: %w", err)
 	}
 	return category, nil
 }
 
-func (r *TourismRepo) CreatePurchase(purchase *entity.Purchase) (*entity.Purchase, error) {
-	err := r.PG.Conn.Transaction(func(tx *gorm.DB) error {
+func (r *TourismRepo) CreatePurchase(ctx context.Context, purchase *entity.Purchase) (*entity.Purchase, error) {
+	err := r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
 		// Check tour event record in the database
 		var tourEvent entity.TourEvent
 
 		// Fetch the tour event to verify conditions before updating
-		if err := tx.Where("id = ? AND is_opened = ? AND amount_of_places > 0",
-			purchase.TourEventID, true).First(&tourEvent).Error; err != nil {
-			return fmt.Errorf("tour event not found or closed: %w", err)
+		if err := tx.Where("id = ?", purchase.TourEventID).First(&tourEvent).Error; err != nil {
+			return fmt.Errorf("tour event not found: %w", err)
 		}
 
-		// Decrease the available places count
-		if err := tx.Model(&entity.TourEvent{}).
-			Where("id = ?", purchase.TourEventID).
-			UpdateColumn("amount_of_places", gorm.Expr("amount_of_places - 1")).Error; err != nil {
-			return fmt.Errorf("failed to update amount_of_places: %w", err)
+		// Decrease the available places count, a concurrent purchase may have taken the last one
+		result := tx.Model(&entity.TourEvent{}).
+			Where("id = ? AND is_opened = ? AND amount_of_places > 0", purchase.TourEventID, true).
+			UpdateColumn("amount_of_places", gorm.Expr("amount_of_places - 1"))
+		if result.Error != nil {
+			return fmt.Errorf("failed to update amount_of_places: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return entity.ErrTourEventSoldOut
 		}
 
 		// Create the purchase record in the database
@@ -149,7 +146,7 @@ func (r *TourismRepo) CreatePurchase(purchase *entity.Purchase) (*entity.Purchas
 	}
 
 	// Reload purchase with related data
-	err = r.PG.Conn.Preload("User").Preload("TourEvent.Tour").
+	err = postgres.Primary(r.PG.DB(ctx)).Preload("User").Preload("TourEvent.Tour.TourCategories.Category").
 		First(purchase, "id = ?", purchase.ID).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to preload purchase data: %w", err)
@@ -158,50 +155,92 @@ func (r *TourismRepo) CreatePurchase(purchase *entity.Purchase) (*entity.Purchas
 	return purchase, nil
 }
 
-func (r *TourismRepo) PayTourEvent(purchase *entity.Purchase) error {
+func (r *TourismRepo) PayTourEvent(ctx context.Context, purchase *entity.Purchase) error {
 
-	result := r.PG.Conn.Model(&entity.Purchase{}).
-		Where("id = ? AND status = ?", purchase.ID, "Processing").
-		Update("status", "Paid")
+	result := r.PG.DB(ctx).Model(&entity.Purchase{}).
+		Where("id = ? AND status = ?", purchase.ID, entity.PurchaseStatusProcessing).
+		Update("status", entity.PurchaseStatusPaid)
 
 	if result.Error != nil {
 		return result.Error
 	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("purchase %s is not in %s status", purchase.ID, entity.PurchaseStatusProcessing)
+	}
 
 	return nil
 }
 
-func (r *TourismRepo) CheckTourOwner(tourID uuid.UUID, userID uuid.UUID) bool {
+// FailPurchase moves a processing purchase to failed and gives its seat back to the tour event.
+func (r *TourismRepo) FailPurchase(ctx context.Context, purchase *entity.Purchase) error {
+	return r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Purchase{}).
+			Where("id = ? AND status = ?", purchase.ID, entity.PurchaseStatusProcessing).
+			Update("status", entity.PurchaseStatusFailed)
+		if result.Error != nil {
+			return fmt.Errorf("fail purchase: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("purchase %s is not in %s status", purchase.ID, entity.PurchaseStatusProcessing)
+		}
+
+		err := tx.Model(&entity.TourEvent{}).
+			Where("id = ?", purchase.TourEventID).
+			UpdateColumn("amount_of_places", gorm.Expr("amount_of_places + 1")).Error
+		if err != nil {
+			return fmt.Errorf("failed to release the place: %w", err)
+		}
+		return nil
+	})
+}
+
+// CheckTourOwner reports false for unknown tours. Query errors are logged by the GORM logger.
+func (r *TourismRepo) CheckTourOwner(ctx context.Context, tourID uuid.UUID, userID uuid.UUID) bool {
 	var tourOwnerID string
-	err := r.PG.Conn.Table("tours").
+	err := postgres.Primary(r.PG.Conn.WithContext(ctx)).Table("tours").
 		Select("owner_id").
 		Where("id = ?", tourID).
 		Scan(&tourOwnerID).Error
-
-	if err != nil {
-		fmt.Println("Error checking tour owner:", err)
+	if err != nil || tourOwnerID == "" {
 		return false
 	}
 
 	// Convert string to UUID
 	ownerUUID, err := uuid.Parse(tourOwnerID)
 	if err != nil {
-		fmt.Println("Error parsing owner UUID:", err)
 		return false
 	}
 
 	return ownerUUID == userID
 }
 
-func (r *TourismRepo) CreateTourEvent(tourEvent *entity.TourEvent) (*entity.TourEvent, error) {
-	err := r.PG.Conn.Transaction(func(tx *gorm.DB) error {
+// TourExists reads from the primary, so a tour created just before is found while replicas lag.
+func (r *TourismRepo) TourExists(ctx context.Context, tourID uuid.UUID) (bool, error) {
+	var count int64
+	if err := postgres.Primary(r.PG.Conn.WithContext(ctx)).Model(&entity.Tour{}).Where("id = ?", tourID).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("tour exists: %w", err)
+	}
+	return count > 0, nil
+}
+
+// TourEventExists reads from the primary like TourExists.
+func (r *TourismRepo) TourEventExists(ctx context.Context, tourEventID uuid.UUID) (bool, error) {
+	var count int64
+	if err := postgres.Primary(r.PG.Conn.WithContext(ctx)).Model(&entity.TourEvent{}).Where("id = ?", tourEventID).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("tour event exists: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *TourismRepo) CreateTourEvent(ctx context.Context, tourEvent *entity.TourEvent) (*entity.TourEvent, error) {
+	err := r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
 		// Create the tour record in the database
 		var count int64
 		if err := tx.Model(&entity.Tour{}).Where("id = ?", tourEvent.TourID).Count(&count).Error; err != nil {
 			return err
 		}
 		if count == 0 {
-			return fmt.Errorf("tour with id %s does not exist", tourEvent.TourID)
+			return fmt.Errorf("tour with id %s does not exist: %w", tourEvent.TourID, gorm.ErrRecordNotFound)
 		}
 
 		// Create the tour event record in the database
@@ -211,7 +250,10 @@ func (r *TourismRepo) CreateTourEvent(tourEvent *entity.TourEvent) (*entity.Tour
 
 		return nil
 	})
-	err = r.PG.Conn.Preload("Tour").First(tourEvent, "id = ?", tourEvent.ID).Error
+	if err != nil {
+		return nil, fmt.Errorf("create tour event: %w", err)
+	}
+	err = postgres.Primary(r.PG.DB(ctx)).Preload("Tour").First(tourEvent, "id = ?", tourEvent.ID).Error
 
 	if err != nil {
 		return nil, err
@@ -220,60 +262,61 @@ func (r *TourismRepo) CreateTourEvent(tourEvent *entity.TourEvent) (*entity.Tour
 	return tourEvent, nil
 }
 
-func (r *TourismRepo) GetTourByID(tourID string) (*entity.Tour, error) {
+func (r *TourismRepo) GetTourByID(ctx context.Context, tourID string) (*entity.Tour, error) {
 	var tour entity.Tour
 
-	err := r.PG.Conn.Preload("TourImages").Preload("TourVideos").First(&tour, "id = ?", tourID).Error
+	err := preloadTourMedia(r.PG.Conn.WithContext(ctx)).First(&tour, "id = ?", tourID).Error
 	if err != nil {
 		return nil, err
 	}
+	resolveTourMedia(r.Media, &tour)
 
 	return &tour, nil
 }
 
-func (r *TourismRepo) GetTours() ([]entity.Tour, error) {
+func (r *TourismRepo) GetTours(ctx context.Context) ([]entity.Tour, error) {
 	var tours []entity.Tour
-	err := r.PG.Conn.Preload("TourImages").Preload("TourVideos").Find(&tours).Error
+	err := preloadTourMedia(r.PG.Conn.WithContext(ctx)).Find(&tours).Error
 	if err != nil {
 		return nil, err
 	}
+	for i := range tours {
+		resolveTourMedia(r.Media, &tours[i])
+	}
 	return tours, nil
 }
 
-func (r *TourismRepo) CreateTour(tour *entity.Tour, imageFiles []*multipart.FileHeader, videoFiles []*multipart.FileHeader) (*entity.Tour, error) {
-	err := r.PG.Conn.Transaction(func(tx *gorm.DB) error {
+func (r *TourismRepo) CreateTour(ctx context.Context, tour *entity.Tour, imageFiles []*multipart.FileHeader, videoFiles []*multipart.FileHeader) (*entity.Tour, error) {
+	err := r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
 		// Create the tour record in the database
 		if err := tx.Create(&tour).Error; err != nil {
 			return err
 		}
 
-		// Save images inside the transaction
-		for _, file := range imageFiles {
-			filename := uuid.New().String() + filepath.Ext(file.Filename)
-			filespath := "./uploads/images/" + filename
-			// Save the image file
-			if err := r.saveFile(file, filespath); err != nil {
+		// Save images inside the transaction, the first one is the cover
+		for i, file := range imageFiles {
+			key, err := putMedia(tx, r.Media, _mediaImages, file)
+			if err != nil {
 				return err
 			}
-			image := &entity.Image{ImageURL: filespath, TourID: tour.ID}
+			image := &entity.Image{ImageURL: key, TourID: tour.ID, Position: i, IsCover: i == 0}
 			if err := tx.Create(&image).Error; err != nil {
 				return err
 			}
+			tour.TourImages = append(tour.TourImages, *image)
 		}
 
 		// Save videos inside the transaction
-		for _, file := range videoFiles {
-			filename := uuid.New().String() + filepath.Ext(file.Filename)
-			filespath := "./uploads/videos/" + filename
-			// Save the video file
-			if err := r.saveFile(file, filespath); err != nil {
+		for i, file := range videoFiles {
+			key, err := putMedia(tx, r.Media, _mediaVideos, file)
+			if err != nil {
 				return err
 			}
-			// Append the video record to the list
-			video := &entity.Video{VideoURL: filespath, TourID: tour.ID}
+			video := &entity.Video{VideoURL: key, TourID: tour.ID, Position: i}
 			if err := tx.Create(&video).Error; err != nil {
 				return err
 			}
+			tour.TourVideos = append(tour.TourVideos, *video)
 		}
 		return nil
 	})
@@ -281,34 +324,11 @@ func (r *TourismRepo) CreateTour(tour *entity.Tour, imageFiles []*multipart.File
 	if err != nil {
 		return nil, err
 	}
+	resolveTourMedia(r.Media, tour)
 
 	return tour, nil
 }
 
-// Helper function to save the file to the disk
-func (r *TourismRepo) saveFile(file *multipart.FileHeader, path string) error {
-	// Open the file
-	src, err := file.Open()
-	if err != nil {
-		return err
-	}
-	defer src.Close()
-
-	// Create the destination file
-	dst, err := os.Create(path)
-	if err != nil {
-		return err
-	}
-	defer dst.Close()
-
-	// Copy the contents of the source file to the destination file
-	if _, err := io.Copy(dst, src); err != nil {
-		return err
-	}
-
-	return nil
-}
-
 //
 //// GetHistory -.
 //func (r *TranslationRepo) GetHistory(ctx context.Context) ([]entity.Translation, error) {
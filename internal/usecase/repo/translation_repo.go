@@ -0,0 +1,112 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/postgres"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TranslationRepo struct {
+	PG *postgres.Postgres
+}
+
+// New -.
+func NewTranslationRepo(pg *postgres.Postgres) *TranslationRepo {
+	return &TranslationRepo{pg}
+}
+
+// GetTranslations returns the cached machine translations into language of the given resources.
+func (r *TranslationRepo) GetTranslations(ctx context.Context, language string, resourceIDs []uuid.UUID) ([]entity.TourTranslation, error) {
+	var translations []entity.TourTranslation
+	err := r.PG.DB(ctx).Where("language = ? AND resource_id IN ?", language, resourceIDs).Find(&translations).Error
+	if err != nil {
+		return nil, fmt.Errorf("get translations: %w", err)
+	}
+	return translations, nil
+}
+
+// SaveTranslations caches machine translations, replacing the ones made from an earlier source text.
+func (r *TranslationRepo) SaveTranslations(ctx context.Context, translations []entity.TourTranslation) error {
+	if len(translations) == 0 {
+		return nil
+	}
+	err := r.PG.DB(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "resource"}, {Name: "resource_id"}, {Name: "field"}, {Name: "language"}},
+		DoUpdates: clause.AssignmentColumns([]string{"text", "source_hash", "updated_at"}),
+	}).Create(&translations).Error
+	if err != nil {
+		return fmt.Errorf("save translations: %w", err)
+	}
+	return nil
+}
+
+// GetTourLocales returns the locales of the tours in languages, or in every language when languages is empty.
+func (r *TranslationRepo) GetTourLocales(ctx context.Context, tourIDs []uuid.UUID, languages []string) ([]entity.TourLocale, error) {
+	query := r.PG.DB(ctx).Where("tour_id IN ?", tourIDs)
+	if len(languages) > 0 {
+		query = query.Where("language IN ?", languages)
+	}
+	var locales []entity.TourLocale
+	if err := query.Order("language ASC").Find(&locales).Error; err != nil {
+		return nil, fmt.Errorf("get tour locales: %w", err)
+	}
+	return locales, nil
+}
+
+// SaveTourLocale creates or replaces the locale of a tour.
+func (r *TranslationRepo) SaveTourLocale(ctx context.Context, locale *entity.TourLocale) error {
+	err := r.PG.DB(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tour_id"}, {Name: "language"}},
+		DoUpdates: clause.AssignmentColumns([]string{"description", "route", "source_hash", "updated_at"}),
+	}).Omit("Tour").Create(locale).Error
+	if err != nil {
+		return fmt.Errorf("save tour locale: %w", err)
+	}
+	return nil
+}
+
+// DeleteTourLocale removes the locale of a tour together with its cached machine translations.
+func (r *TranslationRepo) DeleteTourLocale(ctx context.Context, tourID uuid.UUID, language string) error {
+	return r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("tour_id = ? AND language = ?", tourID, language).Delete(&entity.TourLocale{})
+		if result.Error != nil {
+			return fmt.Errorf("delete tour locale: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("delete tour locale: %w", gorm.ErrRecordNotFound)
+		}
+		err := tx.Where("resource = ? AND resource_id = ? AND language = ?", entity.TranslationResourceTour, tourID, language).
+			Delete(&entity.TourTranslation{}).Error
+		if err != nil {
+			return fmt.Errorf("delete tour translations: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetCategoryLocales returns the locales of the categories in languages.
+func (r *TranslationRepo) GetCategoryLocales(ctx context.Context, categoryIDs []uuid.UUID, languages []string) ([]entity.CategoryLocale, error) {
+	var locales []entity.CategoryLocale
+	err := r.PG.DB(ctx).Where("category_id IN ? AND language IN ?", categoryIDs, languages).Find(&locales).Error
+	if err != nil {
+		return nil, fmt.Errorf("get category locales: %w", err)
+	}
+	return locales, nil
+}
+
+// SaveCategoryLocale creates or replaces the name of a category in one language.
+func (r *TranslationRepo) SaveCategoryLocale(ctx context.Context, locale *entity.CategoryLocale) error {
+	err := r.PG.DB(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "category_id"}, {Name: "language"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "updated_at"}),
+	}).Omit("Category").Create(locale).Error
+	if err != nil {
+		return fmt.Errorf("save category locale: %w", err)
+	}
+	return nil
+}
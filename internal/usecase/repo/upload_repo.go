@@ -0,0 +1,114 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/media"
+	"tourism-backend/pkg/postgres"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type UploadRepo struct {
+	PG    *postgres.Postgres
+	Media media.Store
+}
+
+// New -.
+func NewUploadRepo(pg *postgres.Postgres, store media.Store) *UploadRepo {
+	return &UploadRepo{pg, store}
+}
+
+func (r *UploadRepo) CreateUpload(ctx context.Context, upload *entity.Upload) error {
+	if err := r.PG.DB(ctx).Create(upload).Error; err != nil {
+		return fmt.Errorf("create upload: %w", err)
+	}
+	return nil
+}
+
+func (r *UploadRepo) GetUpload(ctx context.Context, id uuid.UUID) (*entity.Upload, error) {
+	var upload entity.Upload
+	if err := r.PG.DB(ctx).Where("id = ?", id).First(&upload).Error; err != nil {
+		return nil, fmt.Errorf("get upload: %w", err)
+	}
+	return &upload, nil
+}
+
+// LockUpload locks the upload until the transaction of ctx ends, so one request at a time
+// writes to it across instances. It reports false, without waiting, when another request holds the lock.
+// It must be called in a transaction.
+func (r *UploadRepo) LockUpload(ctx context.Context, id uuid.UUID) (bool, error) {
+	var locked bool
+	if err := r.PG.DB(ctx).Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", "upload:"+id.String()).Scan(&locked).Error; err != nil {
+		return false, fmt.Errorf("lock upload: %w", err)
+	}
+	return locked, nil
+}
+
+// SetUploadOffset records received bytes. It fails with gorm.ErrRecordNotFound when
+// the stored offset is no longer from, so a stale writer cannot move the offset back.
+func (r *UploadRepo) SetUploadOffset(ctx context.Context, id uuid.UUID, from, to int64) error {
+	result := r.PG.DB(ctx).Model(&entity.Upload{}).Where("id = ? AND \"offset\" = ?", id, from).Update("offset", to)
+	if result.Error != nil {
+		return fmt.Errorf("set upload offset: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("set upload offset: %w", gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+// CompleteUpload stores the assembled file and appends it to the tour videos.
+func (r *UploadRepo) CompleteUpload(ctx context.Context, upload *entity.Upload, src io.Reader, contentType string) (*entity.Video, error) {
+	var video entity.Video
+	err := r.PG.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		key, err := storeBlob(tx, r.Media, _mediaVideos, src, upload.Length, contentType)
+		if err != nil {
+			return err
+		}
+		position, err := nextPosition(tx, &entity.Video{}, upload.TourID)
+		if err != nil {
+			return err
+		}
+		video = entity.Video{
+			TourID:   upload.TourID,
+			VideoURL: key,
+			Position: position,
+			AltText:  upload.AltText,
+			Caption:  upload.Caption,
+		}
+		if err := tx.Create(&video).Error; err != nil {
+			return fmt.Errorf("create video: %w", err)
+		}
+		if err := tx.Model(upload).Update("video_id", video.ID).Error; err != nil {
+			return fmt.Errorf("complete upload: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	video.URL = r.Media.URL(video.VideoURL)
+	return &video, nil
+}
+
+func (r *UploadRepo) DeleteUpload(ctx context.Context, id uuid.UUID) error {
+	if err := r.PG.DB(ctx).Where("id = ?", id).Delete(&entity.Upload{}).Error; err != nil {
+		return fmt.Errorf("delete upload: %w", err)
+	}
+	return nil
+}
+
+// GetExpiredUploadIDs returns up to limit uploads that expired before t.
+func (r *UploadRepo) GetExpiredUploadIDs(ctx context.Context, t time.Time, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.PG.DB(ctx).Model(&entity.Upload{}).Where("expires_at < ?", t).Order("expires_at").Limit(limit).Pluck("id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("get expired uploads: %w", err)
+	}
+	return ids, nil
+}
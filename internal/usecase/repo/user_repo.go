@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"context"
 	"fmt"
 	"tourism-backend/internal/entity"
 	"tourism-backend/pkg/postgres"
@@ -15,18 +16,18 @@ func NewUserRepo(pg *postgres.Postgres) *UserRepo {
 	return &UserRepo{pg}
 }
 
-func (u *UserRepo) LoginUser(user *entity.LoginUserDTO) (*entity.User, error) {
+func (u *UserRepo) LoginUser(ctx context.Context, user *entity.LoginUserDTO) (*entity.User, error) {
 
 	var userFromDB entity.User
 
-	if err := u.PG.Conn.Where("username = ?", user.Username).First(&userFromDB).Error; err != nil {
-		return nil, fmt.Errorf("Username Not Found: %v", err)
+	if err := u.PG.Conn.WithContext(ctx).Where("username = ?", user.Username).First(&userFromDB).Error; err != nil {
+		return nil, fmt.Errorf("Username Not Found: %w", err)
 	}
 	return &userFromDB, nil
 }
 
-func (u *UserRepo) RegisterUser(user *entity.User) (*entity.User, error) {
-	err := u.PG.Conn.Create(user).Error
+func (u *UserRepo) RegisterUser(ctx context.Context, user *entity.User) (*entity.User, error) {
+	err := u.PG.Conn.WithContext(ctx).Create(user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,203 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/media"
+	"tourism-backend/pkg/postgres"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WishlistRepo struct {
+	PG    *postgres.Postgres
+	Media media.Store
+}
+
+// New -.
+func NewWishlistRepo(pg *postgres.Postgres, store media.Store) *WishlistRepo {
+	return &WishlistRepo{pg, store}
+}
+
+// AddFavorite is idempotent: when the tour is already a favorite, the stored favorite is returned.
+func (r *WishlistRepo) AddFavorite(ctx context.Context, userID, tourID uuid.UUID) (*entity.Favorite, error) {
+	favorite := &entity.Favorite{UserID: userID, TourID: tourID}
+	result := r.PG.Conn.WithContext(ctx).Omit(clause.Associations).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(favorite)
+	if result.Error != nil {
+		return nil, fmt.Errorf("add favorite: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return favorite, nil
+	}
+
+	// The insert was skipped, so the ID and timestamps filled in are not the stored ones.
+	favorite = &entity.Favorite{}
+	err := postgres.Primary(r.PG.Conn.WithContext(ctx)).
+		Where("user_id = ? AND tour_id = ?", userID, tourID).
+		First(favorite).Error
+	if err != nil {
+		return nil, fmt.Errorf("get favorite: %w", err)
+	}
+	return favorite, nil
+}
+
+func (r *WishlistRepo) RemoveFavorite(ctx context.Context, userID, tourID uuid.UUID) error {
+	err := r.PG.Conn.WithContext(ctx).Unscoped().
+		Where("user_id = ? AND tour_id = ?", userID, tourID).
+		Delete(&entity.Favorite{}).Error
+	if err != nil {
+		return fmt.Errorf("remove favorite: %w", err)
+	}
+	return nil
+}
+
+func (r *WishlistRepo) GetFavorites(ctx context.Context, userID uuid.UUID) ([]*entity.Favorite, error) {
+	favorites := make([]*entity.Favorite, 0, _defaultEntityCap)
+	err := r.PG.Conn.WithContext(ctx).Preload("Tour.TourImages", orderMedia).Preload("Tour.TourImages.Variants", orderVariants).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&favorites).Error
+	if err != nil {
+		return nil, fmt.Errorf("get favorites: %w", err)
+	}
+	for _, favorite := range favorites {
+		resolveTourMedia(r.Media, &favorite.Tour)
+	}
+	return favorites, nil
+}
+
+// CreateSavedSearch stores the search and marks the events it already matches as seen,
+// so that only events published afterwards produce notifications.
+func (r *WishlistRepo) CreateSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch, current []*entity.TourEvent) (*entity.SavedSearch, error) {
+	err := r.PG.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		savedSearch.LastRunAt = &now
+		if err := tx.Create(savedSearch).Error; err != nil {
+			return fmt.Errorf("create saved search: %w", err)
+		}
+		for _, tourEvent := range current {
+			if err := upsertMatch(tx, savedSearch.ID, tourEvent); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return savedSearch, nil
+}
+
+func (r *WishlistRepo) GetSavedSearches(ctx context.Context, userID uuid.UUID) ([]*entity.SavedSearch, error) {
+	savedSearches := make([]*entity.SavedSearch, 0, _defaultEntityCap)
+	err := r.PG.Conn.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&savedSearches).Error
+	if err != nil {
+		return nil, fmt.Errorf("get saved searches: %w", err)
+	}
+	return savedSearches, nil
+}
+
+func (r *WishlistRepo) GetAllSavedSearches(ctx context.Context) ([]*entity.SavedSearch, error) {
+	savedSearches := make([]*entity.SavedSearch, 0, _defaultEntityCap)
+	err := r.PG.Conn.WithContext(ctx).Order("created_at ASC").Find(&savedSearches).Error
+	if err != nil {
+		return nil, fmt.Errorf("get all saved searches: %w", err)
+	}
+	return savedSearches, nil
+}
+
+func (r *WishlistRepo) DeleteSavedSearch(ctx context.Context, userID, savedSearchID uuid.UUID) error {
+	err := r.PG.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Where("id = ? AND user_id = ?", savedSearchID, userID).Delete(&entity.SavedSearch{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Where("saved_search_id = ?", savedSearchID).Delete(&entity.SavedSearchMatch{}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("delete saved search: %w", err)
+	}
+	return nil
+}
+
+func (r *WishlistRepo) GetMatches(ctx context.Context, savedSearchID uuid.UUID) (map[uuid.UUID]float64, error) {
+	var matches []entity.SavedSearchMatch
+	err := r.PG.Conn.WithContext(ctx).Where("saved_search_id = ?", savedSearchID).Find(&matches).Error
+	if err != nil {
+		return nil, fmt.Errorf("get saved search matches: %w", err)
+	}
+	prices := make(map[uuid.UUID]float64, len(matches))
+	for _, match := range matches {
+		prices[match.TourEventID] = match.Price
+	}
+	return prices, nil
+}
+
+// RecordRun stores notifications for a saved search run together with the updated matches,
+// so a notification is never sent twice for the same event and price.
+func (r *WishlistRepo) RecordRun(ctx context.Context, savedSearch *entity.SavedSearch, seen []*entity.TourEvent, notifications []*entity.Notification) error {
+	return r.PG.Conn.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, tourEvent := range seen {
+			if err := upsertMatch(tx, savedSearch.ID, tourEvent); err != nil {
+				return err
+			}
+		}
+		if len(notifications) > 0 {
+			if err := tx.Create(&notifications).Error; err != nil {
+				return fmt.Errorf("create notifications: %w", err)
+			}
+		}
+		if err := tx.Model(savedSearch).Update("last_run_at", time.Now()).Error; err != nil {
+			return fmt.Errorf("update saved search: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *WishlistRepo) GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool) ([]*entity.Notification, error) {
+	notifications := make([]*entity.Notification, 0, _defaultEntityCap)
+	query := r.PG.Conn.WithContext(ctx).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	err := query.Order("created_at DESC").Limit(100).Find(&notifications).Error
+	if err != nil {
+		return nil, fmt.Errorf("get notifications: %w", err)
+	}
+	return notifications, nil
+}
+
+func (r *WishlistRepo) MarkNotificationRead(ctx context.Context, userID, notificationID uuid.UUID) error {
+	result := r.PG.Conn.WithContext(ctx).Model(&entity.Notification{}).
+		Where("id = ? AND user_id = ? AND read_at IS NULL", notificationID, userID).
+		Update("read_at", time.Now())
+	if result.Error != nil {
+		return fmt.Errorf("mark notification read: %w", result.Error)
+	}
+	return nil
+}
+
+func upsertMatch(tx *gorm.DB, savedSearchID uuid.UUID, tourEvent *entity.TourEvent) error {
+	match := entity.SavedSearchMatch{
+		SavedSearchID: savedSearchID,
+		TourEventID:   tourEvent.ID,
+		Price:         tourEvent.Price,
+	}
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "saved_search_id"}, {Name: "tour_event_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"price", "updated_at"}),
+	}).Create(&match).Error
+	if err != nil {
+		return fmt.Errorf("upsert saved search match: %w", err)
+	}
+	return nil
+}
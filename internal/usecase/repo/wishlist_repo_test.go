@@ -0,0 +1,105 @@
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase"
+	"tourism-backend/internal/usecase/repo"
+	"tourism-backend/pkg/media"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// searchResults returns events as the result of every tour event search.
+type searchResults struct {
+	usecase.TourismRepo
+
+	events []*entity.TourEvent
+}
+
+func (s *searchResults) GetFilteredTourEvents(context.Context, *entity.TourEventFilter) ([]*entity.TourEvent, error) {
+	return s.events, nil
+}
+
+func newWishlistRepo(t *testing.T) *repo.WishlistRepo {
+	t.Helper()
+	pg := repo.NewTestDB(t, &entity.Tour{}, &entity.Favorite{}, &entity.SavedSearch{}, &entity.SavedSearchMatch{}, &entity.Notification{})
+	return repo.NewWishlistRepo(pg, media.NewLocalStore(t.TempDir(), "/uploads"))
+}
+
+func TestAddFavoriteTwice(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	wishlist := newWishlistRepo(t)
+	userID, tourID := uuid.New(), uuid.New()
+
+	first, err := wishlist.AddFavorite(ctx, userID, tourID)
+	require.NoError(t, err)
+	second, err := wishlist.AddFavorite(ctx, userID, tourID)
+	require.NoError(t, err)
+	require.Equal(t, first.ID, second.ID, "adding a favorite again returns the stored one")
+
+	favorites, err := wishlist.GetFavorites(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, favorites, 1)
+	require.Equal(t, first.ID, favorites[0].ID)
+}
+
+func TestRunSavedSearches(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	wishlist := newWishlistRepo(t)
+	results := &searchResults{}
+	wishlistUseCase := usecase.NewWishlistUseCase(wishlist, results)
+
+	userID := uuid.New()
+	_, err := wishlistUseCase.CreateSavedSearch(ctx, userID, &entity.CreateSavedSearchDTO{Name: "Walks"})
+	require.NoError(t, err)
+	tourEvent := &entity.TourEvent{ID: uuid.New(), Place: "Old town", Date: time.Now().Add(24 * time.Hour)}
+
+	steps := []struct {
+		name   string
+		events []*entity.TourEvent
+		price  float64
+		want   *entity.Notification
+	}{
+		{name: "new event", events: []*entity.TourEvent{tourEvent}, price: 100,
+			want: &entity.Notification{Kind: entity.NotificationKindNewEvent, Price: 100}},
+		{name: "re-run without changes", events: []*entity.TourEvent{tourEvent}, price: 100},
+		{name: "price drop", events: []*entity.TourEvent{tourEvent}, price: 80,
+			want: &entity.Notification{Kind: entity.NotificationKindPriceDrop, Price: 80, OldPrice: 100}},
+		{name: "price rise is recorded silently", events: []*entity.TourEvent{tourEvent}, price: 90},
+		{name: "drop from the raised price", events: []*entity.TourEvent{tourEvent}, price: 85,
+			want: &entity.Notification{Kind: entity.NotificationKindPriceDrop, Price: 85, OldPrice: 90}},
+		{name: "event no longer matches", price: 85},
+	}
+	notified := 0
+	for _, step := range steps {
+		tourEvent.Price = step.price
+		results.events = step.events
+
+		created, err := wishlistUseCase.RunSavedSearches(ctx)
+		require.NoError(t, err, step.name)
+		notifications, err := wishlist.GetNotifications(ctx, userID, false)
+		require.NoError(t, err, step.name)
+
+		if step.want == nil {
+			require.Zero(t, created, step.name)
+			require.Len(t, notifications, notified, step.name)
+			continue
+		}
+		notified++
+		require.Equal(t, 1, created, step.name)
+		require.Len(t, notifications, notified, step.name)
+		latest := notifications[0]
+		require.Equal(t, step.want.Kind, latest.Kind, step.name)
+		require.Equal(t, step.want.Price, latest.Price, step.name)
+		require.Equal(t, step.want.OldPrice, latest.OldPrice, step.name)
+		require.Equal(t, tourEvent.ID, latest.TourEventID, step.name)
+	}
+}
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/media"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrReviewNotAllowed is returned when the user has no paid purchase of a past event of the tour.
+	ErrReviewNotAllowed = entity.NewForbiddenError("review_not_allowed", "only travelers with a completed paid booking can review this tour")
+	// ErrNotTourOwner is returned when a provider acts on a tour they do not own.
+	ErrNotTourOwner = entity.NewForbiddenError("not_tour_owner", "you are not owner of this tour")
+	// ErrReviewNotFound -.
+	ErrReviewNotFound = entity.NewNotFoundError("review_not_found", "review not found")
+	// ErrReviewExists is returned when the user has already reviewed the tour.
+	ErrReviewExists = entity.NewConflictError("review_exists", "you can review a tour only once")
+)
+
+type ReviewUseCase struct {
+	repo ReviewRepo
+	// tx commits the moderation changes together with their audit events.
+	tx      Transactor
+	tourism TourismRepo
+	audit   *AuditUseCase
+	uploads *media.Validator
+}
+
+// NewReviewUseCase -.
+func NewReviewUseCase(r ReviewRepo, tx Transactor, tourism TourismRepo, audit *AuditUseCase, uploads *media.Validator) *ReviewUseCase {
+	return &ReviewUseCase{
+		repo:    r,
+		tx:      tx,
+		tourism: tourism,
+		audit:   audit,
+		uploads: uploads,
+	}
+}
+
+func (r *ReviewUseCase) CreateReview(ctx context.Context, review *entity.Review, photoFiles []*multipart.FileHeader) (*entity.Review, error) {
+	allowed, err := r.repo.HasCompletedPurchase(ctx, review.TourID, review.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("create review: %w", err)
+	}
+	if !allowed {
+		return nil, ErrReviewNotAllowed
+	}
+	if fileErrors := r.uploads.Validate("photos", media.KindImage, photoFiles); len(fileErrors) > 0 {
+		return nil, &media.ValidationError{Files: fileErrors}
+	}
+
+	review.Status = entity.ReviewStatusPublished
+	createdReview, err := r.repo.CreateReview(ctx, review, photoFiles)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return nil, ErrReviewExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create review: %w", err)
+	}
+	return createdReview, nil
+}
+
+func (r *ReviewUseCase) GetTourReviews(ctx context.Context, tourID uuid.UUID, filter *entity.ReviewFilter) ([]*entity.Review, error) {
+	return r.repo.GetTourReviews(ctx, tourID, filter)
+}
+
+func (r *ReviewUseCase) ReplyToReview(ctx context.Context, providerID, reviewID uuid.UUID, reply string) (*entity.Review, error) {
+	review, err := r.getReview(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if !r.tourism.CheckTourOwner(ctx, review.TourID, providerID) {
+		return nil, ErrNotTourOwner
+	}
+	return r.repo.ReplyToReview(ctx, reviewID, reply)
+}
+
+func (r *ReviewUseCase) ModerateReview(ctx context.Context, actor entity.AuditActor, reviewID uuid.UUID, status string) (*entity.Review, error) {
+	review, err := r.getReview(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	before := map[string]interface{}{"status": review.Status}
+
+	err = r.tx.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		review, err = r.repo.SetReviewStatus(ctx, reviewID, status)
+		if err != nil {
+			return err
+		}
+		return r.audit.Record(ctx, actor, entity.AuditActionReviewModerate, "review", reviewID.String(), before, map[string]interface{}{"status": review.Status})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("moderate review: %w", err)
+	}
+	return review, nil
+}
+
+func (r *ReviewUseCase) DeleteReview(ctx context.Context, actor entity.AuditActor, reviewID uuid.UUID) error {
+	review, err := r.getReview(ctx, reviewID)
+	if err != nil {
+		return err
+	}
+	before := map[string]interface{}{"status": review.Status, "rating": review.Rating, "tour_id": review.TourID.String()}
+	err = r.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := r.repo.DeleteReview(ctx, reviewID); err != nil {
+			return err
+		}
+		return r.audit.Record(ctx, actor, entity.AuditActionReviewDelete, "review", reviewID.String(), before, nil)
+	})
+	if err != nil {
+		return fmt.Errorf("delete review: %w", err)
+	}
+	return nil
+}
+
+func (r *ReviewUseCase) getReview(ctx context.Context, reviewID uuid.UUID) (*entity.Review, error) {
+	review, err := r.repo.GetReviewByID(ctx, reviewID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrReviewNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
@@ -0,0 +1,92 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"mime/multipart"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase"
+	"tourism-backend/internal/usecase/repo/inmemory"
+	"tourism-backend/pkg/media"
+)
+
+func reviews(t *testing.T) (*usecase.ReviewUseCase, *MockReviewRepo) {
+	t.Helper()
+
+	repo := NewMockReviewRepo(gomock.NewController(t))
+	uploads := media.NewValidator(media.Limits{MaxCount: 10}, media.Limits{}, media.Limits{})
+	reviews := usecase.NewReviewUseCase(repo, inmemory.Transactor{}, inmemory.NewTourismRepo(), usecase.NewAuditUseCase(inmemory.NewAuditRepo()), uploads)
+
+	return reviews, repo
+}
+
+func TestCreateReview(t *testing.T) {
+	t.Parallel()
+
+	reviews, repo := reviews(t)
+	review := &entity.Review{ID: uuid.New(), TourID: uuid.New(), UserID: uuid.New(), Rating: 5}
+
+	tests := []test{
+		{
+			name: "without a completed purchase",
+			mock: func() {
+				repo.EXPECT().HasCompletedPurchase(gomock.Any(), review.TourID, review.UserID).Return(false, nil)
+			},
+			err: usecase.ErrReviewNotAllowed,
+		},
+		{
+			name: "purchase check fails",
+			mock: func() {
+				repo.EXPECT().HasCompletedPurchase(gomock.Any(), review.TourID, review.UserID).Return(false, errInternalServErr)
+			},
+			err: errInternalServErr,
+		},
+		{
+			name: "second review of the tour",
+			mock: func() {
+				repo.EXPECT().HasCompletedPurchase(gomock.Any(), review.TourID, review.UserID).Return(true, nil)
+				repo.EXPECT().CreateReview(gomock.Any(), review, nil).Return(nil, fmt.Errorf("create review: %w", gorm.ErrDuplicatedKey))
+			},
+			err: usecase.ErrReviewExists,
+		},
+		{
+			name: "published review",
+			mock: func() {
+				repo.EXPECT().HasCompletedPurchase(gomock.Any(), review.TourID, review.UserID).Return(true, nil)
+				repo.EXPECT().CreateReview(gomock.Any(), review, nil).DoAndReturn(
+					func(_ context.Context, review *entity.Review, _ []*multipart.FileHeader) (*entity.Review, error) {
+						require.Equal(t, entity.ReviewStatusPublished, review.Status)
+						return review, nil
+					})
+			},
+			res: review,
+		},
+	}
+	for _, tc := range tests {
+		tc.mock()
+
+		res, err := reviews.CreateReview(context.Background(), review, nil)
+		require.ErrorIs(t, err, tc.err, tc.name)
+		if tc.err == nil {
+			require.Equal(t, tc.res, res, tc.name)
+		}
+	}
+
+	// The number of photos is bounded by the configured image limit.
+	photos := make([]*multipart.FileHeader, 11)
+	for i := range photos {
+		photos[i] = &multipart.FileHeader{Filename: fmt.Sprintf("photo-%d.jpg", i), Size: 1}
+	}
+	repo.EXPECT().HasCompletedPurchase(gomock.Any(), review.TourID, review.UserID).Return(true, nil)
+	_, err := reviews.CreateReview(context.Background(), review, photos)
+	var validationErr *media.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Equal(t, media.CodeTooMany, validationErr.Files[0].Code)
+}
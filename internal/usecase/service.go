@@ -1,15 +1,31 @@
 package usecase
 
 type Service struct {
-	UserUseCase  *UserUseCase
-	TourUseCase  *TourismUseCase
-	AdminUseCase *AdminUseCase
+	UserUseCase        *UserUseCase
+	TourUseCase        *TourismUseCase
+	AdminUseCase       *AdminUseCase
+	ReviewUseCase      *ReviewUseCase
+	WishlistUseCase    *WishlistUseCase
+	ItineraryUseCase   *ItineraryUseCase
+	TourMediaUseCase   *TourMediaUseCase
+	UploadUseCase      *UploadUseCase
+	DocumentUseCase    *DocumentUseCase
+	TranslationUseCase *TranslationUseCase
+	CategoryUseCase    *CategoryUseCase
 }
 
-func NewService(user *UserUseCase, tour *TourismUseCase, admin *AdminUseCase) *Service {
+func NewService(user *UserUseCase, tour *TourismUseCase, admin *AdminUseCase, review *ReviewUseCase, wishlist *WishlistUseCase, itinerary *ItineraryUseCase, tourMedia *TourMediaUseCase, upload *UploadUseCase, document *DocumentUseCase, translation *TranslationUseCase, category *CategoryUseCase) *Service {
 	return &Service{
-		UserUseCase:  user,
-		TourUseCase:  tour,
-		AdminUseCase: admin,
+		UserUseCase:        user,
+		TourUseCase:        tour,
+		AdminUseCase:       admin,
+		ReviewUseCase:      review,
+		WishlistUseCase:    wishlist,
+		ItineraryUseCase:   itinerary,
+		TourMediaUseCase:   tourMedia,
+		UploadUseCase:      upload,
+		DocumentUseCase:    document,
+		TranslationUseCase: translation,
+		CategoryUseCase:    category,
 	}
 }
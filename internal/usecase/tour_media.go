@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"time"
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase/repo"
+	"tourism-backend/pkg/media"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrMediaNotFound is returned when the image or video does not belong to the tour.
+	ErrMediaNotFound = entity.NewNotFoundError("media_not_found", "media not found")
+	// ErrInvalidMediaOrder -.
+	ErrInvalidMediaOrder = entity.NewValidationError("invalid_media_order", "order must list every media item of the tour exactly once")
+	// ErrNoMediaFiles is returned when an add request carries no files.
+	ErrNoMediaFiles = entity.NewValidationError("no_media_files", "at least one file is required")
+)
+
+type TourMediaUseCase struct {
+	repo TourMediaRepo
+	// tx commits the changes together with their audit events.
+	tx      Transactor
+	tourism TourismRepo
+	audit   *AuditUseCase
+	uploads *media.Validator
+}
+
+// NewTourMediaUseCase -.
+func NewTourMediaUseCase(r TourMediaRepo, tx Transactor, tourism TourismRepo, audit *AuditUseCase, uploads *media.Validator) *TourMediaUseCase {
+	return &TourMediaUseCase{
+		repo:    r,
+		tx:      tx,
+		tourism: tourism,
+		audit:   audit,
+		uploads: uploads,
+	}
+}
+
+func (m *TourMediaUseCase) AddTourImages(ctx context.Context, actor entity.AuditActor, tourID uuid.UUID, files []*multipart.FileHeader, meta []entity.MediaMeta) ([]entity.Image, error) {
+	if err := m.checkUpload(ctx, actor, tourID, "images", media.KindImage, files); err != nil {
+		return nil, err
+	}
+	var images []entity.Image
+	err := m.tx.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		images, err = m.repo.AddTourImages(ctx, tourID, files, padMeta(meta, len(files)))
+		if err != nil {
+			return fmt.Errorf("add tour images: %w", err)
+		}
+
+		keys := make([]string, 0, len(images))
+		for _, image := range images {
+			keys = append(keys, image.ImageURL)
+		}
+		return m.audit.Record(ctx, actor, entity.AuditActionTourMediaAdd, "tour", tourID.String(), nil, map[string]interface{}{
+			"images": keys,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+func (m *TourMediaUseCase) AddTourVideos(ctx context.Context, actor entity.AuditActor, tourID uuid.UUID, files []*multipart.FileHeader, meta []entity.MediaMeta) ([]entity.Video, error) {
+	if err := m.checkUpload(ctx, actor, tourID, "videos", media.KindVideo, files); err != nil {
+		return nil, err
+	}
+	var videos []entity.Video
+	err := m.tx.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		videos, err = m.repo.AddTourVideos(ctx, tourID, files, padMeta(meta, len(files)))
+		if err != nil {
+			return fmt.Errorf("add tour videos: %w", err)
+		}
+
+		keys := make([]string, 0, len(videos))
+		for _, video := range videos {
+			keys = append(keys, video.VideoURL)
+		}
+		return m.audit.Record(ctx, actor, entity.AuditActionTourMediaAdd, "tour", tourID.String(), nil, map[string]interface{}{
+			"videos": keys,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return videos, nil
+}
+
+func (m *TourMediaUseCase) DeleteTourImage(ctx context.Context, actor entity.AuditActor, tourID, imageID uuid.UUID) error {
+	if !m.tourism.CheckTourOwner(ctx, tourID, actor.UserID) {
+		return ErrNotTourOwner
+	}
+	return m.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := m.repo.DeleteTourImage(ctx, tourID, imageID); err != nil {
+			return mediaError(err)
+		}
+		return m.audit.Record(ctx, actor, entity.AuditActionTourMediaDelete, "tour", tourID.String(),
+			map[string]interface{}{"image_id": imageID}, nil)
+	})
+}
+
+func (m *TourMediaUseCase) DeleteTourVideo(ctx context.Context, actor entity.AuditActor, tourID, videoID uuid.UUID) error {
+	if !m.tourism.CheckTourOwner(ctx, tourID, actor.UserID) {
+		return ErrNotTourOwner
+	}
+	return m.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := m.repo.DeleteTourVideo(ctx, tourID, videoID); err != nil {
+			return mediaError(err)
+		}
+		return m.audit.Record(ctx, actor, entity.AuditActionTourMediaDelete, "tour", tourID.String(),
+			map[string]interface{}{"video_id": videoID}, nil)
+	})
+}
+
+func (m *TourMediaUseCase) ReorderTourImages(ctx context.Context, providerID, tourID uuid.UUID, ids []uuid.UUID) (*entity.Tour, error) {
+	if !m.tourism.CheckTourOwner(ctx, tourID, providerID) {
+		return nil, ErrNotTourOwner
+	}
+	if err := m.repo.ReorderTourImages(ctx, tourID, ids); err != nil {
+		return nil, mediaError(err)
+	}
+	return m.tourism.GetTourByID(ctx, tourID.String())
+}
+
+func (m *TourMediaUseCase) ReorderTourVideos(ctx context.Context, providerID, tourID uuid.UUID, ids []uuid.UUID) (*entity.Tour, error) {
+	if !m.tourism.CheckTourOwner(ctx, tourID, providerID) {
+		return nil, ErrNotTourOwner
+	}
+	if err := m.repo.ReorderTourVideos(ctx, tourID, ids); err != nil {
+		return nil, mediaError(err)
+	}
+	return m.tourism.GetTourByID(ctx, tourID.String())
+}
+
+func (m *TourMediaUseCase) SetCoverImage(ctx context.Context, providerID, tourID, imageID uuid.UUID) (*entity.Tour, error) {
+	if !m.tourism.CheckTourOwner(ctx, tourID, providerID) {
+		return nil, ErrNotTourOwner
+	}
+	if err := m.repo.SetCoverImage(ctx, tourID, imageID); err != nil {
+		return nil, mediaError(err)
+	}
+	return m.tourism.GetTourByID(ctx, tourID.String())
+}
+
+func (m *TourMediaUseCase) UpdateTourImage(ctx context.Context, providerID, tourID, imageID uuid.UUID, dto *entity.UpdateMediaDTO) (*entity.Tour, error) {
+	if !m.tourism.CheckTourOwner(ctx, tourID, providerID) {
+		return nil, ErrNotTourOwner
+	}
+	if err := m.repo.UpdateTourImage(ctx, tourID, imageID, dto); err != nil {
+		return nil, mediaError(err)
+	}
+	return m.tourism.GetTourByID(ctx, tourID.String())
+}
+
+func (m *TourMediaUseCase) UpdateTourVideo(ctx context.Context, providerID, tourID, videoID uuid.UUID, dto *entity.UpdateMediaDTO) (*entity.Tour, error) {
+	if !m.tourism.CheckTourOwner(ctx, tourID, providerID) {
+		return nil, ErrNotTourOwner
+	}
+	if err := m.repo.UpdateTourVideo(ctx, tourID, videoID, dto); err != nil {
+		return nil, mediaError(err)
+	}
+	return m.tourism.GetTourByID(ctx, tourID.String())
+}
+
+// CollectOrphanedMedia deletes stored files that no row references and that are older than gracePeriod.
+func (m *TourMediaUseCase) CollectOrphanedMedia(ctx context.Context, gracePeriod time.Duration) (int, error) {
+	// Take the cutoff before reading the references, so files uploaded meanwhile are never candidates.
+	cutoff := time.Now().Add(-gracePeriod)
+	referenced, err := m.repo.ReferencedMediaKeys(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return m.repo.DeleteUnreferencedMedia(ctx, referenced, cutoff)
+}
+
+func (m *TourMediaUseCase) checkUpload(ctx context.Context, actor entity.AuditActor, tourID uuid.UUID, field string, kind media.Kind, files []*multipart.FileHeader) error {
+	if !m.tourism.CheckTourOwner(ctx, tourID, actor.UserID) {
+		return ErrNotTourOwner
+	}
+	if len(files) == 0 {
+		return ErrNoMediaFiles
+	}
+	if fileErrors := m.uploads.Validate(field, kind, files); len(fileErrors) > 0 {
+		return &media.ValidationError{Files: fileErrors}
+	}
+
+	// The count limit applies to the whole tour, not only to one request.
+	existing, err := m.repo.CountTourMedia(ctx, tourID, kind)
+	if err != nil {
+		return err
+	}
+	if limit := m.uploads.Limits(kind).MaxCount; limit > 0 && int(existing)+len(files) > limit {
+		return &media.ValidationError{Files: []media.FileError{{
+			Field:   field,
+			Code:    media.CodeTooMany,
+			Message: fmt.Sprintf("a tour can have at most %d %ss", limit, kind),
+		}}}
+	}
+	return nil
+}
+
+// padMeta returns meta with one entry per file, files without texts get empty ones.
+func padMeta(meta []entity.MediaMeta, n int) []entity.MediaMeta {
+	padded := make([]entity.MediaMeta, n)
+	copy(padded, meta)
+	return padded
+}
+
+func mediaError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrMediaNotFound
+	case errors.Is(err, repo.ErrMediaOrderMismatch):
+		return ErrInvalidMediaOrder
+	}
+	return err
+}
@@ -1,89 +1,384 @@
 package usecase
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"mime/multipart"
 	"tourism-backend/internal/entity"
-	"tourism-backend/internal/usecase/repo"
+	"tourism-backend/pkg/geo"
+	"tourism-backend/pkg/media"
+	"tourism-backend/pkg/metrics"
+
+	"gorm.io/gorm"
+)
+
+// _tourFormFieldsSize bounds the text fields and part headers of a tour creation request.
+const _tourFormFieldsSize = 1 << 20
+
+var (
+	// ErrTourEventNotFound -.
+	ErrTourEventNotFound = entity.NewNotFoundError("tour_event_not_found", "tour event not found")
+	// ErrTourLocationNotFound -.
+	ErrTourLocationNotFound = entity.NewNotFoundError("tour_location_not_found", "tour location not found")
+	// ErrTourCategoryExists is returned when the tour is already in the category.
+	ErrTourCategoryExists = entity.NewConflictError("tour_category_exists", "tour is already in this category")
 )
 
 // TranslationUseCase -.
 type TourismUseCase struct {
-	repo *repo.TourismRepo
+	repo TourismRepo
+	// tx commits the changes together with their audit events.
+	tx           Transactor
+	audit        *AuditUseCase
+	uploads      *media.Validator
+	translations *TranslationUseCase
 }
 
 // NewTourismUseCase -.
-func NewTourismUseCase(r *repo.TourismRepo) *TourismUseCase {
+func NewTourismUseCase(r TourismRepo, tx Transactor, audit *AuditUseCase, uploads *media.Validator, translations *TranslationUseCase) *TourismUseCase {
 	return &TourismUseCase{
-		repo: r,
+		repo:         r,
+		tx:           tx,
+		audit:        audit,
+		uploads:      uploads,
+		translations: translations,
 	}
 }
 
-func (r *TourismUseCase) GetFilteredTourEvents(filter *entity.TourEventFilter) ([]*entity.TourEvent, error) {
-	return r.repo.GetFilteredTourEvents(filter)
+// Languages returns the supported content languages, the default first.
+func (r *TourismUseCase) Languages() []string {
+	return r.translations.Languages()
 }
 
-func (r *TourismUseCase) GetTourLocationByID(tourLocationID uuid.UUID) (*entity.TourLocation, error) {
-	return r.repo.GetTourLocationByID(tourLocationID)
+// MaxCreateTourSize returns the largest tour creation request the upload limits accept:
+// the most images and videos of the largest size plus room for the text fields.
+// It is 0 when the size or the number of images or videos is unlimited.
+func (r *TourismUseCase) MaxCreateTourSize() int64 {
+	images := r.uploads.Limits(media.KindImage)
+	videos := r.uploads.Limits(media.KindVideo)
+	if images.MaxSize <= 0 || images.MaxCount <= 0 || videos.MaxSize <= 0 || videos.MaxCount <= 0 {
+		return 0
+	}
+	return int64(images.MaxCount)*images.MaxSize + int64(videos.MaxCount)*videos.MaxSize + _tourFormFieldsSize
 }
 
-func (r *TourismUseCase) CreateTourLocation(tourLocation *entity.CreateTourLocationDTO) (*entity.TourLocation, error) {
-	return r.repo.CreateTourLocation(tourLocation)
+// GetFilteredTourEvents returns the open events matching filter with tour texts in filter.Language.
+// The text query matches the tours in filter.Language and its fallbacks.
+// On ErrTranslationUnavailable the events are returned untranslated.
+func (r *TourismUseCase) GetFilteredTourEvents(ctx context.Context, filter *entity.TourEventFilter) ([]*entity.TourEvent, error) {
+	if err := r.translations.CheckLanguage(filter.Language); err != nil {
+		return nil, err
+	}
+	filter.Languages = r.translations.Chain(filter.Language)
+	tourEvents, err := r.repo.GetFilteredTourEvents(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	tours := make([]*entity.Tour, 0, len(tourEvents))
+	for _, tourEvent := range tourEvents {
+		tours = append(tours, &tourEvent.Tour)
+	}
+	err = r.translations.LocalizeTours(ctx, tours, filter.Language)
+	if err != nil && !errors.Is(err, ErrTranslationUnavailable) {
+		return nil, err
+	}
+	return tourEvents, err
 }
 
-func (r *TourismUseCase) CreateTourCategory(tourCategory *entity.CreateTourCategoryDTO) (*entity.TourCategory, error) {
-	return r.repo.CreateTourCategory(tourCategory)
+// SearchToursByLocation returns located tours as a GeoJSON FeatureCollection of points.
+// Texts are translated into lang, on ErrTranslationUnavailable the collection is returned untranslated.
+func (r *TourismUseCase) SearchToursByLocation(ctx context.Context, filter *entity.TourGeoSearchFilter, lang string) (*geo.FeatureCollection, error) {
+	filter.Events.Languages = r.translations.Chain(lang)
+	results, err := r.repo.SearchToursByLocation(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	tours := make([]*entity.Tour, 0, len(results))
+	for _, result := range results {
+		tours = append(tours, result.Tour)
+	}
+	localizeErr := r.translations.LocalizeTours(ctx, tours, lang)
+	if localizeErr != nil && !errors.Is(localizeErr, ErrTranslationUnavailable) {
+		return nil, localizeErr
+	}
+
+	features := make([]*geo.Feature, 0, len(results))
+	for _, result := range results {
+		properties := map[string]interface{}{
+			"tour_id":        result.Tour.ID,
+			"description":    result.Tour.Description,
+			"route":          result.Tour.Route,
+			"average_rating": result.Tour.AverageRating,
+			"review_count":   result.Tour.ReviewCount,
+		}
+		if result.DistanceKm != nil {
+			properties["distance_km"] = *result.DistanceKm
+		}
+		if cover := result.Tour.CoverImage(); cover != nil {
+			properties["image_url"] = cover.URL
+		}
+		features = append(features, &geo.Feature{
+			Type:       geo.TypeFeature,
+			ID:         result.Tour.ID.String(),
+			Geometry:   geo.NewPointGeometry(geo.Point{Latitude: result.Latitude, Longitude: result.Longitude}),
+			Properties: properties,
+		})
+	}
+	return geo.NewFeatureCollection(features...), localizeErr
+}
+
+func (r *TourismUseCase) GetTourLocationByID(ctx context.Context, tourLocationID uuid.UUID) (*entity.TourLocation, error) {
+	tourLocation, err := r.repo.GetTourLocationByID(ctx, tourLocationID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTourLocationNotFound
+	}
+	return tourLocation, err
 }
 
-func (r *TourismUseCase) GetAllCategories() ([]entity.Category, error) {
-	categories, err := r.repo.GetAllCategories()
+func (r *TourismUseCase) CreateTourLocation(ctx context.Context, actor entity.AuditActor, tourLocation *entity.CreateTourLocationDTO) (*entity.TourLocation, error) {
+	var createdTourLocation *entity.TourLocation
+	err := r.tx.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		createdTourLocation, err = r.repo.CreateTourLocation(ctx, tourLocation)
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return ErrTourNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return r.audit.Record(ctx, actor, entity.AuditActionTourLocationCreate, "tour_location", createdTourLocation.ID.String(), nil, tourLocation)
+	})
 	if err != nil {
 		return nil, err
 	}
-	return categories, nil
+	return createdTourLocation, nil
 }
 
-func (t *TourismUseCase) CreatePurchase(purchase *entity.Purchase) (*entity.Purchase, error) {
-	return t.repo.CreatePurchase(purchase)
+func (r *TourismUseCase) CreateTourCategory(ctx context.Context, actor entity.AuditActor, tourCategory *entity.CreateTourCategoryDTO) (*entity.TourCategory, error) {
+	var createdTourCategory *entity.TourCategory
+	err := r.tx.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		createdTourCategory, err = r.repo.CreateTourCategory(ctx, tourCategory)
+		switch {
+		case errors.Is(err, gorm.ErrDuplicatedKey):
+			return ErrTourCategoryExists
+		case errors.Is(err, gorm.ErrForeignKeyViolated):
+			// The tour was checked by its owner, so the category is missing
+			return ErrCategoryNotFound
+		case err != nil:
+			return err
+		}
+		// The link has no ID of its own, it is identified by the tour and the category.
+		resourceID := tourCategory.TourID.String() + ":" + tourCategory.CategoryID.String()
+		return r.audit.Record(ctx, actor, entity.AuditActionTourCategoryCreate, "tour_category", resourceID, nil, tourCategory)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return createdTourCategory, nil
 }
 
-func (t *TourismUseCase) PayTourEvent(purchase *entity.Purchase) error {
-	return t.repo.PayTourEvent(purchase)
+// GetAllCategories returns the categories with names in lang.
+// On ErrTranslationUnavailable the categories are returned untranslated.
+func (r *TourismUseCase) GetAllCategories(ctx context.Context, lang string) ([]entity.Category, error) {
+	categories, err := r.repo.GetAllCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = r.translations.LocalizeCategories(ctx, categories, lang)
+	if err != nil && !errors.Is(err, ErrTranslationUnavailable) {
+		return nil, err
+	}
+	return categories, err
 }
 
-func (t *TourismUseCase) CheckTourOwner(tourID uuid.UUID, userID uuid.UUID) bool {
-	return t.repo.CheckTourOwner(tourID, userID)
+func (t *TourismUseCase) CreatePurchase(ctx context.Context, actor entity.AuditActor, purchase *entity.Purchase) (*entity.Purchase, error) {
+	var createdPurchase *entity.Purchase
+	err := t.tx.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		createdPurchase, err = t.repo.CreatePurchase(ctx, purchase)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTourEventNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return t.audit.Record(ctx, actor, entity.AuditActionPurchaseCreate, "purchase", createdPurchase.ID.String(), nil, purchaseSnapshot(createdPurchase))
+	})
+	if err != nil {
+		return nil, err
+	}
+	metrics.PurchaseCreatedIn(categorySlugs(createdPurchase.TourEvent.Tour)...)
+	return createdPurchase, nil
 }
 
-func (t *TourismUseCase) CreateTourEvent(tourEvent *entity.TourEvent) (*entity.TourEvent, error) {
-	tourEvent, err := t.repo.CreateTourEvent(tourEvent)
+// categorySlugs lists the slugs of the loaded categories of tour, by name for categories without one.
+func categorySlugs(tour entity.Tour) []string {
+	slugs := make([]string, 0, len(tour.TourCategories))
+	for _, tc := range tour.TourCategories {
+		slug := tc.Category.Slug
+		if slug == "" {
+			slug = tc.Category.Name
+		}
+		slugs = append(slugs, slug)
+	}
+	return slugs
+}
+
+func (t *TourismUseCase) PayTourEvent(ctx context.Context, purchase *entity.Purchase) error {
+	return t.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := t.repo.PayTourEvent(ctx, purchase); err != nil {
+			return err
+		}
+		before := purchaseSnapshot(purchase)
+		after := purchaseSnapshot(purchase)
+		after["status"] = entity.PurchaseStatusPaid
+		return t.audit.Record(ctx, entity.SystemActor("payment"), entity.AuditActionPurchasePay, "purchase", purchase.ID.String(), before, after)
+	})
+}
+
+// FailPurchase marks a purchase that is still processing as failed and releases its seat.
+func (t *TourismUseCase) FailPurchase(ctx context.Context, purchase *entity.Purchase) error {
+	return t.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := t.repo.FailPurchase(ctx, purchase); err != nil {
+			return err
+		}
+		before := purchaseSnapshot(purchase)
+		after := purchaseSnapshot(purchase)
+		after["status"] = entity.PurchaseStatusFailed
+		return t.audit.Record(ctx, entity.SystemActor("payment"), entity.AuditActionPurchaseFail, "purchase", purchase.ID.String(), before, after)
+	})
+}
+
+func (t *TourismUseCase) CheckTourOwner(ctx context.Context, tourID uuid.UUID, userID uuid.UUID) bool {
+	return t.repo.CheckTourOwner(ctx, tourID, userID)
+}
+
+// TourExists reports whether the tour exists.
+func (t *TourismUseCase) TourExists(ctx context.Context, tourID uuid.UUID) (bool, error) {
+	return t.repo.TourExists(ctx, tourID)
+}
+
+// TourEventExists reports whether the tour event exists.
+func (t *TourismUseCase) TourEventExists(ctx context.Context, tourEventID uuid.UUID) (bool, error) {
+	return t.repo.TourEventExists(ctx, tourEventID)
+}
+
+// CategoryExists reports whether the category exists.
+func (t *TourismUseCase) CategoryExists(ctx context.Context, categoryID uuid.UUID) (bool, error) {
+	_, err := t.repo.GetCategoryByID(ctx, categoryID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (t *TourismUseCase) CreateTourEvent(ctx context.Context, actor entity.AuditActor, tourEvent *entity.TourEvent) (*entity.TourEvent, error) {
+	err := t.tx.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		tourEvent, err = t.repo.CreateTourEvent(ctx, tourEvent)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTourNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return t.audit.Record(ctx, actor, entity.AuditActionTourEventCreate, "tour_event", tourEvent.ID.String(), nil, tourEventSnapshot(tourEvent))
+	})
+	if errors.Is(err, ErrTourNotFound) {
+		return nil, err
+	}
 	if err != nil {
 		return nil, fmt.Errorf("create tour event: %w", err)
 	}
 	return tourEvent, nil
 }
 
-func (t *TourismUseCase) CreateTour(tour *entity.Tour, imageFiles []*multipart.FileHeader, videoFiles []*multipart.FileHeader) (*entity.Tour, error) {
-	tour, err := t.repo.CreateTour(tour, imageFiles, videoFiles)
+func (t *TourismUseCase) CreateTour(ctx context.Context, actor entity.AuditActor, tour *entity.Tour, imageFiles []*multipart.FileHeader, videoFiles []*multipart.FileHeader) (*entity.Tour, error) {
+	fileErrors := append(
+		t.uploads.Validate("images", media.KindImage, imageFiles),
+		t.uploads.Validate("videos", media.KindVideo, videoFiles)...,
+	)
+	if len(fileErrors) > 0 {
+		return nil, &media.ValidationError{Files: fileErrors}
+	}
+	if err := t.translations.CheckLanguage(tour.Language); err != nil {
+		return nil, err
+	}
+
+	err := t.tx.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		tour, err = t.repo.CreateTour(ctx, tour, imageFiles, videoFiles)
+		if err != nil {
+			return err
+		}
+		return t.audit.Record(ctx, actor, entity.AuditActionTourCreate, "tour", tour.ID.String(), nil, map[string]interface{}{
+			"description": tour.Description,
+			"route":       tour.Route,
+			"language":    tour.Language,
+			"owner_id":    tour.OwnerID,
+			"images":      len(imageFiles),
+			"videos":      len(videoFiles),
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
 	return tour, nil
 }
 
-func (t *TourismUseCase) GetTourByID(id string) (*entity.Tour, error) {
-	tour, err := t.repo.GetTourByID(id)
+// GetTourByID returns the tour with texts in lang.
+// On ErrTranslationUnavailable the tour is returned untranslated.
+func (t *TourismUseCase) GetTourByID(ctx context.Context, id string, lang string) (*entity.Tour, error) {
+	tour, err := t.repo.GetTourByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTourNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
-	return tour, nil
+	err = t.translations.LocalizeTours(ctx, []*entity.Tour{tour}, lang)
+	if err != nil && !errors.Is(err, ErrTranslationUnavailable) {
+		return nil, err
+	}
+	return tour, err
 }
 
-func (t *TourismUseCase) GetTours() ([]entity.Tour, error) {
-	tours, err := t.repo.GetTours()
+// GetTours returns the tours with texts in lang.
+// On ErrTranslationUnavailable the tours are returned untranslated.
+func (t *TourismUseCase) GetTours(ctx context.Context, lang string) ([]entity.Tour, error) {
+	tours, err := t.repo.GetTours(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return tours, nil
+	pointers := make([]*entity.Tour, 0, len(tours))
+	for i := range tours {
+		pointers = append(pointers, &tours[i])
+	}
+	err = t.translations.LocalizeTours(ctx, pointers, lang)
+	if err != nil && !errors.Is(err, ErrTranslationUnavailable) {
+		return nil, err
+	}
+	return tours, err
+}
+
+func tourEventSnapshot(tourEvent *entity.TourEvent) map[string]interface{} {
+	return map[string]interface{}{
+		"tour_id":          tourEvent.TourID,
+		"date":             tourEvent.Date,
+		"price":            tourEvent.Price,
+		"place":            tourEvent.Place,
+		"amount_of_places": tourEvent.AmountOfPlaces,
+		"is_opened":        tourEvent.IsOpened,
+	}
+}
+
+func purchaseSnapshot(purchase *entity.Purchase) map[string]interface{} {
+	return map[string]interface{}{
+		"user_id":       purchase.UserID,
+		"tour_event_id": purchase.TourEventID,
+		"status":        purchase.Status,
+	}
 }
@@ -6,10 +6,13 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
 	"tourism-backend/internal/entity"
 	"tourism-backend/internal/usecase"
+	"tourism-backend/internal/usecase/repo/inmemory"
+	"tourism-backend/pkg/media"
 )
 
 var errInternalServErr = errors.New("internal server error")
@@ -21,40 +24,63 @@ type test struct {
 	err  error
 }
 
-func translation(t *testing.T) (*usecase.TranslationUseCase, *MockTranslationRepo, *MockTranslationWebAPI) {
+func tourism(t *testing.T) (*usecase.TourismUseCase, *MockTourismRepo, *MockAuditRepo) {
 	t.Helper()
 
 	mockCtl := gomock.NewController(t)
-	defer mockCtl.Finish()
 
-	repo := NewMockTranslationRepo(mockCtl)
-	webAPI := NewMockTranslationWebAPI(mockCtl)
+	repo := NewMockTourismRepo(mockCtl)
+	auditRepo := NewMockAuditRepo(mockCtl)
 
-	translation := usecase.NewTourismUseCase(repo, webAPI)
+	tourism := usecase.NewTourismUseCase(repo, inmemory.Transactor{}, usecase.NewAuditUseCase(auditRepo), nil, nil)
 
-	return translation, repo, webAPI
+	return tourism, repo, auditRepo
 }
 
-func TestHistory(t *testing.T) {
+func TestCreatePurchase(t *testing.T) {
 	t.Parallel()
 
-	translation, repo, _ := translation(t)
+	tourism, repo, auditRepo := tourism(t)
+
+	actor := entity.AuditActor{UserID: uuid.New(), Role: "user"}
+	purchase := &entity.Purchase{
+		ID:          uuid.New(),
+		UserID:      actor.UserID,
+		TourEventID: uuid.New(),
+		Status:      entity.PurchaseStatusProcessing,
+	}
 
 	tests := []test{
 		{
-			name: "empty result",
+			name: "purchase is audited",
 			mock: func() {
-				repo.EXPECT().GetHistory(context.Background()).Return(nil, nil)
+				repo.EXPECT().CreatePurchase(gomock.Any(), purchase).Return(purchase, nil)
+				auditRepo.EXPECT().Append(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, event *entity.AuditEvent) error {
+						require.Equal(t, entity.AuditActionPurchaseCreate, event.Action)
+						require.Equal(t, purchase.ID.String(), event.ResourceID)
+						require.Equal(t, actor.UserID, event.ActorID)
+						return nil
+					})
 			},
-			res: []entity.Translation(nil),
+			res: purchase,
 			err: nil,
 		},
 		{
-			name: "result with error",
+			name: "repo error",
+			mock: func() {
+				repo.EXPECT().CreatePurchase(gomock.Any(), purchase).Return(nil, errInternalServErr)
+			},
+			res: (*entity.Purchase)(nil),
+			err: errInternalServErr,
+		},
+		{
+			name: "audit error",
 			mock: func() {
-				repo.EXPECT().GetHistory(context.Background()).Return(nil, errInternalServErr)
+				repo.EXPECT().CreatePurchase(gomock.Any(), purchase).Return(purchase, nil)
+				auditRepo.EXPECT().Append(gomock.Any(), gomock.Any()).Return(errInternalServErr)
 			},
-			res: []entity.Translation(nil),
+			res: (*entity.Purchase)(nil),
 			err: errInternalServErr,
 		},
 	}
@@ -63,64 +89,169 @@ func TestHistory(t *testing.T) {
 		tc := tc
 
 		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
-
 			tc.mock()
 
-			res, err := translation.History(context.Background())
+			res, err := tourism.CreatePurchase(context.Background(), actor, purchase)
 
-			require.Equal(t, res, tc.res)
+			require.Equal(t, tc.res, res)
 			require.ErrorIs(t, err, tc.err)
 		})
 	}
 }
 
-func TestTranslate(t *testing.T) {
+func TestCheckTourOwner(t *testing.T) {
 	t.Parallel()
 
-	translation, repo, webAPI := translation(t)
+	tourism, repo, _ := tourism(t)
 
-	tests := []test{
-		{
-			name: "empty result",
-			mock: func() {
-				webAPI.EXPECT().Translate(entity.Translation{}).Return(entity.Translation{}, nil)
-				repo.EXPECT().Store(context.Background(), entity.Translation{}).Return(nil)
-			},
-			res: entity.Translation{},
-			err: nil,
-		},
-		{
-			name: "web API error",
-			mock: func() {
-				webAPI.EXPECT().Translate(entity.Translation{}).Return(entity.Translation{}, errInternalServErr)
-			},
-			res: entity.Translation{},
-			err: errInternalServErr,
-		},
-		{
-			name: "repo error",
-			mock: func() {
-				webAPI.EXPECT().Translate(entity.Translation{}).Return(entity.Translation{}, nil)
-				repo.EXPECT().Store(context.Background(), entity.Translation{}).Return(errInternalServErr)
-			},
-			res: entity.Translation{},
-			err: errInternalServErr,
-		},
+	tourID, ownerID, otherID := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name   string
+		userID uuid.UUID
+		owner  bool
+	}{
+		{name: "owner", userID: ownerID, owner: true},
+		{name: "not the owner", userID: otherID, owner: false},
 	}
 
 	for _, tc := range tests {
 		tc := tc
 
 		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+			repo.EXPECT().CheckTourOwner(gomock.Any(), tourID, tc.userID).Return(tc.userID == ownerID)
 
-			tc.mock()
+			require.Equal(t, tc.owner, tourism.CheckTourOwner(context.Background(), tourID, tc.userID))
+		})
+	}
+}
 
-			res, err := translation.Translate(context.Background(), entity.Translation{})
+func TestCreatePurchaseInMemory(t *testing.T) {
+	t.Parallel()
 
-			require.EqualValues(t, res, tc.res)
-			require.ErrorIs(t, err, tc.err)
-		})
+	ctx := context.Background()
+	repo := inmemory.NewTourismRepo()
+	auditRepo := inmemory.NewAuditRepo()
+	tourism := usecase.NewTourismUseCase(repo, inmemory.Transactor{}, usecase.NewAuditUseCase(auditRepo), nil, nil)
+
+	ownerID := uuid.New()
+	tour, err := repo.CreateTour(ctx, &entity.Tour{Description: "Old town walk", OwnerID: ownerID}, nil, nil)
+	require.NoError(t, err)
+	tourEvent, err := repo.CreateTourEvent(ctx, &entity.TourEvent{TourID: tour.ID, Price: 10, AmountOfPlaces: 1, IsOpened: true})
+	require.NoError(t, err)
+
+	require.True(t, tourism.CheckTourOwner(ctx, tour.ID, ownerID))
+	require.False(t, tourism.CheckTourOwner(ctx, tour.ID, uuid.New()))
+
+	actor := entity.AuditActor{UserID: uuid.New(), Role: "user"}
+	purchase, err := tourism.CreatePurchase(ctx, actor, &entity.Purchase{
+		UserID:      actor.UserID,
+		TourEventID: tourEvent.ID,
+		Status:      entity.PurchaseStatusProcessing,
+	})
+	require.NoError(t, err)
+	require.Equal(t, float64(0), purchase.TourEvent.AmountOfPlaces)
+
+	// The only place is taken.
+	_, err = tourism.CreatePurchase(ctx, actor, &entity.Purchase{UserID: actor.UserID, TourEventID: tourEvent.ID})
+	require.ErrorIs(t, err, entity.ErrTourEventSoldOut)
+
+	_, err = tourism.CreatePurchase(ctx, actor, &entity.Purchase{UserID: actor.UserID, TourEventID: uuid.New()})
+	require.ErrorIs(t, err, usecase.ErrTourEventNotFound)
+
+	// A failed purchase gives its place back, and is failed only once.
+	require.NoError(t, tourism.FailPurchase(ctx, purchase))
+	require.Error(t, tourism.FailPurchase(ctx, purchase))
+	_, err = tourism.CreatePurchase(ctx, actor, &entity.Purchase{UserID: actor.UserID, TourEventID: tourEvent.ID, Status: entity.PurchaseStatusProcessing})
+	require.NoError(t, err)
+
+	events := auditRepo.Events()
+	require.Len(t, events, 3)
+	require.Equal(t, entity.AuditActionPurchaseCreate, events[0].Action)
+	require.Equal(t, entity.AuditActionPurchaseFail, events[1].Action)
+	require.Equal(t, entity.AuditActionPurchaseCreate, events[2].Action)
+
+	report, err := auditRepo.VerifyChain(ctx)
+	require.NoError(t, err)
+	require.True(t, report.Valid)
+}
+
+func TestRecordsExist(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := inmemory.NewTourismRepo()
+	tourism := usecase.NewTourismUseCase(repo, inmemory.Transactor{}, usecase.NewAuditUseCase(inmemory.NewAuditRepo()), nil, nil)
+
+	tour, err := repo.CreateTour(ctx, &entity.Tour{Description: "Old town walk", OwnerID: uuid.New()}, nil, nil)
+	require.NoError(t, err)
+	tourEvent, err := repo.CreateTourEvent(ctx, &entity.TourEvent{TourID: tour.ID, Price: 10, AmountOfPlaces: 1, IsOpened: true})
+	require.NoError(t, err)
+	category := &entity.Category{Name: "Hiking", Slug: "hiking"}
+	require.NoError(t, repo.CreateCategory(ctx, category))
+
+	for _, tc := range []struct {
+		name   string
+		exists func(context.Context, uuid.UUID) (bool, error)
+		id     uuid.UUID
+	}{
+		{name: "tour", exists: tourism.TourExists, id: tour.ID},
+		{name: "tour event", exists: tourism.TourEventExists, id: tourEvent.ID},
+		{name: "category", exists: tourism.CategoryExists, id: category.ID},
+	} {
+		found, err := tc.exists(ctx, tc.id)
+		require.NoError(t, err, tc.name)
+		require.True(t, found, tc.name)
+
+		found, err = tc.exists(ctx, uuid.New())
+		require.NoError(t, err, tc.name)
+		require.False(t, found, tc.name)
+	}
+}
+
+func TestCreateTourCategoryAudit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := inmemory.NewTourismRepo()
+	auditRepo := inmemory.NewAuditRepo()
+	tourism := usecase.NewTourismUseCase(repo, inmemory.Transactor{}, usecase.NewAuditUseCase(auditRepo), nil, nil)
+
+	tour, err := repo.CreateTour(ctx, &entity.Tour{Description: "Old town walk", OwnerID: uuid.New()}, nil, nil)
+	require.NoError(t, err)
+	category := &entity.Category{Name: "Hiking", Slug: "hiking"}
+	require.NoError(t, repo.CreateCategory(ctx, category))
+
+	dto := &entity.CreateTourCategoryDTO{TourID: tour.ID, CategoryID: category.ID}
+	_, err = tourism.CreateTourCategory(ctx, entity.AuditActor{UserID: tour.OwnerID}, dto)
+	require.NoError(t, err)
+	_, err = tourism.CreateTourCategory(ctx, entity.AuditActor{UserID: tour.OwnerID}, dto)
+	require.ErrorIs(t, err, usecase.ErrTourCategoryExists)
+
+	events := auditRepo.Events()
+	require.Len(t, events, 1)
+	require.Equal(t, "tour_category", events[0].Resource)
+	require.Equal(t, tour.ID.String()+":"+category.ID.String(), events[0].ResourceID)
+}
+
+func TestMaxCreateTourSize(t *testing.T) {
+	t.Parallel()
+
+	images := media.Limits{MaxSize: 10 << 20, MaxCount: 20}
+	videos := media.Limits{MaxSize: 100 << 20, MaxCount: 2}
+	tests := []struct {
+		name   string
+		images media.Limits
+		videos media.Limits
+		size   int64
+	}{
+		{name: "configured limits", images: images, videos: videos, size: 20*(10<<20) + 2*(100<<20) + 1<<20},
+		{name: "unlimited image size", images: media.Limits{MaxCount: 20}, videos: videos},
+		{name: "unlimited number of videos", images: images, videos: media.Limits{MaxSize: 100 << 20}},
+	}
+	for _, tc := range tests {
+		uploads := media.NewValidator(tc.images, tc.videos, media.Limits{})
+		tourism := usecase.NewTourismUseCase(inmemory.NewTourismRepo(), inmemory.Transactor{}, usecase.NewAuditUseCase(inmemory.NewAuditRepo()), uploads, nil)
+		require.Equal(t, tc.size, tourism.MaxCreateTourSize(), tc.name)
 	}
 }
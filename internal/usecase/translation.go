@@ -0,0 +1,462 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase/webapi"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrUnsupportedLanguage is returned for languages outside the configured list.
+	ErrUnsupportedLanguage = entity.NewValidationError("unsupported_language", "unsupported language")
+	// ErrTranslationUnavailable is returned along with the untranslated content when the translator fails.
+	ErrTranslationUnavailable = errors.New("machine translation unavailable")
+	// ErrTranslationNotFound -.
+	ErrTranslationNotFound = entity.NewNotFoundError("translation_not_found", "translation not found")
+	// ErrEmptyTranslation is returned when a translation update sets no field.
+	ErrEmptyTranslation = entity.NewValidationError("empty_translation", "at least one field is required")
+	// ErrCategoryNotFound -.
+	ErrCategoryNotFound = entity.NewNotFoundError("category_not_found", "category not found")
+)
+
+// TranslationUseCase serves tour and category texts in the requested language.
+// A text is resolved along the fallback chain of the language: the first language
+// that is the one the text is written in or has a locale written by the provider wins.
+// When a translator is configured, texts without a locale in the requested language
+// are machine translated instead, results are cached per source text.
+type TranslationUseCase struct {
+	repo TranslationRepo
+	// tx commits the locale changes together with their audit events.
+	tx         Transactor
+	tourism    TourismRepo
+	audit      *AuditUseCase
+	translator webapi.Translator
+	source     string
+	languages  []string
+	fallbacks  []string
+}
+
+// NewTranslationUseCase -.
+// translator may be nil, then only the written locales are served.
+func NewTranslationUseCase(r TranslationRepo, tx Transactor, tourism TourismRepo, audit *AuditUseCase, translator webapi.Translator, source string, languages, fallbacks []string) *TranslationUseCase {
+	// The source language is listed first, it is the default of language negotiation.
+	supported := []string{source}
+	for _, lang := range languages {
+		if !slices.Contains(supported, lang) {
+			supported = append(supported, lang)
+		}
+	}
+	var chain []string
+	for _, lang := range fallbacks {
+		if slices.Contains(supported, lang) && !slices.Contains(chain, lang) {
+			chain = append(chain, lang)
+		}
+	}
+	return &TranslationUseCase{
+		repo:       r,
+		tx:         tx,
+		tourism:    tourism,
+		audit:      audit,
+		translator: translator,
+		source:     source,
+		languages:  supported,
+		fallbacks:  chain,
+	}
+}
+
+// Languages returns the supported content languages, the source language first.
+func (u *TranslationUseCase) Languages() []string {
+	return u.languages
+}
+
+// Chain returns the languages texts in lang are looked up in, lang first.
+func (u *TranslationUseCase) Chain(lang string) []string {
+	if lang == "" {
+		lang = u.source
+	}
+	chain := []string{lang}
+	for _, fallback := range u.fallbacks {
+		if !slices.Contains(chain, fallback) {
+			chain = append(chain, fallback)
+		}
+	}
+	return chain
+}
+
+// translatable is a text field resolved in place.
+type translatable struct {
+	resource string
+	id       uuid.UUID
+	field    string
+	// source is the language text is written in.
+	source string
+	text   *string
+	// locales holds the texts written for other languages.
+	locales map[string]string
+	// original is the source text of a field left to the translator.
+	original string
+}
+
+// LocalizeTours resolves the descriptions and routes of tours in lang.
+func (u *TranslationUseCase) LocalizeTours(ctx context.Context, tours []*entity.Tour, lang string) error {
+	if len(tours) == 0 {
+		return nil
+	}
+	chain, err := u.chainOf(lang)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]uuid.UUID, 0, len(tours))
+	for _, tour := range tours {
+		ids = append(ids, tour.ID)
+	}
+	locales, err := u.repo.GetTourLocales(ctx, ids, chain)
+	if err != nil {
+		return err
+	}
+	descriptions := make(map[uuid.UUID]map[string]string, len(tours))
+	routes := make(map[uuid.UUID]map[string]string, len(tours))
+	for _, locale := range locales {
+		if descriptions[locale.TourID] == nil {
+			descriptions[locale.TourID] = map[string]string{}
+			routes[locale.TourID] = map[string]string{}
+		}
+		descriptions[locale.TourID][locale.Language] = locale.Description
+		routes[locale.TourID][locale.Language] = locale.Route
+	}
+
+	fields := make([]translatable, 0, 2*len(tours))
+	for _, tour := range tours {
+		source := tour.Language
+		if source == "" {
+			source = u.source
+		}
+		fields = append(fields,
+			translatable{resource: entity.TranslationResourceTour, id: tour.ID, field: entity.TranslationFieldDescription, source: source, text: &tour.Description, locales: descriptions[tour.ID]},
+			translatable{resource: entity.TranslationResourceTour, id: tour.ID, field: entity.TranslationFieldRoute, source: source, text: &tour.Route, locales: routes[tour.ID]},
+		)
+	}
+	return u.localize(ctx, fields, chain)
+}
+
+// LocalizeCategories resolves the category names in lang and sorts the categories by
+// sort order, then by name.
+func (u *TranslationUseCase) LocalizeCategories(ctx context.Context, categories []entity.Category, lang string) error {
+	if len(categories) == 0 {
+		return nil
+	}
+	chain, err := u.chainOf(lang)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]uuid.UUID, 0, len(categories))
+	for i := range categories {
+		ids = append(ids, categories[i].ID)
+	}
+	locales, err := u.repo.GetCategoryLocales(ctx, ids, chain)
+	if err != nil {
+		return err
+	}
+	names := make(map[uuid.UUID]map[string]string, len(categories))
+	for _, locale := range locales {
+		if names[locale.CategoryID] == nil {
+			names[locale.CategoryID] = map[string]string{}
+		}
+		names[locale.CategoryID][locale.Language] = locale.Name
+	}
+
+	fields := make([]translatable, 0, len(categories))
+	for i := range categories {
+		fields = append(fields, translatable{
+			resource: entity.TranslationResourceCategory,
+			id:       categories[i].ID,
+			field:    entity.TranslationFieldName,
+			source:   u.source,
+			text:     &categories[i].Name,
+			locales:  names[categories[i].ID],
+		})
+	}
+	err = u.localize(ctx, fields, chain)
+
+	collator := collate.New(language.Make(chain[0]), collate.IgnoreCase)
+	sort.SliceStable(categories, func(i, j int) bool {
+		if categories[i].SortOrder != categories[j].SortOrder {
+			return categories[i].SortOrder < categories[j].SortOrder
+		}
+		return collator.CompareString(categories[i].Name, categories[j].Name) < 0
+	})
+	return err
+}
+
+func (u *TranslationUseCase) chainOf(lang string) ([]string, error) {
+	if lang != "" && !slices.Contains(u.languages, lang) {
+		return nil, ErrUnsupportedLanguage
+	}
+	return u.Chain(lang), nil
+}
+
+// localize resolves every field along chain. Fields without a locale in chain[0] are
+// machine translated into it in one batch per source language, when translation fails
+// they keep the text resolved from the rest of the chain.
+func (u *TranslationUseCase) localize(ctx context.Context, fields []translatable, chain []string) error {
+	lang := chain[0]
+	var pending []translatable
+	for _, f := range fields {
+		if *f.text == "" {
+			continue
+		}
+	resolve:
+		for _, l := range chain {
+			switch {
+			case l == f.source:
+				break resolve
+			case f.locales[l] != "":
+				*f.text = f.locales[l]
+				break resolve
+			case l == lang && u.translator != nil:
+				f.original = *f.text
+				pending = append(pending, f)
+			}
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(pending))
+	for _, f := range pending {
+		ids = append(ids, f.id)
+	}
+	cached, err := u.repo.GetTranslations(ctx, lang, ids)
+	if err != nil {
+		return err
+	}
+	type key struct {
+		id    uuid.UUID
+		field string
+	}
+	translations := make(map[key]entity.TourTranslation, len(cached))
+	for _, t := range cached {
+		translations[key{t.ResourceID, t.Field}] = t
+	}
+
+	// Tours often share texts, each distinct one is translated once per source language.
+	missing := map[string][]translatable{}
+	for _, f := range pending {
+		t, ok := translations[key{f.id, f.field}]
+		if ok && t.SourceHash == sourceHash(f.original) {
+			*f.text = t.Text
+			continue
+		}
+		missing[f.source] = append(missing[f.source], f)
+	}
+
+	var rows []entity.TourTranslation
+	var translateErr error
+	for source, group := range missing {
+		index := make(map[string]int, len(group))
+		var texts []string
+		for _, f := range group {
+			if _, ok := index[f.original]; !ok {
+				index[f.original] = len(texts)
+				texts = append(texts, f.original)
+			}
+		}
+		translated, err := u.translator.Translate(ctx, texts, source, lang)
+		if err != nil {
+			translateErr = fmt.Errorf("%w: %w", ErrTranslationUnavailable, err)
+			continue
+		}
+		for _, f := range group {
+			*f.text = translated[index[f.original]]
+			rows = append(rows, entity.TourTranslation{
+				Resource:   f.resource,
+				ResourceID: f.id,
+				Field:      f.field,
+				Language:   lang,
+				Text:       *f.text,
+				SourceHash: sourceHash(f.original),
+			})
+		}
+	}
+	if err := u.repo.SaveTranslations(ctx, rows); err != nil {
+		return err
+	}
+	return translateErr
+}
+
+// GetTourLocales returns the locales written for a tour of the provider.
+// Locales written before the tour texts were last edited are marked outdated.
+func (u *TranslationUseCase) GetTourLocales(ctx context.Context, providerID, tourID uuid.UUID) ([]entity.TourLocale, error) {
+	tour, err := u.ownedTour(ctx, providerID, tourID)
+	if err != nil {
+		return nil, err
+	}
+	locales, err := u.repo.GetTourLocales(ctx, []uuid.UUID{tourID}, nil)
+	if err != nil {
+		return nil, err
+	}
+	hash := tourSourceHash(tour)
+	for i := range locales {
+		locales[i].Outdated = locales[i].SourceHash != hash
+	}
+	return locales, nil
+}
+
+// SetTourLocale writes the tour fields set in dto in lang, omitted fields keep their current text.
+func (u *TranslationUseCase) SetTourLocale(ctx context.Context, actor entity.AuditActor, tourID uuid.UUID, lang string, dto *entity.UpdateTourLocaleDTO) (*entity.TourLocale, error) {
+	if dto.Description == nil && dto.Route == nil {
+		return nil, ErrEmptyTranslation
+	}
+	tour, err := u.ownedTour(ctx, actor.UserID, tourID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.checkLanguage(lang, tour.Language); err != nil {
+		return nil, err
+	}
+
+	locale := &entity.TourLocale{TourID: tourID, Language: lang}
+	existing, err := u.repo.GetTourLocales(ctx, []uuid.UUID{tourID}, []string{lang})
+	if err != nil {
+		return nil, err
+	}
+	var before interface{}
+	if len(existing) > 0 {
+		locale = &existing[0]
+		before = map[string]interface{}{
+			entity.TranslationFieldDescription: locale.Description,
+			entity.TranslationFieldRoute:       locale.Route,
+		}
+	}
+	if dto.Description != nil {
+		locale.Description = *dto.Description
+	}
+	if dto.Route != nil {
+		locale.Route = *dto.Route
+	}
+	locale.SourceHash = tourSourceHash(tour)
+
+	err = u.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.repo.SaveTourLocale(ctx, locale); err != nil {
+			return err
+		}
+		return u.audit.Record(ctx, actor, entity.AuditActionTranslationUpdate, "tour", tourID.String(), before, map[string]interface{}{
+			"language":                         lang,
+			entity.TranslationFieldDescription: locale.Description,
+			entity.TranslationFieldRoute:       locale.Route,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return locale, nil
+}
+
+// DeleteTourLocale drops the locale of a tour in lang, reads fall back to the next language of the chain.
+func (u *TranslationUseCase) DeleteTourLocale(ctx context.Context, actor entity.AuditActor, tourID uuid.UUID, lang string) error {
+	tour, err := u.ownedTour(ctx, actor.UserID, tourID)
+	if err != nil {
+		return err
+	}
+	if err := u.checkLanguage(lang, tour.Language); err != nil {
+		return err
+	}
+	return u.tx.Transaction(ctx, func(ctx context.Context) error {
+		err := u.repo.DeleteTourLocale(ctx, tourID, lang)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTranslationNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return u.audit.Record(ctx, actor, entity.AuditActionTranslationDelete, "tour", tourID.String(), map[string]interface{}{"language": lang}, nil)
+	})
+}
+
+// SetCategoryLocale writes the name of a category in lang.
+func (u *TranslationUseCase) SetCategoryLocale(ctx context.Context, actor entity.AuditActor, categoryID uuid.UUID, lang string, dto *entity.UpdateCategoryLocaleDTO) (*entity.CategoryLocale, error) {
+	if err := u.checkLanguage(lang, ""); err != nil {
+		return nil, err
+	}
+	_, err := u.tourism.GetCategoryByID(ctx, categoryID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCategoryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	locale := &entity.CategoryLocale{CategoryID: categoryID, Language: lang, Name: dto.Name}
+	err = u.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.repo.SaveCategoryLocale(ctx, locale); err != nil {
+			return err
+		}
+		return u.audit.Record(ctx, actor, entity.AuditActionTranslationUpdate, "category", categoryID.String(), nil, map[string]interface{}{
+			"language":                  lang,
+			entity.TranslationFieldName: dto.Name,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return locale, nil
+}
+
+// CheckLanguage validates the language a new text is written in, empty means the source language.
+func (u *TranslationUseCase) CheckLanguage(lang string) error {
+	if lang != "" && !slices.Contains(u.languages, lang) {
+		return ErrUnsupportedLanguage
+	}
+	return nil
+}
+
+// checkLanguage validates the language of a locale, which must differ from the language
+// the resource is written in, empty meaning the source language.
+func (u *TranslationUseCase) checkLanguage(lang, written string) error {
+	if written == "" {
+		written = u.source
+	}
+	if lang == written || !slices.Contains(u.languages, lang) {
+		return ErrUnsupportedLanguage
+	}
+	return nil
+}
+
+func (u *TranslationUseCase) ownedTour(ctx context.Context, providerID, tourID uuid.UUID) (*entity.Tour, error) {
+	tour, err := u.tourism.GetTourByID(ctx, tourID.String())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTourNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if tour.OwnerID != providerID {
+		return nil, ErrNotTourOwner
+	}
+	return tour, nil
+}
+
+// tourSourceHash identifies the version of the tour texts a locale is written for.
+func tourSourceHash(tour *entity.Tour) string {
+	return sourceHash(tour.Description + "\x00" + tour.Route)
+}
+
+// sourceHash identifies the source text a translation was made from.
+func sourceHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
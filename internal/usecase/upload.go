@@ -0,0 +1,320 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/media"
+	"tourism-backend/pkg/tus"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// _expiredUploadsBatch is the number of expired uploads removed per query.
+const _expiredUploadsBatch = 100
+
+var (
+	// ErrUploadNotFound is returned for unknown uploads and uploads of other users.
+	ErrUploadNotFound = entity.NewNotFoundError("upload_not_found", "upload not found")
+	// ErrUploadExpired -.
+	ErrUploadExpired = entity.NewNotFoundError("upload_expired", "upload expired")
+	// ErrUploadLocked is returned while another request is writing to the upload.
+	ErrUploadLocked = entity.NewConflictError("upload_locked", "upload is being written by another request")
+	// ErrUploadOffsetMismatch is returned when a chunk does not start at the current offset.
+	ErrUploadOffsetMismatch = entity.NewConflictError("upload_offset_mismatch", "chunk offset does not match the upload offset")
+	// ErrUploadTooLarge is returned when the upload length exceeds the video size limit.
+	ErrUploadTooLarge = entity.NewValidationError("upload_too_large", "upload is larger than the video size limit")
+	// ErrInvalidUpload is returned when a creation request lacks the tour or the filename.
+	ErrInvalidUpload = entity.NewValidationError("invalid_upload", "upload metadata must contain tour_id and filename")
+)
+
+type UploadUseCase struct {
+	repo UploadRepo
+	// tx holds the lock of an upload while it is written, and commits the completed
+	// uploads together with their audit events.
+	tx         Transactor
+	parts      *tus.PartStore
+	tourism    TourismRepo
+	tourMedia  TourMediaRepo
+	audit      *AuditUseCase
+	uploads    *media.Validator
+	expiration time.Duration
+}
+
+// NewUploadUseCase -.
+func NewUploadUseCase(r UploadRepo, tx Transactor, parts *tus.PartStore, tourism TourismRepo, tourMedia TourMediaRepo,
+	audit *AuditUseCase, uploads *media.Validator, expiration time.Duration,
+) *UploadUseCase {
+	return &UploadUseCase{
+		repo:       r,
+		tx:         tx,
+		parts:      parts,
+		tourism:    tourism,
+		tourMedia:  tourMedia,
+		audit:      audit,
+		uploads:    uploads,
+		expiration: expiration,
+	}
+}
+
+// MaxSize returns the largest accepted upload length, 0 when unlimited.
+func (u *UploadUseCase) MaxSize() int64 {
+	return u.uploads.Limits(media.KindVideo).MaxSize
+}
+
+// CreateUpload starts a resumable video upload for a tour of the actor.
+func (u *UploadUseCase) CreateUpload(ctx context.Context, actor entity.AuditActor, dto *entity.CreateUploadDTO) (*entity.Upload, error) {
+	if dto.TourID == uuid.Nil || dto.Filename == "" {
+		return nil, ErrInvalidUpload
+	}
+	if !u.tourism.CheckTourOwner(ctx, dto.TourID, actor.UserID) {
+		return nil, ErrNotTourOwner
+	}
+	if dto.Length == 0 {
+		return nil, &media.ValidationError{Files: []media.FileError{{
+			Field: "video", Filename: dto.Filename, Code: media.CodeEmpty, Message: dto.Filename + " is empty",
+		}}}
+	}
+	if maxSize := u.MaxSize(); maxSize > 0 && dto.Length > maxSize {
+		return nil, ErrUploadTooLarge
+	}
+	if err := u.checkVideoCount(ctx, dto.TourID, dto.Filename); err != nil {
+		return nil, err
+	}
+
+	upload := &entity.Upload{
+		UserID:    actor.UserID,
+		TourID:    dto.TourID,
+		Length:    dto.Length,
+		Filename:  dto.Filename,
+		AltText:   dto.AltText,
+		Caption:   dto.Caption,
+		ExpiresAt: time.Now().Add(u.expiration),
+	}
+	if err := u.repo.CreateUpload(ctx, upload); err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+// GetUpload returns an upload of the user.
+func (u *UploadUseCase) GetUpload(ctx context.Context, userID, id uuid.UUID) (*entity.Upload, error) {
+	upload, err := u.repo.GetUpload(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUploadNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if upload.UserID != userID {
+		return nil, ErrUploadNotFound
+	}
+	if !upload.Completed() && time.Now().After(upload.ExpiresAt) {
+		return nil, ErrUploadExpired
+	}
+	return upload, nil
+}
+
+// WriteUploadChunk appends a chunk starting at offset. Once the last byte arrives the
+// file is validated and attached to the tour as a video.
+// On a failed or partial read the received bytes are kept unless a checksum was sent,
+// so the client can resume from the offset reported by a HEAD request.
+func (u *UploadUseCase) WriteUploadChunk(ctx context.Context, actor entity.AuditActor, id uuid.UUID, offset int64, chunk io.Reader, checksum *tus.Checksum) (*entity.Upload, error) {
+	// The bytes read before the client went away are still recorded.
+	ctx = context.WithoutCancel(ctx)
+
+	var upload *entity.Upload
+	var chunkErr error
+	completed := false
+	err := u.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.lock(ctx, id); err != nil {
+			return err
+		}
+		var err error
+		upload, err = u.GetUpload(ctx, actor.UserID, id)
+		if err != nil {
+			return err
+		}
+		if offset != upload.Offset {
+			return ErrUploadOffsetMismatch
+		}
+
+		n, writeErr := u.parts.Append(ctx, id, offset, upload.Length-offset, chunk, checksum)
+		if n > 0 {
+			if err := u.repo.SetUploadOffset(ctx, id, offset, offset+n); err != nil {
+				return err
+			}
+			upload.Offset += n
+		}
+		if writeErr != nil {
+			// Commit the offset of the bytes that were stored.
+			chunkErr = fmt.Errorf("write upload chunk: %w", writeErr)
+			return nil
+		}
+
+		if upload.Completed() && upload.VideoID == nil {
+			if err := u.complete(ctx, actor, upload); err != nil {
+				var validationErr *media.ValidationError
+				if !errors.As(err, &validationErr) {
+					return err
+				}
+				// Commit the removal of the invalid upload.
+				chunkErr = err
+				return nil
+			}
+			completed = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if chunkErr != nil {
+		return nil, chunkErr
+	}
+	if completed {
+		// The row is kept until it expires, so HEAD still reports the finished upload.
+		// Chunks left by a failed removal go with it.
+		_ = u.parts.Remove(ctx, id)
+	}
+	return upload, nil
+}
+
+// DeleteUpload terminates an upload. A video created by a completed upload is kept.
+func (u *UploadUseCase) DeleteUpload(ctx context.Context, userID, id uuid.UUID) error {
+	return u.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := u.lock(ctx, id); err != nil {
+			return err
+		}
+		if _, err := u.GetUpload(ctx, userID, id); err != nil && !errors.Is(err, ErrUploadExpired) {
+			return err
+		}
+		return u.remove(ctx, id)
+	})
+}
+
+// DeleteExpiredUploads removes expired uploads and their chunks.
+func (u *UploadUseCase) DeleteExpiredUploads(ctx context.Context) (int, error) {
+	deleted := 0
+	for {
+		ids, err := u.repo.GetExpiredUploadIDs(ctx, time.Now(), _expiredUploadsBatch)
+		if err != nil {
+			return deleted, err
+		}
+		removed := 0
+		for _, id := range ids {
+			err := u.tx.Transaction(ctx, func(ctx context.Context) error {
+				if err := u.lock(ctx, id); err != nil {
+					return err
+				}
+				return u.remove(ctx, id)
+			})
+			// Uploads being written are left for the next run.
+			if errors.Is(err, ErrUploadLocked) {
+				continue
+			}
+			if err != nil {
+				return deleted, err
+			}
+			removed++
+		}
+		deleted += removed
+		if len(ids) < _expiredUploadsBatch || removed == 0 {
+			return deleted, nil
+		}
+	}
+}
+
+// complete validates the assembled file and attaches it to the tour. An invalid file
+// is discarded together with its upload, since resending the same bytes cannot succeed.
+func (u *UploadUseCase) complete(ctx context.Context, actor entity.AuditActor, upload *entity.Upload) error {
+	part, err := u.parts.Open(ctx, upload.ID, upload.Length)
+	if err != nil {
+		return err
+	}
+	contentType, fileErr := u.uploads.ValidateStream("video", media.KindVideo, upload.Filename, upload.Length, part)
+	part.Close()
+	if fileErr == nil {
+		if err := u.checkVideoCount(ctx, upload.TourID, upload.Filename); err != nil {
+			var validationErr *media.ValidationError
+			if !errors.As(err, &validationErr) {
+				return err
+			}
+			fileErr = &validationErr.Files[0]
+		}
+	}
+	if fileErr != nil {
+		if err := u.remove(ctx, upload.ID); err != nil {
+			return err
+		}
+		return &media.ValidationError{Files: []media.FileError{*fileErr}}
+	}
+
+	part, err = u.parts.Open(ctx, upload.ID, upload.Length)
+	if err != nil {
+		return err
+	}
+	defer part.Close()
+	var video *entity.Video
+	err = u.tx.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		video, err = u.repo.CompleteUpload(ctx, upload, part, contentType)
+		if err != nil {
+			return err
+		}
+		return u.audit.Record(ctx, actor, entity.AuditActionTourMediaAdd, "tour", upload.TourID.String(), nil, map[string]interface{}{
+			"videos": []string{video.VideoURL},
+			"upload": upload.ID,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	upload.VideoID = &video.ID
+	return nil
+}
+
+func (u *UploadUseCase) checkVideoCount(ctx context.Context, tourID uuid.UUID, filename string) error {
+	limit := u.uploads.Limits(media.KindVideo).MaxCount
+	if limit <= 0 {
+		return nil
+	}
+	existing, err := u.tourMedia.CountTourMedia(ctx, tourID, media.KindVideo)
+	if err != nil {
+		return err
+	}
+	if int(existing) >= limit {
+		return &media.ValidationError{Files: []media.FileError{{
+			Field:    "video",
+			Filename: filename,
+			Code:     media.CodeTooMany,
+			Message:  fmt.Sprintf("a tour can have at most %d videos", limit),
+		}}}
+	}
+	return nil
+}
+
+// remove deletes the chunks before the row, so chunks whose removal failed are retried
+// once the upload expires instead of being left behind.
+func (u *UploadUseCase) remove(ctx context.Context, id uuid.UUID) error {
+	if err := u.parts.Remove(ctx, id); err != nil {
+		return err
+	}
+	return u.repo.DeleteUpload(ctx, id)
+}
+
+// lock takes the lock of the upload for the transaction of ctx.
+func (u *UploadUseCase) lock(ctx context.Context, id uuid.UUID) error {
+	locked, err := u.repo.LockUpload(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !locked {
+		return ErrUploadLocked
+	}
+	return nil
+}
@@ -1,40 +1,62 @@
 package usecase
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"tourism-backend/internal/entity"
-	"tourism-backend/internal/usecase/repo"
+	"tourism-backend/pkg/metrics"
 	"tourism-backend/utils"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidCredentials is returned for unknown usernames and wrong passwords alike.
+	ErrInvalidCredentials = entity.NewUnauthorizedError("invalid_credentials", "invalid username or password")
+	// ErrUserExists is returned when the username or the email is already registered.
+	ErrUserExists = entity.NewConflictError("user_exists", "username or email is already registered")
 )
 
 type UserUseCase struct {
-	repo *repo.UserRepo
+	repo UserRepo
 }
 
 // NewTourismUseCase -.
-func NewUserUseCase(r *repo.UserRepo) *UserUseCase {
+func NewUserUseCase(r UserRepo) *UserUseCase {
 	return &UserUseCase{
 		repo: r,
 	}
 }
 
-func (u *UserUseCase) LoginUser(user *entity.LoginUserDTO) (string, error) {
-	userFromRepo, err := u.repo.LoginUser(user)
+func (u *UserUseCase) LoginUser(ctx context.Context, user *entity.LoginUserDTO) (string, error) {
+	userFromRepo, err := u.repo.LoginUser(ctx, user)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		metrics.Login(metrics.LoginFailure)
+		return "", ErrInvalidCredentials
+	}
 	if err != nil {
+		metrics.Login(metrics.LoginError)
 		return "", fmt.Errorf("User From Repo: %w", err)
 	}
 	if !utils.CheckPassword(userFromRepo.Password, user.Password) {
-		return "", fmt.Errorf("Check Password: %w", err)
+		metrics.Login(metrics.LoginFailure)
+		return "", ErrInvalidCredentials
 	}
 	token, err := utils.GenerateJWT(userFromRepo.ID, userFromRepo.Role)
 	if err != nil {
+		metrics.Login(metrics.LoginError)
 		return "", fmt.Errorf("Generate JWT: %w", err)
 	}
+	metrics.Login(metrics.LoginSuccess)
 	return token, nil
 }
 
-func (u *UserUseCase) RegisterUser(user *entity.User) (*entity.User, error) {
-	user, err := u.repo.RegisterUser(user)
+func (u *UserUseCase) RegisterUser(ctx context.Context, user *entity.User) (*entity.User, error) {
+	user, err := u.repo.RegisterUser(ctx, user)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return nil, ErrUserExists
+	}
 	if err != nil {
 		return nil, fmt.Errorf("register user: %w", err)
 	}
@@ -0,0 +1,75 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase"
+	"tourism-backend/internal/usecase/repo/inmemory"
+	"tourism-backend/utils"
+)
+
+func TestLoginUser(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := inmemory.NewUserRepo()
+	user := usecase.NewUserUseCase(repo)
+
+	password, err := utils.HashPassword("secret")
+	require.NoError(t, err)
+	_, err = user.RegisterUser(ctx, &entity.User{Username: "alice", Email: "alice@example.com", Password: password, Role: "user"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		login    entity.LoginUserDTO
+		hasToken bool
+	}{
+		{
+			name:     "valid credentials",
+			login:    entity.LoginUserDTO{Username: "alice", Password: "secret"},
+			hasToken: true,
+		},
+		{
+			name:  "wrong password",
+			login: entity.LoginUserDTO{Username: "alice", Password: "wrong"},
+		},
+		{
+			name:  "unknown user",
+			login: entity.LoginUserDTO{Username: "bob", Password: "secret"},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			token, err := user.LoginUser(ctx, &tc.login)
+			if !tc.hasToken {
+				require.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+				require.Empty(t, token)
+				return
+			}
+			require.NoError(t, err)
+			require.NotEmpty(t, token)
+		})
+	}
+}
+
+func TestRegisterUserDuplicate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	user := usecase.NewUserUseCase(inmemory.NewUserRepo())
+
+	_, err := user.RegisterUser(ctx, &entity.User{Username: "alice", Email: "alice@example.com"})
+	require.NoError(t, err)
+	_, err = user.RegisterUser(ctx, &entity.User{Username: "alice", Email: "other@example.com"})
+	require.ErrorIs(t, err, usecase.ErrUserExists)
+}
@@ -0,0 +1,33 @@
+package webapi
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// FakeTranslator prefixes every text with the target language, e.g. "[kk] Tour", without calling
+// any service. It is meant for tests and local development.
+type FakeTranslator struct {
+	calls atomic.Int64
+}
+
+var _ Translator = (*FakeTranslator)(nil)
+
+// NewFakeTranslator -.
+func NewFakeTranslator() *FakeTranslator {
+	return &FakeTranslator{}
+}
+
+func (f *FakeTranslator) Translate(_ context.Context, texts []string, _, target string) ([]string, error) {
+	f.calls.Add(1)
+	translated := make([]string, len(texts))
+	for i, text := range texts {
+		translated[i] = "[" + target + "] " + text
+	}
+	return translated, nil
+}
+
+// Calls returns how many times Translate was called, so tests can check that results are cached.
+func (f *FakeTranslator) Calls() int64 {
+	return f.calls.Load()
+}
@@ -1,15 +1,119 @@
 package webapi
 
-// TranslationWebAPI -.
-type TourismWebAPI struct {
-}
-
-// New -.
-func New() *TourismWebAPI {
-	//conf := translator.Config{
-	//	UserAgent:   []string{"Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:15.0) Gecko/20100101 Firefox/15.0.1"},
-	//	ServiceUrls: []string{"translate.google.com"},
-	//}
-	//
-	return nil
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	_googleEndpoint = "https://translation.googleapis.com/language/translate/v2"
+	// _googleMaxSegments is the number of texts the API accepts in one request.
+	_googleMaxSegments = 128
+)
+
+// GoogleTranslator uses the Cloud Translation API (Basic edition).
+type GoogleTranslator struct {
+	apiKey   string
+	endpoint string
+	client   *http.Client
+}
+
+var _ Translator = (*GoogleTranslator)(nil)
+
+// GoogleOption -.
+type GoogleOption func(*GoogleTranslator)
+
+// GoogleEndpoint -.
+func GoogleEndpoint(endpoint string) GoogleOption {
+	return func(g *GoogleTranslator) {
+		g.endpoint = endpoint
+	}
+}
+
+// NewGoogleTranslator -.
+func NewGoogleTranslator(apiKey string, timeout time.Duration, opts ...GoogleOption) *GoogleTranslator {
+	g := &GoogleTranslator{
+		apiKey:   apiKey,
+		endpoint: _googleEndpoint,
+		client:   &http.Client{Timeout: timeout},
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+type googleRequest struct {
+	Q      []string `json:"q"`
+	Source string   `json:"source,omitempty"`
+	Target string   `json:"target"`
+	Format string   `json:"format"`
+}
+
+type googleResponse struct {
+	Data struct {
+		Translations []struct {
+			TranslatedText string `json:"translatedText"`
+		} `json:"translations"`
+	} `json:"data"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (g *GoogleTranslator) Translate(ctx context.Context, texts []string, source, target string) ([]string, error) {
+	translated := make([]string, 0, len(texts))
+	for start := 0; start < len(texts); start += _googleMaxSegments {
+		end := min(start+_googleMaxSegments, len(texts))
+		batch, err := g.translate(ctx, texts[start:end], source, target)
+		if err != nil {
+			return nil, err
+		}
+		translated = append(translated, batch...)
+	}
+	return translated, nil
+}
+
+func (g *GoogleTranslator) translate(ctx context.Context, texts []string, source, target string) ([]string, error) {
+	body, err := json.Marshal(googleRequest{Q: texts, Source: source, Target: target, Format: "text"})
+	if err != nil {
+		return nil, fmt.Errorf("webapi - GoogleTranslator - json.Marshal: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.endpoint+"?key="+url.QueryEscape(g.apiKey), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("webapi - GoogleTranslator - http.NewRequestWithContext: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("webapi - GoogleTranslator - Do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result googleResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 10<<20)).Decode(&result); err != nil {
+		return nil, fmt.Errorf("webapi - GoogleTranslator - status %d: %w", resp.StatusCode, err)
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("webapi - GoogleTranslator - status %d: %s", result.Error.Code, result.Error.Message)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("webapi - GoogleTranslator - status %d", resp.StatusCode)
+	}
+	if len(result.Data.Translations) != len(texts) {
+		return nil, fmt.Errorf("webapi - GoogleTranslator - got %d translations for %d texts", len(result.Data.Translations), len(texts))
+	}
+
+	translated := make([]string, len(texts))
+	for i, t := range result.Data.Translations {
+		translated[i] = t.TranslatedText
+	}
+	return translated, nil
 }
@@ -0,0 +1,77 @@
+package webapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"tourism-backend/internal/usecase/webapi"
+)
+
+func TestGoogleTranslator(t *testing.T) {
+	t.Parallel()
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("key") != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":{"code":403,"message":"API key not valid"}}`))
+			return
+		}
+
+		var req struct {
+			Q      []string `json:"q"`
+			Source string   `json:"source"`
+			Target string   `json:"target"`
+			Format string   `json:"format"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "ru", req.Source)
+		require.Equal(t, "text", req.Format)
+
+		translations := make([]map[string]string, len(req.Q))
+		for i, q := range req.Q {
+			translations[i] = map[string]string{"translatedText": strings.ToUpper(q) + "@" + req.Target}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"translations": translations},
+		})
+	}))
+	defer server.Close()
+
+	translator := webapi.NewGoogleTranslator("secret", time.Second, webapi.GoogleEndpoint(server.URL))
+
+	texts := make([]string, 130)
+	for i := range texts {
+		texts[i] = "тур"
+	}
+	texts[129] = "маршрут"
+	translated, err := translator.Translate(context.Background(), texts, "ru", "kk")
+	require.NoError(t, err)
+	require.Len(t, translated, 130)
+	require.Equal(t, "ТУР@kk", translated[0])
+	require.Equal(t, "МАРШРУТ@kk", translated[129])
+	require.Equal(t, 2, requests, "texts are sent in batches of 128")
+
+	_, err = webapi.NewGoogleTranslator("wrong", time.Second, webapi.GoogleEndpoint(server.URL)).
+		Translate(context.Background(), []string{"тур"}, "ru", "en")
+	require.ErrorContains(t, err, "API key not valid")
+}
+
+func TestFakeTranslator(t *testing.T) {
+	t.Parallel()
+
+	translator := webapi.NewFakeTranslator()
+
+	translated, err := translator.Translate(context.Background(), []string{"Тур", ""}, "ru", "en")
+	require.NoError(t, err)
+	require.Equal(t, []string{"[en] Тур", "[en] "}, translated)
+	require.EqualValues(t, 1, translator.Calls())
+}
@@ -0,0 +1,10 @@
+// Package webapi implements the external services used by the use cases.
+package webapi
+
+import "context"
+
+// Translator translates plain texts between languages given as base language codes, e.g. "ru" or "kk".
+type Translator interface {
+	// Translate returns the translations of texts in the same order.
+	Translate(ctx context.Context, texts []string, source, target string) ([]string, error)
+}
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"tourism-backend/internal/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrSavedSearchNotFound -.
+var ErrSavedSearchNotFound = entity.NewNotFoundError("saved_search_not_found", "saved search not found")
+
+type WishlistUseCase struct {
+	repo    WishlistRepo
+	tourism TourismRepo
+}
+
+// NewWishlistUseCase -.
+func NewWishlistUseCase(r WishlistRepo, tourism TourismRepo) *WishlistUseCase {
+	return &WishlistUseCase{
+		repo:    r,
+		tourism: tourism,
+	}
+}
+
+func (w *WishlistUseCase) AddFavorite(ctx context.Context, userID, tourID uuid.UUID) (*entity.Favorite, error) {
+	favorite, err := w.repo.AddFavorite(ctx, userID, tourID)
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return nil, ErrTourNotFound
+	}
+	return favorite, err
+}
+
+func (w *WishlistUseCase) RemoveFavorite(ctx context.Context, userID, tourID uuid.UUID) error {
+	return w.repo.RemoveFavorite(ctx, userID, tourID)
+}
+
+func (w *WishlistUseCase) GetFavorites(ctx context.Context, userID uuid.UUID) ([]*entity.Favorite, error) {
+	return w.repo.GetFavorites(ctx, userID)
+}
+
+func (w *WishlistUseCase) CreateSavedSearch(ctx context.Context, userID uuid.UUID, dto *entity.CreateSavedSearchDTO) (*entity.SavedSearch, error) {
+	filter, err := json.Marshal(dto.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("encode saved search filter: %w", err)
+	}
+
+	current, err := w.tourism.GetFilteredTourEvents(ctx, &dto.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("run saved search: %w", err)
+	}
+
+	savedSearch := &entity.SavedSearch{
+		UserID: userID,
+		Name:   dto.Name,
+		Filter: string(filter),
+	}
+	return w.repo.CreateSavedSearch(ctx, savedSearch, uniqueTourEvents(current))
+}
+
+func (w *WishlistUseCase) GetSavedSearches(ctx context.Context, userID uuid.UUID) ([]*entity.SavedSearch, error) {
+	return w.repo.GetSavedSearches(ctx, userID)
+}
+
+func (w *WishlistUseCase) DeleteSavedSearch(ctx context.Context, userID, savedSearchID uuid.UUID) error {
+	err := w.repo.DeleteSavedSearch(ctx, userID, savedSearchID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrSavedSearchNotFound
+	}
+	return err
+}
+
+func (w *WishlistUseCase) GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool) ([]*entity.Notification, error) {
+	return w.repo.GetNotifications(ctx, userID, unreadOnly)
+}
+
+func (w *WishlistUseCase) MarkNotificationRead(ctx context.Context, userID, notificationID uuid.UUID) error {
+	return w.repo.MarkNotificationRead(ctx, userID, notificationID)
+}
+
+// RunSavedSearches re-runs every saved search and notifies owners about events they
+// have not been told about yet and about price drops since the last notification.
+// It returns the number of notifications created.
+func (w *WishlistUseCase) RunSavedSearches(ctx context.Context) (int, error) {
+	savedSearches, err := w.repo.GetAllSavedSearches(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	var errs []error
+	for _, savedSearch := range savedSearches {
+		n, err := w.runSavedSearch(ctx, savedSearch)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("saved search %s: %w", savedSearch.ID, err))
+			continue
+		}
+		created += n
+	}
+	return created, errors.Join(errs...)
+}
+
+func (w *WishlistUseCase) runSavedSearch(ctx context.Context, savedSearch *entity.SavedSearch) (int, error) {
+	var filter entity.TourEventFilter
+	if err := json.Unmarshal([]byte(savedSearch.Filter), &filter); err != nil {
+		return 0, fmt.Errorf("decode filter: %w", err)
+	}
+
+	tourEvents, err := w.tourism.GetFilteredTourEvents(ctx, &filter)
+	if err != nil {
+		return 0, err
+	}
+	seenPrices, err := w.repo.GetMatches(ctx, savedSearch.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	var seen []*entity.TourEvent
+	var notifications []*entity.Notification
+	for _, tourEvent := range uniqueTourEvents(tourEvents) {
+		oldPrice, known := seenPrices[tourEvent.ID]
+		switch {
+		case !known:
+			notifications = append(notifications, newSavedSearchNotification(savedSearch, tourEvent, entity.NotificationKindNewEvent, 0))
+		case tourEvent.Price < oldPrice:
+			notifications = append(notifications, newSavedSearchNotification(savedSearch, tourEvent, entity.NotificationKindPriceDrop, oldPrice))
+		case tourEvent.Price == oldPrice:
+			continue
+		}
+		seen = append(seen, tourEvent)
+	}
+
+	if err := w.repo.RecordRun(ctx, savedSearch, seen, notifications); err != nil {
+		return 0, err
+	}
+	return len(notifications), nil
+}
+
+func newSavedSearchNotification(savedSearch *entity.SavedSearch, tourEvent *entity.TourEvent, kind string, oldPrice float64) *entity.Notification {
+	savedSearchID := savedSearch.ID
+	notification := &entity.Notification{
+		UserID:        savedSearch.UserID,
+		SavedSearchID: &savedSearchID,
+		TourEventID:   tourEvent.ID,
+		Kind:          kind,
+		Price:         tourEvent.Price,
+		OldPrice:      oldPrice,
+	}
+
+	place := tourEvent.Place
+	date := tourEvent.Date.Format("2006-01-02")
+	switch kind {
+	case entity.NotificationKindPriceDrop:
+		notification.Message = fmt.Sprintf("Price dropped from %.2f to %.2f for %s on %s", oldPrice, tourEvent.Price, place, date)
+	default:
+		notification.Message = fmt.Sprintf("New date matching %q: %s on %s for %.2f", savedSearch.Name, place, date, tourEvent.Price)
+	}
+	return notification
+}
+
+// uniqueTourEvents drops duplicates produced by joining tours with several categories.
+func uniqueTourEvents(tourEvents []*entity.TourEvent) []*entity.TourEvent {
+	seen := make(map[uuid.UUID]struct{}, len(tourEvents))
+	unique := make([]*entity.TourEvent, 0, len(tourEvents))
+	for _, tourEvent := range tourEvents {
+		if _, ok := seen[tourEvent.ID]; ok {
+			continue
+		}
+		seen[tourEvent.ID] = struct{}{}
+		unique = append(unique, tourEvent)
+	}
+	return unique
+}
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS
+    tour_locations,
+    tour_categories,
+    categories,
+    purchases,
+    tour_events,
+    videos,
+    images,
+    tours,
+    users;
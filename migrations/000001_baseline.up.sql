@@ -0,0 +1,126 @@
+-- Baseline of the schema previously created by GORM AutoMigrate, before the migrations.
+-- Every migration is idempotent, so running them against a database created by
+-- AutoMigrate only adds what is missing and records the version.
+
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE IF NOT EXISTS users (
+    id         uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    username   text        NOT NULL,
+    email      text        NOT NULL,
+    password   text        NOT NULL,
+    role       text        NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT uni_users_username UNIQUE (username),
+    CONSTRAINT uni_users_email UNIQUE (email)
+);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE IF NOT EXISTS tours (
+    id          uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    deleted_at  timestamptz,
+    description text,
+    route       text,
+    owner_id    uuid,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_users_created_tours FOREIGN KEY (owner_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_tours_deleted_at ON tours (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_tours_owner_id ON tours (owner_id);
+
+CREATE TABLE IF NOT EXISTS images (
+    id         uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    tour_id    uuid,
+    image_url  text,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_tours_tour_images FOREIGN KEY (tour_id) REFERENCES tours (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_images_deleted_at ON images (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_images_tour_id ON images (tour_id);
+
+CREATE TABLE IF NOT EXISTS videos (
+    id         uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    tour_id    uuid,
+    video_url  text,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_tours_tour_videos FOREIGN KEY (tour_id) REFERENCES tours (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_videos_deleted_at ON videos (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_videos_tour_id ON videos (tour_id);
+
+CREATE TABLE IF NOT EXISTS tour_events (
+    id               uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    created_at       timestamptz,
+    updated_at       timestamptz,
+    deleted_at       timestamptz,
+    date             timestamptz NOT NULL,
+    price            numeric     NOT NULL,
+    place            text        NOT NULL,
+    amount_of_places numeric     NOT NULL,
+    is_opened        boolean     NOT NULL DEFAULT true,
+    tour_id          uuid,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_tours_tour_events FOREIGN KEY (tour_id) REFERENCES tours (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_tour_events_deleted_at ON tour_events (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_tour_events_tour_id ON tour_events (tour_id);
+
+CREATE TABLE IF NOT EXISTS purchases (
+    id            uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    created_at    timestamptz,
+    updated_at    timestamptz,
+    deleted_at    timestamptz,
+    user_id       uuid,
+    tour_event_id uuid,
+    status        text,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_users_purchased_tour_events FOREIGN KEY (user_id) REFERENCES users (id),
+    CONSTRAINT fk_tour_events_purchases FOREIGN KEY (tour_event_id) REFERENCES tour_events (id)
+);
+CREATE INDEX IF NOT EXISTS idx_purchases_deleted_at ON purchases (deleted_at);
+
+-- AutoMigrate only created categories as a dependency of tour_categories.
+CREATE TABLE IF NOT EXISTS categories (
+    id         uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name       text,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_categories_deleted_at ON categories (deleted_at);
+
+CREATE TABLE IF NOT EXISTS tour_categories (
+    category_id uuid NOT NULL,
+    tour_id     uuid NOT NULL,
+    PRIMARY KEY (category_id, tour_id),
+    CONSTRAINT fk_categories_tour_categories FOREIGN KEY (category_id) REFERENCES categories (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT fk_tours_tour_categories FOREIGN KEY (tour_id) REFERENCES tours (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_tour_categories_category_id ON tour_categories (category_id);
+CREATE INDEX IF NOT EXISTS idx_tour_categories_tour_id ON tour_categories (tour_id);
+
+CREATE TABLE IF NOT EXISTS tour_locations (
+    id         uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    tour_id    uuid,
+    latitude   numeric,
+    longitude  numeric,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_tour_locations_tour FOREIGN KEY (tour_id) REFERENCES tours (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_tour_locations_deleted_at ON tour_locations (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_tour_locations_tour_id ON tour_locations (tour_id);
@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS audit_events (
+    id          uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    seq         bigint      NOT NULL,
+    created_at  timestamptz NOT NULL,
+    actor_id    uuid,
+    actor_role  text,
+    action      text        NOT NULL,
+    resource    text        NOT NULL,
+    resource_id text,
+    before      text,
+    after       text,
+    diff        text,
+    ip          text,
+    request_id  text,
+    prev_hash   text        NOT NULL,
+    hash        text        NOT NULL,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_audit_events_seq ON audit_events (seq);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_audit_events_hash ON audit_events (hash);
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events (created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor_id ON audit_events (actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_action ON audit_events (action);
+CREATE INDEX IF NOT EXISTS idx_audit_events_resource ON audit_events (resource);
+CREATE INDEX IF NOT EXISTS idx_audit_events_resource_id ON audit_events (resource_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_request_id ON audit_events (request_id);
+
+-- Audit events are append-only: reject any UPDATE or DELETE at the database level.
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS review_photos, reviews;
+
+ALTER TABLE tours
+    DROP COLUMN IF EXISTS average_rating,
+    DROP COLUMN IF EXISTS review_count,
+    DROP COLUMN IF EXISTS rating_sum;
@@ -0,0 +1,37 @@
+ALTER TABLE tours
+    ADD COLUMN IF NOT EXISTS average_rating numeric NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS review_count bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS rating_sum bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS reviews (
+    id         uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    tour_id    uuid        NOT NULL,
+    user_id    uuid        NOT NULL,
+    rating     bigint      NOT NULL,
+    text       text,
+    status     text        NOT NULL DEFAULT 'published',
+    reply      text,
+    replied_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_reviews_tour FOREIGN KEY (tour_id) REFERENCES tours (id) ON DELETE CASCADE,
+    CONSTRAINT fk_reviews_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_reviews_deleted_at ON reviews (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_reviews_status ON reviews (status);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_review_tour_user ON reviews (tour_id, user_id);
+
+CREATE TABLE IF NOT EXISTS review_photos (
+    id         uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    review_id  uuid,
+    image_url  text,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_reviews_review_photos FOREIGN KEY (review_id) REFERENCES reviews (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_review_photos_deleted_at ON review_photos (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_review_photos_review_id ON review_photos (review_id);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS notifications, saved_search_matches, saved_searches, favorites;
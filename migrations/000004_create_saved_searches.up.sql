@@ -0,0 +1,54 @@
+CREATE TABLE IF NOT EXISTS favorites (
+    id         uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id    uuid        NOT NULL,
+    tour_id    uuid        NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_favorites_tour FOREIGN KEY (tour_id) REFERENCES tours (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_favorites_deleted_at ON favorites (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_favorite_user_tour ON favorites (user_id, tour_id);
+
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id          uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    deleted_at  timestamptz,
+    user_id     uuid        NOT NULL,
+    name        text,
+    filter      text        NOT NULL,
+    last_run_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_saved_searches_deleted_at ON saved_searches (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_saved_searches_user_id ON saved_searches (user_id);
+
+CREATE TABLE IF NOT EXISTS saved_search_matches (
+    saved_search_id uuid        NOT NULL,
+    tour_event_id   uuid        NOT NULL,
+    price           numeric     NOT NULL,
+    created_at      timestamptz,
+    updated_at      timestamptz,
+    PRIMARY KEY (saved_search_id, tour_event_id)
+);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id              uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    created_at      timestamptz,
+    updated_at      timestamptz,
+    deleted_at      timestamptz,
+    user_id         uuid        NOT NULL,
+    saved_search_id uuid,
+    tour_event_id   uuid,
+    kind            text        NOT NULL,
+    price           numeric,
+    old_price       numeric,
+    message         text,
+    read_at         timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_notifications_deleted_at ON notifications (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications (user_id);
+CREATE INDEX IF NOT EXISTS idx_notifications_saved_search_id ON notifications (saved_search_id);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_tour_locations_lat_lon;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_tour_locations_lat_lon ON tour_locations (latitude, longitude);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS route_tracks, route_stops;
+
+ALTER TABLE tours
+    DROP COLUMN IF EXISTS route_distance_km,
+    DROP COLUMN IF EXISTS route_elevation_gain_m;
@@ -0,0 +1,35 @@
+ALTER TABLE tours
+    ADD COLUMN IF NOT EXISTS route_distance_km numeric NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS route_elevation_gain_m numeric NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS route_stops (
+    id               uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    created_at       timestamptz,
+    updated_at       timestamptz,
+    deleted_at       timestamptz,
+    tour_id          uuid        NOT NULL,
+    position         bigint      NOT NULL,
+    name             text,
+    description      text,
+    latitude         numeric,
+    longitude        numeric,
+    elevation        numeric,
+    duration_minutes bigint,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_route_stops_tour FOREIGN KEY (tour_id) REFERENCES tours (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_route_stops_deleted_at ON route_stops (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_route_stops_tour_position ON route_stops (tour_id, position);
+
+CREATE TABLE IF NOT EXISTS route_tracks (
+    id         uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    tour_id    uuid        NOT NULL,
+    points     text        NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_route_tracks_tour FOREIGN KEY (tour_id) REFERENCES tours (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_route_tracks_deleted_at ON route_tracks (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_route_tracks_tour_id ON route_tracks (tour_id);
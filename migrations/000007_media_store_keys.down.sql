@@ -0,0 +1,3 @@
+UPDATE images SET image_url = './uploads/' || image_url WHERE image_url <> '' AND image_url NOT LIKE './uploads/%';
+UPDATE videos SET video_url = './uploads/' || video_url WHERE video_url <> '' AND video_url NOT LIKE './uploads/%';
+UPDATE review_photos SET image_url = './uploads/' || image_url WHERE image_url <> '' AND image_url NOT LIKE './uploads/%';
@@ -0,0 +1,4 @@
+-- Media columns used to hold local file paths, they now hold media store keys.
+UPDATE images SET image_url = substr(image_url, 11) WHERE image_url LIKE './uploads/%';
+UPDATE videos SET video_url = substr(video_url, 11) WHERE video_url LIKE './uploads/%';
+UPDATE review_photos SET image_url = substr(image_url, 11) WHERE image_url LIKE './uploads/%';
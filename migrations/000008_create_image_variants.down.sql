@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS image_variants;
+
+DROP INDEX IF EXISTS idx_images_status;
+ALTER TABLE images
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS width,
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS blurhash;
@@ -0,0 +1,23 @@
+ALTER TABLE images
+    ADD COLUMN IF NOT EXISTS status text NOT NULL DEFAULT 'pending',
+    ADD COLUMN IF NOT EXISTS width bigint,
+    ADD COLUMN IF NOT EXISTS height bigint,
+    ADD COLUMN IF NOT EXISTS blurhash text;
+CREATE INDEX IF NOT EXISTS idx_images_status ON images (status);
+
+CREATE TABLE IF NOT EXISTS image_variants (
+    id         uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    image_id   uuid,
+    kind       text,
+    format     text,
+    width      bigint,
+    height     bigint,
+    key        text,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_images_variants FOREIGN KEY (image_id) REFERENCES images (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_image_variants_deleted_at ON image_variants (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_image_variants_image_id ON image_variants (image_id);
@@ -0,0 +1,10 @@
+ALTER TABLE videos
+    DROP COLUMN IF EXISTS position,
+    DROP COLUMN IF EXISTS alt_text,
+    DROP COLUMN IF EXISTS caption;
+
+ALTER TABLE images
+    DROP COLUMN IF EXISTS position,
+    DROP COLUMN IF EXISTS is_cover,
+    DROP COLUMN IF EXISTS alt_text,
+    DROP COLUMN IF EXISTS caption;
@@ -0,0 +1,10 @@
+ALTER TABLE images
+    ADD COLUMN IF NOT EXISTS position bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS is_cover boolean NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS alt_text text,
+    ADD COLUMN IF NOT EXISTS caption text;
+
+ALTER TABLE videos
+    ADD COLUMN IF NOT EXISTS position bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS alt_text text,
+    ADD COLUMN IF NOT EXISTS caption text;
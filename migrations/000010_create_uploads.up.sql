@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS uploads (
+    id         uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    user_id    uuid        NOT NULL,
+    tour_id    uuid        NOT NULL,
+    length     bigint      NOT NULL,
+    "offset"   bigint      NOT NULL DEFAULT 0,
+    filename   text,
+    alt_text   text,
+    caption    text,
+    video_id   uuid,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_uploads_tour FOREIGN KEY (tour_id) REFERENCES tours (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_uploads_user_id ON uploads (user_id);
+CREATE INDEX IF NOT EXISTS idx_uploads_tour_id ON uploads (tour_id);
+CREATE INDEX IF NOT EXISTS idx_uploads_expires_at ON uploads (expires_at);
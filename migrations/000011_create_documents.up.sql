@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS documents (
+    id           uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    created_at   timestamptz,
+    updated_at   timestamptz,
+    deleted_at   timestamptz,
+    provider_id  uuid        NOT NULL,
+    tour_id      uuid,
+    kind         text        NOT NULL,
+    filename     text,
+    content_type text,
+    size         bigint,
+    key          text        NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_documents_tour FOREIGN KEY (tour_id) REFERENCES tours (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_documents_deleted_at ON documents (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_documents_provider_id ON documents (provider_id);
+CREATE INDEX IF NOT EXISTS idx_documents_tour_id ON documents (tour_id);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS media_blobs (
+    key          text        NOT NULL,
+    sha256       text        NOT NULL,
+    size         bigint,
+    content_type text,
+    ref_count    bigint      NOT NULL,
+    created_at   timestamptz,
+    updated_at   timestamptz,
+    PRIMARY KEY (key)
+);
+CREATE INDEX IF NOT EXISTS idx_media_blobs_sha256 ON media_blobs (sha256);
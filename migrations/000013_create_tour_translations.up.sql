@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS tour_translations (
+    id          uuid        NOT NULL DEFAULT uuid_generate_v4(),
+    resource    text        NOT NULL,
+    resource_id uuid        NOT NULL,
+    field       text        NOT NULL,
+    language    text        NOT NULL,
+    text        text,
+    source_hash text        NOT NULL,
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tour_translations_key ON tour_translations (resource, resource_id, field, language);
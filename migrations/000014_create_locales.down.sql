@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS category_locales, tour_locales;
+
+ALTER TABLE tours DROP COLUMN IF EXISTS language;
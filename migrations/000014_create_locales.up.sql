@@ -0,0 +1,23 @@
+ALTER TABLE tours ADD COLUMN IF NOT EXISTS language text;
+
+CREATE TABLE IF NOT EXISTS tour_locales (
+    tour_id     uuid        NOT NULL,
+    language    text        NOT NULL,
+    description text,
+    route       text,
+    source_hash text,
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    PRIMARY KEY (tour_id, language),
+    CONSTRAINT fk_tour_locales_tour FOREIGN KEY (tour_id) REFERENCES tours (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS category_locales (
+    category_id uuid        NOT NULL,
+    language    text        NOT NULL,
+    name        text,
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    PRIMARY KEY (category_id, language),
+    CONSTRAINT fk_category_locales_category FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE
+);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_categories_slug;
+DROP INDEX IF EXISTS idx_categories_parent_id;
+ALTER TABLE categories
+    DROP COLUMN IF EXISTS slug,
+    DROP COLUMN IF EXISTS icon,
+    DROP COLUMN IF EXISTS parent_id,
+    DROP COLUMN IF EXISTS sort_order;
@@ -0,0 +1,7 @@
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS slug text,
+    ADD COLUMN IF NOT EXISTS icon text,
+    ADD COLUMN IF NOT EXISTS parent_id uuid CONSTRAINT fk_categories_parent REFERENCES categories (id) ON DELETE RESTRICT,
+    ADD COLUMN IF NOT EXISTS sort_order bigint NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories (parent_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_slug ON categories (slug) WHERE slug <> '';
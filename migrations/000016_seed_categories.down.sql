@@ -0,0 +1,7 @@
+DELETE FROM categories
+WHERE slug IN (
+    'automotive', 'business', 'culture', 'education', 'entertainment-and-recreation',
+    'facilities', 'finance', 'food-and-drink', 'geographical-areas', 'government',
+    'health-and-wellness', 'housing', 'lodging', 'natural-features', 'places-of-worship',
+    'services', 'shopping', 'sports', 'transportation'
+);
@@ -0,0 +1,25 @@
+-- Default categories, only seeded into an empty table.
+INSERT INTO categories (name, slug, sort_order, created_at, updated_at)
+SELECT seed.name, seed.slug, seed.sort_order, now(), now()
+FROM (VALUES
+    ('Automotive', 'automotive', 1),
+    ('Business', 'business', 2),
+    ('Culture', 'culture', 3),
+    ('Education', 'education', 4),
+    ('Entertainment and Recreation', 'entertainment-and-recreation', 5),
+    ('Facilities', 'facilities', 6),
+    ('Finance', 'finance', 7),
+    ('Food and Drink', 'food-and-drink', 8),
+    ('Geographical Areas', 'geographical-areas', 9),
+    ('Government', 'government', 10),
+    ('Health and Wellness', 'health-and-wellness', 11),
+    ('Housing', 'housing', 12),
+    ('Lodging', 'lodging', 13),
+    ('Natural Features', 'natural-features', 14),
+    ('Places of Worship', 'places-of-worship', 15),
+    ('Services', 'services', 16),
+    ('Shopping', 'shopping', 17),
+    ('Sports', 'sports', 18),
+    ('Transportation', 'transportation', 19)
+) AS seed (name, slug, sort_order)
+WHERE NOT EXISTS (SELECT 1 FROM categories);
@@ -0,0 +1,12 @@
+// Package migrations embeds the versioned SQL migrations of the database schema.
+//
+// Files are named <version>_<name>.up.sql and <version>_<name>.down.sql, create them with
+// make migrate-create and apply them with the migrate subcommand of the app.
+package migrations
+
+import "embed"
+
+// FS holds the migration files.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,57 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"tourism-backend/internal/usecase"
+	"tourism-backend/pkg/logger"
+)
+
+// SavedSearchAlerter periodically re-runs saved searches and notifies their owners.
+type SavedSearchAlerter struct {
+	interval        time.Duration
+	wishlistUsecase usecase.WishlistInterface
+	l               logger.Interface
+	ctx             context.Context
+	cancel          context.CancelFunc
+}
+
+func NewSavedSearchAlerter(interval time.Duration, usecase usecase.WishlistInterface, l logger.Interface) *SavedSearchAlerter {
+	a := &SavedSearchAlerter{
+		interval:        interval,
+		wishlistUsecase: usecase,
+		l:               l,
+	}
+	a.ctx, a.cancel = context.WithCancel(context.Background())
+
+	// Start the worker goroutine
+	go a.Run()
+
+	return a
+}
+
+func (a *SavedSearchAlerter) Run() {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			created, err := a.wishlistUsecase.RunSavedSearches(a.ctx)
+			if err != nil {
+				a.l.Error(fmt.Errorf("alerts - RunSavedSearches: %w", err))
+			}
+			if created > 0 {
+				a.l.Info("alerts - saved search alerts: %d notifications created", created)
+			}
+		case <-a.ctx.Done():
+			return
+		}
+	}
+}
+
+// Stop stops the worker and cancels the run in progress. It is safe to call more than once.
+func (a *SavedSearchAlerter) Stop() {
+	a.cancel()
+}
@@ -0,0 +1,125 @@
+// Package geo implements distance calculations and GeoJSON types.
+package geo
+
+import "math"
+
+// EarthRadiusKm is the mean Earth radius used by Haversine.
+const EarthRadiusKm = 6371.0
+
+// Point -.
+type Point struct {
+	Latitude  float64
+	Longitude float64
+	// Elevation in meters, nil when unknown.
+	Elevation *float64
+}
+
+// BoundingBox -.
+// MinLongitude may be greater than MaxLongitude when the box crosses the antimeridian.
+type BoundingBox struct {
+	MinLatitude  float64
+	MinLongitude float64
+	MaxLatitude  float64
+	MaxLongitude float64
+}
+
+// Haversine returns the great-circle distance between two points in kilometers.
+func Haversine(a, b Point) float64 {
+	lat1 := radians(a.Latitude)
+	lat2 := radians(b.Latitude)
+	dLat := radians(b.Latitude - a.Latitude)
+	dLon := radians(b.Longitude - a.Longitude)
+
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// PathDistanceKm returns the length of the polyline through points in kilometers.
+func PathDistanceKm(points []Point) float64 {
+	total := 0.0
+	for i := 1; i < len(points); i++ {
+		total += Haversine(points[i-1], points[i])
+	}
+	return total
+}
+
+// ElevationGainM returns the cumulative ascent along points in meters.
+// Points without elevation are skipped.
+func ElevationGainM(points []Point) float64 {
+	gain := 0.0
+	var last *float64
+	for _, p := range points {
+		if p.Elevation == nil {
+			continue
+		}
+		if last != nil && *p.Elevation > *last {
+			gain += *p.Elevation - *last
+		}
+		last = p.Elevation
+	}
+	return gain
+}
+
+// BoundingBoxAround returns a box that contains every point within radiusKm of center.
+// It is used to narrow candidates before the exact Haversine check.
+func BoundingBoxAround(center Point, radiusKm float64) BoundingBox {
+	dLat := degrees(radiusKm / EarthRadiusKm)
+	box := BoundingBox{
+		MinLatitude: math.Max(-90, center.Latitude-dLat),
+		MaxLatitude: math.Min(90, center.Latitude+dLat),
+	}
+
+	// Near the poles every longitude can be within the radius.
+	cosLat := math.Cos(radians(center.Latitude))
+	if box.MinLatitude == -90 || box.MaxLatitude == 90 || cosLat < 1e-9 {
+		box.MinLongitude, box.MaxLongitude = -180, 180
+		return box
+	}
+
+	dLon := degrees(math.Asin(math.Min(1, math.Sin(radiusKm/EarthRadiusKm)/cosLat)))
+	box.MinLongitude = normalizeLongitude(center.Longitude - dLon)
+	box.MaxLongitude = normalizeLongitude(center.Longitude + dLon)
+	if dLon >= 180 {
+		box.MinLongitude, box.MaxLongitude = -180, 180
+	}
+	return box
+}
+
+// Contains reports whether the point lies inside the box.
+func (b BoundingBox) Contains(p Point) bool {
+	if p.Latitude < b.MinLatitude || p.Latitude > b.MaxLatitude {
+		return false
+	}
+	if b.CrossesAntimeridian() {
+		return p.Longitude >= b.MinLongitude || p.Longitude <= b.MaxLongitude
+	}
+	return p.Longitude >= b.MinLongitude && p.Longitude <= b.MaxLongitude
+}
+
+// CrossesAntimeridian -.
+func (b BoundingBox) CrossesAntimeridian() bool {
+	return b.MinLongitude > b.MaxLongitude
+}
+
+// ValidPoint reports whether latitude and longitude are within their ranges.
+func ValidPoint(p Point) bool {
+	return p.Latitude >= -90 && p.Latitude <= 90 && p.Longitude >= -180 && p.Longitude <= 180
+}
+
+func normalizeLongitude(lon float64) float64 {
+	for lon > 180 {
+		lon -= 360
+	}
+	for lon < -180 {
+		lon += 360
+	}
+	return lon
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
@@ -0,0 +1,38 @@
+package geo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"tourism-backend/pkg/geo"
+)
+
+var (
+	almaty = geo.Point{Latitude: 43.2389, Longitude: 76.8897}
+	astana = geo.Point{Latitude: 51.1605, Longitude: 71.4704}
+)
+
+func TestHaversine(t *testing.T) {
+	t.Parallel()
+
+	require.InDelta(t, 0, geo.Haversine(almaty, almaty), 1e-9)
+	require.InDelta(t, 970, geo.Haversine(almaty, astana), 10)
+	require.InDelta(t, geo.Haversine(almaty, astana), geo.Haversine(astana, almaty), 1e-9)
+}
+
+func TestBoundingBoxAround(t *testing.T) {
+	t.Parallel()
+
+	box := geo.BoundingBoxAround(almaty, 1000)
+	require.True(t, box.Contains(astana))
+	require.False(t, geo.BoundingBoxAround(almaty, 500).Contains(astana))
+
+	edge := geo.BoundingBoxAround(geo.Point{Latitude: 0, Longitude: 179.9}, 50)
+	require.True(t, edge.CrossesAntimeridian())
+	require.True(t, edge.Contains(geo.Point{Latitude: 0, Longitude: -179.9}))
+
+	polar := geo.BoundingBoxAround(geo.Point{Latitude: 89.9, Longitude: 0}, 50)
+	require.Equal(t, -180.0, polar.MinLongitude)
+	require.Equal(t, 180.0, polar.MaxLongitude)
+}
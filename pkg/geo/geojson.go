@@ -0,0 +1,73 @@
+package geo
+
+// GeoJSON geometry and object types (RFC 7946).
+const (
+	TypePoint             = "Point"
+	TypeLineString        = "LineString"
+	TypeFeature           = "Feature"
+	TypeFeatureCollection = "FeatureCollection"
+)
+
+// Geometry -.
+// Coordinates are [longitude, latitude] for a Point and a list of positions for a LineString.
+type Geometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// Feature -.
+type Feature struct {
+	Type       string                 `json:"type"`
+	ID         string                 `json:"id,omitempty"`
+	Geometry   *Geometry              `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// FeatureCollection -.
+type FeatureCollection struct {
+	Type     string     `json:"type"`
+	Features []*Feature `json:"features"`
+}
+
+// NewPointGeometry -.
+func NewPointGeometry(p Point) *Geometry {
+	return &Geometry{Type: TypePoint, Coordinates: Position(p)}
+}
+
+// NewLineStringGeometry -.
+func NewLineStringGeometry(points []Point) *Geometry {
+	coordinates := make([][]float64, 0, len(points))
+	for _, p := range points {
+		coordinates = append(coordinates, Position(p))
+	}
+	return &Geometry{Type: TypeLineString, Coordinates: coordinates}
+}
+
+// Position returns the GeoJSON position of p: [longitude, latitude] or [longitude, latitude, elevation].
+func Position(p Point) []float64 {
+	if p.Elevation != nil {
+		return []float64{p.Longitude, p.Latitude, *p.Elevation}
+	}
+	return []float64{p.Longitude, p.Latitude}
+}
+
+// PointFromPosition is the inverse of Position.
+func PointFromPosition(position []float64) (Point, bool) {
+	if len(position) < 2 || len(position) > 3 {
+		return Point{}, false
+	}
+	p := Point{Longitude: position[0], Latitude: position[1]}
+	if len(position) == 3 {
+		elevation := position[2]
+		p.Elevation = &elevation
+	}
+	return p, ValidPoint(p)
+}
+
+// NewFeatureCollection -.
+func NewFeatureCollection(features ...*Feature) *FeatureCollection {
+	if features == nil {
+		features = []*Feature{}
+	}
+	return &FeatureCollection{Type: TypeFeatureCollection, Features: features}
+}
@@ -0,0 +1,170 @@
+package geo
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrEmptyRoute is returned when an imported file has neither waypoints nor a track.
+var ErrEmptyRoute = errors.New("file contains no waypoints or track points")
+
+// Waypoint is a named stop of a route.
+type Waypoint struct {
+	Point
+	Name        string
+	Description string
+}
+
+// Route is an ordered list of waypoints with an optional track polyline.
+type Route struct {
+	Name      string
+	Waypoints []Waypoint
+	Track     []Point
+}
+
+type gpxDocument struct {
+	XMLName   xml.Name     `xml:"gpx"`
+	Version   string       `xml:"version,attr"`
+	Creator   string       `xml:"creator,attr"`
+	Xmlns     string       `xml:"xmlns,attr,omitempty"`
+	Metadata  *gpxMetadata `xml:"metadata,omitempty"`
+	Waypoints []gpxPoint   `xml:"wpt"`
+	Routes    []gpxRoute   `xml:"rte"`
+	Tracks    []gpxTrack   `xml:"trk"`
+}
+
+type gpxMetadata struct {
+	Name string `xml:"name,omitempty"`
+}
+
+type gpxPoint struct {
+	Latitude    float64  `xml:"lat,attr"`
+	Longitude   float64  `xml:"lon,attr"`
+	Elevation   *float64 `xml:"ele,omitempty"`
+	Name        string   `xml:"name,omitempty"`
+	Description string   `xml:"desc,omitempty"`
+}
+
+type gpxRoute struct {
+	Name   string     `xml:"name,omitempty"`
+	Points []gpxPoint `xml:"rtept"`
+}
+
+type gpxTrack struct {
+	Name     string       `xml:"name,omitempty"`
+	Segments []gpxSegment `xml:"trkseg"`
+}
+
+type gpxSegment struct {
+	Points []gpxPoint `xml:"trkpt"`
+}
+
+// ParseGPX reads waypoints and tracks from a GPX 1.0/1.1 document.
+// Route points are used as stops when there are no waypoints, and as the track when there is no track.
+func ParseGPX(r io.Reader) (*Route, error) {
+	var doc gpxDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parse gpx: %w", err)
+	}
+
+	route := &Route{}
+	if doc.Metadata != nil {
+		route.Name = doc.Metadata.Name
+	}
+
+	var routePoints []gpxPoint
+	for _, rte := range doc.Routes {
+		routePoints = append(routePoints, rte.Points...)
+		if route.Name == "" {
+			route.Name = rte.Name
+		}
+	}
+
+	stops := doc.Waypoints
+	if len(stops) == 0 {
+		stops = routePoints
+	}
+	for _, p := range stops {
+		point := p.point()
+		if !ValidPoint(point) {
+			return nil, fmt.Errorf("parse gpx: invalid coordinate %f,%f", p.Latitude, p.Longitude)
+		}
+		route.Waypoints = append(route.Waypoints, Waypoint{Point: point, Name: p.Name, Description: p.Description})
+	}
+
+	for _, trk := range doc.Tracks {
+		if route.Name == "" {
+			route.Name = trk.Name
+		}
+		for _, seg := range trk.Segments {
+			for _, p := range seg.Points {
+				route.Track = append(route.Track, p.point())
+			}
+		}
+	}
+	if len(route.Track) == 0 && len(doc.Waypoints) > 0 {
+		for _, p := range routePoints {
+			route.Track = append(route.Track, p.point())
+		}
+	}
+	for _, p := range route.Track {
+		if !ValidPoint(p) {
+			return nil, fmt.Errorf("parse gpx: invalid coordinate %f,%f", p.Latitude, p.Longitude)
+		}
+	}
+
+	if len(route.Waypoints) == 0 && len(route.Track) == 0 {
+		return nil, ErrEmptyRoute
+	}
+	return route, nil
+}
+
+// WriteGPX writes the route as a GPX 1.1 document with waypoints and a single track.
+func WriteGPX(w io.Writer, route *Route) error {
+	doc := gpxDocument{
+		Version: "1.1",
+		Creator: "tourism-backend",
+		Xmlns:   "http://www.topografix.com/GPX/1/1",
+	}
+	if route.Name != "" {
+		doc.Metadata = &gpxMetadata{Name: route.Name}
+	}
+	for _, wpt := range route.Waypoints {
+		doc.Waypoints = append(doc.Waypoints, gpxPoint{
+			Latitude:    wpt.Latitude,
+			Longitude:   wpt.Longitude,
+			Elevation:   wpt.Elevation,
+			Name:        wpt.Name,
+			Description: wpt.Description,
+		})
+	}
+	if len(route.Track) > 0 {
+		segment := gpxSegment{Points: make([]gpxPoint, 0, len(route.Track))}
+		for _, p := range route.Track {
+			segment.Points = append(segment.Points, gpxPoint{Latitude: p.Latitude, Longitude: p.Longitude, Elevation: p.Elevation})
+		}
+		doc.Tracks = []gpxTrack{{Name: route.Name, Segments: []gpxSegment{segment}}}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("write gpx: %w", err)
+	}
+	return nil
+}
+
+func (p gpxPoint) point() Point {
+	return Point{Latitude: p.Latitude, Longitude: p.Longitude, Elevation: p.Elevation}
+}
+
+// IsGPX reports whether the file name looks like a GPX file.
+func IsGPX(filename string) bool {
+	return strings.HasSuffix(strings.ToLower(filename), ".gpx")
+}
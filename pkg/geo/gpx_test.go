@@ -0,0 +1,72 @@
+package geo_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"tourism-backend/pkg/geo"
+)
+
+const testGPX = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="test" xmlns="http://www.topografix.com/GPX/1/1">
+  <metadata><name>Big Almaty Lake</name></metadata>
+  <wpt lat="43.2389" lon="76.8897"><ele>800</ele><name>Start</name></wpt>
+  <wpt lat="43.0506" lon="76.9850"><ele>2500</ele><name>Lake</name><desc>Viewpoint</desc></wpt>
+  <trk><trkseg>
+    <trkpt lat="43.2389" lon="76.8897"><ele>800</ele></trkpt>
+    <trkpt lat="43.1500" lon="76.9300"><ele>1600</ele></trkpt>
+    <trkpt lat="43.1000" lon="76.9500"><ele>1500</ele></trkpt>
+    <trkpt lat="43.0506" lon="76.9850"><ele>2500</ele></trkpt>
+  </trkseg></trk>
+</gpx>`
+
+const testKML = `<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2">
+  <Document>
+    <name>Charyn</name>
+    <Folder>
+      <name>Stops</name>
+      <Placemark><name>Parking</name><Point><coordinates>79.0800,43.3500,1200</coordinates></Point></Placemark>
+    </Folder>
+    <Placemark><name>Path</name><LineString><coordinates>
+      79.0800,43.3500,1200 79.0900,43.3600,1100
+    </coordinates></LineString></Placemark>
+  </Document>
+</kml>`
+
+func TestParseGPX(t *testing.T) {
+	t.Parallel()
+
+	route, err := geo.ParseGPX(strings.NewReader(testGPX))
+	require.NoError(t, err)
+	require.Equal(t, "Big Almaty Lake", route.Name)
+	require.Len(t, route.Waypoints, 2)
+	require.Equal(t, "Viewpoint", route.Waypoints[1].Description)
+	require.Len(t, route.Track, 4)
+	require.InDelta(t, 1800, geo.ElevationGainM(route.Track), 1e-9)
+	require.Greater(t, geo.PathDistanceKm(route.Track), geo.Haversine(route.Track[0], route.Track[3]))
+
+	var buf bytes.Buffer
+	require.NoError(t, geo.WriteGPX(&buf, route))
+	roundTrip, err := geo.ParseGPX(&buf)
+	require.NoError(t, err)
+	require.Equal(t, route, roundTrip)
+
+	_, err = geo.ParseGPX(strings.NewReader(`<gpx version="1.1"></gpx>`))
+	require.ErrorIs(t, err, geo.ErrEmptyRoute)
+}
+
+func TestParseKML(t *testing.T) {
+	t.Parallel()
+
+	route, err := geo.ParseKML(strings.NewReader(testKML))
+	require.NoError(t, err)
+	require.Equal(t, "Charyn", route.Name)
+	require.Len(t, route.Waypoints, 1)
+	require.Equal(t, "Parking", route.Waypoints[0].Name)
+	require.Len(t, route.Track, 2)
+	require.InDelta(t, 0, geo.ElevationGainM(route.Track), 1e-9)
+}
@@ -0,0 +1,148 @@
+package geo
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type kmlPlacemark struct {
+	Name          string            `xml:"name"`
+	Description   string            `xml:"description"`
+	Point         *kmlCoordinates   `xml:"Point"`
+	LineString    *kmlCoordinates   `xml:"LineString"`
+	MultiGeometry *kmlMultiGeometry `xml:"MultiGeometry"`
+}
+
+type kmlMultiGeometry struct {
+	Points      []kmlCoordinates `xml:"Point"`
+	LineStrings []kmlCoordinates `xml:"LineString"`
+}
+
+type kmlCoordinates struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+// ParseKML reads point placemarks as waypoints and line strings as the track.
+// Placemarks are collected at any depth, so documents with nested folders are supported.
+func ParseKML(r io.Reader) (*Route, error) {
+	route := &Route{}
+	dec := xml.NewDecoder(r)
+	var parents []string
+
+	for {
+		token, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse kml: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			parent := ""
+			if len(parents) > 0 {
+				parent = parents[len(parents)-1]
+			}
+			switch {
+			case t.Name.Local == "Placemark":
+				var placemark kmlPlacemark
+				if err := dec.DecodeElement(&placemark, &t); err != nil {
+					return nil, fmt.Errorf("parse kml: %w", err)
+				}
+				if err := route.addPlacemark(&placemark); err != nil {
+					return nil, err
+				}
+			case t.Name.Local == "name" && parent == "Document" && route.Name == "":
+				var name string
+				if err := dec.DecodeElement(&name, &t); err != nil {
+					return nil, fmt.Errorf("parse kml: %w", err)
+				}
+				route.Name = strings.TrimSpace(name)
+			default:
+				parents = append(parents, t.Name.Local)
+			}
+		case xml.EndElement:
+			if len(parents) > 0 {
+				parents = parents[:len(parents)-1]
+			}
+		}
+	}
+
+	if len(route.Waypoints) == 0 && len(route.Track) == 0 {
+		return nil, ErrEmptyRoute
+	}
+	return route, nil
+}
+
+func (route *Route) addPlacemark(placemark *kmlPlacemark) error {
+	points := make([]kmlCoordinates, 0, 1)
+	lines := make([]kmlCoordinates, 0, 1)
+	if placemark.Point != nil {
+		points = append(points, *placemark.Point)
+	}
+	if placemark.LineString != nil {
+		lines = append(lines, *placemark.LineString)
+	}
+	if placemark.MultiGeometry != nil {
+		points = append(points, placemark.MultiGeometry.Points...)
+		lines = append(lines, placemark.MultiGeometry.LineStrings...)
+	}
+
+	for _, p := range points {
+		coordinates, err := parseKMLCoordinates(p.Coordinates)
+		if err != nil {
+			return err
+		}
+		for _, c := range coordinates {
+			route.Waypoints = append(route.Waypoints, Waypoint{
+				Point:       c,
+				Name:        strings.TrimSpace(placemark.Name),
+				Description: strings.TrimSpace(placemark.Description),
+			})
+		}
+	}
+	for _, l := range lines {
+		coordinates, err := parseKMLCoordinates(l.Coordinates)
+		if err != nil {
+			return err
+		}
+		route.Track = append(route.Track, coordinates...)
+	}
+	return nil
+}
+
+// parseKMLCoordinates parses whitespace-separated "lon,lat[,alt]" tuples.
+func parseKMLCoordinates(s string) ([]Point, error) {
+	fields := strings.Fields(s)
+	points := make([]Point, 0, len(fields))
+	for _, tuple := range fields {
+		parts := strings.Split(tuple, ",")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("parse kml: invalid coordinate %q", tuple)
+		}
+		position := make([]float64, 0, len(parts))
+		for _, part := range parts {
+			v, err := strconv.ParseFloat(part, 64)
+			if err != nil {
+				return nil, fmt.Errorf("parse kml: invalid coordinate %q", tuple)
+			}
+			position = append(position, v)
+		}
+		p, ok := PointFromPosition(position)
+		if !ok {
+			return nil, fmt.Errorf("parse kml: invalid coordinate %q", tuple)
+		}
+		points = append(points, p)
+	}
+	return points, nil
+}
+
+// IsKML reports whether the file name looks like a KML file.
+func IsKML(filename string) bool {
+	return strings.HasSuffix(strings.ToLower(filename), ".kml")
+}
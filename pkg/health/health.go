@@ -0,0 +1,131 @@
+// Package health implements the readiness checks of the dependencies the service needs to serve requests.
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const _defaultTimeout = 2 * time.Second
+
+// Statuses of checks and reports.
+const (
+	StatusOK       = "ok"
+	StatusFail     = "fail"
+	StatusDraining = "draining"
+)
+
+// errDraining fails every check once the service is shutting down.
+var errDraining = errors.New("shutting down")
+
+// Check returns an error when a dependency is not usable. It should return once ctx is done.
+type Check func(ctx context.Context) error
+
+// Result is the outcome of a check.
+type Result struct {
+	Status     string `json:"status" example:"ok"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms" example:"3"`
+}
+
+// Report is the outcome of the readiness checks, the service is ready when Status is ok.
+type Report struct {
+	Status string            `json:"status" example:"ok"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Ready reports whether every check passed.
+func (r Report) Ready() bool {
+	return r.Status == StatusOK
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Readiness runs the checks of the dependencies. Once Drain is called it reports the service
+// as draining without running them, so load balancers stop sending requests before shutdown.
+type Readiness struct {
+	mu       sync.RWMutex
+	checks   []namedCheck
+	draining atomic.Bool
+	timeout  time.Duration
+}
+
+// New -.
+func New(opts ...Option) *Readiness {
+	r := &Readiness{timeout: _defaultTimeout}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Add registers check under name.
+func (r *Readiness) Add(name string, check Check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, namedCheck{name: name, check: check})
+}
+
+// Drain makes the service report as not ready from now on.
+func (r *Readiness) Drain() {
+	r.draining.Store(true)
+}
+
+// Check runs the checks concurrently, each bounded by the check timeout.
+func (r *Readiness) Check(ctx context.Context) Report {
+	r.mu.RLock()
+	checks := append([]namedCheck(nil), r.checks...)
+	r.mu.RUnlock()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(checks))}
+	if r.draining.Load() {
+		report.Status = StatusDraining
+		for _, c := range checks {
+			report.Checks[c.name] = Result{Status: StatusFail, Error: errDraining.Error()}
+		}
+		return report
+	}
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.run(ctx, c.check)
+		}()
+	}
+	wg.Wait()
+
+	for i, c := range checks {
+		report.Checks[c.name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+	return report
+}
+
+func (r *Readiness) run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	result := Result{Status: StatusOK, DurationMs: time.Since(start).Milliseconds()}
+	// A check that only passed after the timeout is too slow to count on.
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
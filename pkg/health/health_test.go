@@ -0,0 +1,53 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"tourism-backend/pkg/health"
+)
+
+func TestReadinessCheck(t *testing.T) {
+	t.Parallel()
+
+	readiness := health.New(health.Timeout(50 * time.Millisecond))
+	readiness.Add("postgres", func(context.Context) error { return nil })
+	report := readiness.Check(context.Background())
+	require.True(t, report.Ready())
+	require.Equal(t, health.StatusOK, report.Checks["postgres"].Status)
+
+	readiness.Add("payment_worker", func(context.Context) error { return errors.New("payment worker stopped") })
+	readiness.Add("rabbitmq", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	report = readiness.Check(context.Background())
+	require.False(t, report.Ready())
+	require.Equal(t, health.StatusFail, report.Status)
+	require.Equal(t, health.StatusOK, report.Checks["postgres"].Status)
+	require.Equal(t, health.StatusFail, report.Checks["payment_worker"].Status)
+	require.Equal(t, "payment worker stopped", report.Checks["payment_worker"].Error)
+	require.Equal(t, context.DeadlineExceeded.Error(), report.Checks["rabbitmq"].Error)
+}
+
+func TestReadinessDrain(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	readiness := health.New()
+	readiness.Add("postgres", func(context.Context) error {
+		called = true
+		return nil
+	})
+	readiness.Drain()
+
+	report := readiness.Check(context.Background())
+	require.False(t, report.Ready())
+	require.Equal(t, health.StatusDraining, report.Status)
+	require.Equal(t, health.StatusFail, report.Checks["postgres"].Status)
+	require.False(t, called, "checks do not run while draining")
+}
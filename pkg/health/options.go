@@ -0,0 +1,13 @@
+package health
+
+import "time"
+
+// Option -.
+type Option func(*Readiness)
+
+// Timeout bounds the time a single check may take.
+func Timeout(timeout time.Duration) Option {
+	return func(r *Readiness) {
+		r.timeout = timeout
+	}
+}
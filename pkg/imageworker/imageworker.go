@@ -0,0 +1,123 @@
+package imageworker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"tourism-backend/internal/usecase"
+	"tourism-backend/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// _sweepBatch is the number of pending images picked up by one sweep.
+const _sweepBatch = 100
+
+// ImageProcessor builds image variants in the background, so uploads return as soon as the files are stored.
+// Images missed by the in-memory queue (full queue, restart) are picked up by a periodic sweep of pending images.
+type ImageProcessor struct {
+	queue         chan uuid.UUID
+	sweepInterval time.Duration
+	imageUsecase  usecase.ImageInterface
+	l             logger.Interface
+
+	mu       sync.Mutex
+	inFlight map[uuid.UUID]struct{}
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewImageProcessor(bufferSize, workers int, sweepInterval time.Duration, usecase usecase.ImageInterface, l logger.Interface) *ImageProcessor {
+	p := &ImageProcessor{
+		queue:         make(chan uuid.UUID, bufferSize),
+		sweepInterval: sweepInterval,
+		imageUsecase:  usecase,
+		l:             l,
+		inFlight:      make(map[uuid.UUID]struct{}),
+	}
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+
+	// Start the worker goroutines
+	for i := 0; i < workers; i++ {
+		p.wg.Add(1)
+		go p.ProcessImages()
+	}
+	p.wg.Add(1)
+	go p.Sweep()
+
+	return p
+}
+
+// Enqueue schedules images for processing without blocking the caller.
+func (p *ImageProcessor) Enqueue(imageIDs ...uuid.UUID) {
+	for _, id := range imageIDs {
+		p.mu.Lock()
+		if _, ok := p.inFlight[id]; ok {
+			p.mu.Unlock()
+			continue
+		}
+		p.inFlight[id] = struct{}{}
+		p.mu.Unlock()
+
+		select {
+		case p.queue <- id:
+		default:
+			// Queue is full, the next sweep picks the image up.
+			p.release(id)
+		}
+	}
+}
+
+func (p *ImageProcessor) ProcessImages() {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case id := <-p.queue:
+			if err := p.imageUsecase.ProcessImage(p.ctx, id); err != nil {
+				p.l.Error(fmt.Errorf("imageworker - ProcessImage %s: %w", id, err))
+			}
+			p.release(id)
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+// Sweep enqueues pending images at start and then every sweep interval.
+func (p *ImageProcessor) Sweep() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		ids, err := p.imageUsecase.GetPendingImageIDs(p.ctx, _sweepBatch)
+		if err != nil {
+			p.l.Error(fmt.Errorf("imageworker - GetPendingImageIDs: %w", err))
+		}
+		p.Enqueue(ids...)
+
+		select {
+		case <-ticker.C:
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+// Stop stops the workers and waits for them to return. Images being processed are interrupted
+// and stay pending for the next sweep. It is safe to call more than once.
+func (p *ImageProcessor) Stop() {
+	p.cancel()
+	p.wg.Wait()
+}
+
+func (p *ImageProcessor) release(id uuid.UUID) {
+	p.mu.Lock()
+	delete(p.inFlight, id)
+	p.mu.Unlock()
+}
@@ -0,0 +1,246 @@
+// Package imaging builds the web variants of uploaded images.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // register the GIF decoder
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/buckket/go-blurhash"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // register the WebP decoder
+)
+
+// Output formats.
+const (
+	FormatJPEG = "jpeg"
+	FormatWebP = "webp"
+	FormatPNG  = "png"
+	FormatGIF  = "gif"
+)
+
+// Variant kinds.
+const (
+	KindThumbnail  = "thumbnail"
+	KindResponsive = "responsive"
+)
+
+const (
+	_defaultJPEGQuality    = 82
+	_originalJPEGQuality   = 90
+	_defaultThumbnailWidth = 320
+	_defaultMaxPixels      = 40_000_000
+	_blurhashWidth         = 32
+	_blurhashXComponents   = 4
+	_blurhashYComponents   = 3
+)
+
+// DefaultWidths are the responsive widths generated when Options.Widths is empty.
+var DefaultWidths = []int{640, 1024, 1600}
+
+var (
+	// ErrUnsupportedFormat is returned for images that cannot be decoded.
+	ErrUnsupportedFormat = errors.New("imaging: unsupported image format")
+	// ErrTooManyPixels is returned for images whose dimensions exceed Options.MaxPixels.
+	ErrTooManyPixels = errors.New("imaging: image has too many pixels")
+)
+
+// Options -.
+type Options struct {
+	// Widths are the responsive JPEG widths; widths not smaller than the original are skipped.
+	Widths []int
+	// ThumbnailWidth is the width of the JPEG and WebP thumbnails.
+	ThumbnailWidth int
+	// JPEGQuality is used for every generated JPEG variant.
+	JPEGQuality int
+	// MaxPixels bounds width times height of the accepted images. A small file can declare
+	// huge dimensions, and decoding allocates memory for all of its pixels.
+	MaxPixels int64
+}
+
+// Variant is an encoded resized copy of the image.
+type Variant struct {
+	Kind        string
+	Format      string
+	ContentType string
+	Width       int
+	Height      int
+	Data        []byte
+}
+
+// Result -.
+type Result struct {
+	// Width and Height are the dimensions after EXIF orientation is applied.
+	Width  int
+	Height int
+	// Blurhash is a compact placeholder shown while the image loads.
+	Blurhash string
+	// Original is the re-encoded upload without metadata, nil when the upload carries none (GIF).
+	Original            []byte
+	OriginalContentType string
+	Variants            []Variant
+}
+
+// Process decodes an uploaded image, applies its EXIF orientation and builds metadata-free
+// variants. Re-encoding drops every EXIF, XMP and GPS block of the upload.
+func Process(r io.Reader, opts Options) (*Result, error) {
+	if len(opts.Widths) == 0 {
+		opts.Widths = DefaultWidths
+	}
+	if opts.ThumbnailWidth <= 0 {
+		opts.ThumbnailWidth = _defaultThumbnailWidth
+	}
+	if opts.JPEGQuality <= 0 {
+		opts.JPEGQuality = _defaultJPEGQuality
+	}
+	if opts.MaxPixels <= 0 {
+		opts.MaxPixels = _defaultMaxPixels
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("imaging - read: %w", err)
+	}
+	// The header is checked before the pixels are decoded.
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+	if int64(config.Width)*int64(config.Height) > opts.MaxPixels {
+		return nil, fmt.Errorf("%w: %dx%d", ErrTooManyPixels, config.Width, config.Height)
+	}
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+	if format == FormatJPEG {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+
+	bounds := img.Bounds()
+	result := &Result{Width: bounds.Dx(), Height: bounds.Dy()}
+
+	result.Original, result.OriginalContentType, err = encodeOriginal(img, format)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Blurhash, err = blurhash.Encode(_blurhashXComponents, _blurhashYComponents, Resize(img, _blurhashWidth))
+	if err != nil {
+		return nil, fmt.Errorf("imaging - blurhash: %w", err)
+	}
+
+	thumbnail := Resize(img, opts.ThumbnailWidth)
+	for _, format := range []string{FormatWebP, FormatJPEG} {
+		variant, err := encodeVariant(thumbnail, KindThumbnail, format, opts.JPEGQuality)
+		if err != nil {
+			return nil, err
+		}
+		result.Variants = append(result.Variants, variant)
+	}
+
+	for _, width := range opts.Widths {
+		if width >= result.Width {
+			continue
+		}
+		variant, err := encodeVariant(Resize(img, width), KindResponsive, FormatJPEG, opts.JPEGQuality)
+		if err != nil {
+			return nil, err
+		}
+		result.Variants = append(result.Variants, variant)
+	}
+	return result, nil
+}
+
+// Resize scales img to width keeping the aspect ratio. Images already narrower are returned as is.
+func Resize(img image.Image, width int) image.Image {
+	bounds := img.Bounds()
+	if width <= 0 || bounds.Dx() <= width {
+		return img
+	}
+	height := bounds.Dy() * width / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+func encodeOriginal(img image.Image, format string) ([]byte, string, error) {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case FormatJPEG:
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: _originalJPEGQuality})
+	case FormatPNG:
+		err = png.Encode(&buf, img)
+	case FormatWebP:
+		err = nativewebp.Encode(&buf, img, nil)
+	case FormatGIF:
+		// GIF has no EXIF, keep the upload and its animation untouched.
+		return nil, "", nil
+	default:
+		return nil, "", fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("imaging - encode original: %w", err)
+	}
+	return buf.Bytes(), contentType(format), nil
+}
+
+func encodeVariant(img image.Image, kind, format string, quality int) (Variant, error) {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case FormatJPEG:
+		err = jpeg.Encode(&buf, flatten(img), &jpeg.Options{Quality: quality})
+	case FormatWebP:
+		err = nativewebp.Encode(&buf, img, nil)
+	default:
+		err = fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+	if err != nil {
+		return Variant{}, fmt.Errorf("imaging - encode %s %s: %w", kind, format, err)
+	}
+	bounds := img.Bounds()
+	return Variant{
+		Kind:        kind,
+		Format:      format,
+		ContentType: contentType(format),
+		Width:       bounds.Dx(),
+		Height:      bounds.Dy(),
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// flatten composes transparent images on white, JPEG has no alpha channel.
+func flatten(img image.Image) image.Image {
+	if opaque, ok := img.(interface{ Opaque() bool }); ok && opaque.Opaque() {
+		return img
+	}
+	bounds := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Over)
+	return dst
+}
+
+func contentType(format string) string {
+	return "image/" + format
+}
+
+// Extension returns the file extension used for keys of the format.
+func Extension(format string) string {
+	if format == FormatJPEG {
+		return ".jpg"
+	}
+	return "." + format
+}
@@ -0,0 +1,122 @@
+package imaging_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"tourism-backend/pkg/imaging"
+)
+
+func testImage(w, h int) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+// jpegWithExif encodes img as JPEG with an APP1 segment holding the orientation and a GPS marker string.
+func jpegWithExif(t *testing.T, img image.Image, orientation uint16) []byte {
+	t.Helper()
+
+	var encoded bytes.Buffer
+	require.NoError(t, jpeg.Encode(&encoded, img, nil))
+
+	var tiff bytes.Buffer
+	tiff.WriteString("MM")
+	_ = binary.Write(&tiff, binary.BigEndian, uint16(42))
+	_ = binary.Write(&tiff, binary.BigEndian, uint32(8))
+	_ = binary.Write(&tiff, binary.BigEndian, uint16(1))
+	_ = binary.Write(&tiff, binary.BigEndian, uint16(0x0112))
+	_ = binary.Write(&tiff, binary.BigEndian, uint16(3))
+	_ = binary.Write(&tiff, binary.BigEndian, uint32(1))
+	_ = binary.Write(&tiff, binary.BigEndian, orientation)
+	_ = binary.Write(&tiff, binary.BigEndian, uint16(0))
+	_ = binary.Write(&tiff, binary.BigEndian, uint32(0))
+	tiff.WriteString("GPSLatitude 43.2389")
+
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	data := encoded.Bytes()
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+func TestProcessJPEGOrientationAndMetadata(t *testing.T) {
+	data := jpegWithExif(t, testImage(200, 100), 6)
+	require.Contains(t, string(data), "GPSLatitude")
+
+	result, err := imaging.Process(bytes.NewReader(data), imaging.Options{Widths: []int{80, 400}, ThumbnailWidth: 40})
+	require.NoError(t, err)
+
+	// Rotated 90 degrees: the portrait dimensions are reported.
+	require.Equal(t, 100, result.Width)
+	require.Equal(t, 200, result.Height)
+	require.NotEmpty(t, result.Blurhash)
+
+	require.Equal(t, "image/jpeg", result.OriginalContentType)
+	require.NotContains(t, string(result.Original), "GPSLatitude")
+	require.NotContains(t, string(result.Original), "Exif")
+
+	// Two thumbnails and only the responsive width smaller than the original.
+	require.Len(t, result.Variants, 3)
+	require.Equal(t, imaging.KindThumbnail, result.Variants[0].Kind)
+	require.Equal(t, imaging.FormatWebP, result.Variants[0].Format)
+	require.Equal(t, 40, result.Variants[0].Width)
+	require.Equal(t, 80, result.Variants[0].Height)
+	require.Equal(t, imaging.FormatJPEG, result.Variants[1].Format)
+	require.Equal(t, imaging.KindResponsive, result.Variants[2].Kind)
+	require.Equal(t, 80, result.Variants[2].Width)
+
+	for _, variant := range result.Variants {
+		cfg, format, err := image.DecodeConfig(bytes.NewReader(variant.Data))
+		require.NoError(t, err)
+		require.Equal(t, variant.Format, format)
+		require.Equal(t, variant.Width, cfg.Width)
+		require.Equal(t, variant.Height, cfg.Height)
+	}
+}
+
+func TestProcessPNG(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, testImage(64, 32)))
+
+	result, err := imaging.Process(&buf, imaging.Options{})
+	require.NoError(t, err)
+	require.Equal(t, 64, result.Width)
+	require.Equal(t, 32, result.Height)
+	require.Equal(t, "image/png", result.OriginalContentType)
+	// Smaller than every default width: only the thumbnails, at the original size.
+	require.Len(t, result.Variants, 2)
+	require.Equal(t, 64, result.Variants[0].Width)
+}
+
+func TestProcessUnsupported(t *testing.T) {
+	_, err := imaging.Process(bytes.NewReader([]byte("<html></html>")), imaging.Options{})
+	require.ErrorIs(t, err, imaging.ErrUnsupportedFormat)
+}
+
+func TestProcessTooManyPixels(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, testImage(64, 32)))
+	_, err := imaging.Process(&buf, imaging.Options{MaxPixels: 64*32 - 1})
+	require.ErrorIs(t, err, imaging.ErrTooManyPixels)
+
+	// A GIF header declaring 65535x65535 pixels is rejected before any pixel is decoded.
+	header := []byte("GIF89a\xff\xff\xff\xff\x00\x00\x00")
+	_, err = imaging.Process(bytes.NewReader(header), imaging.Options{})
+	require.ErrorIs(t, err, imaging.ErrTooManyPixels)
+}
@@ -0,0 +1,45 @@
+// Package locale negotiates the content language of a request.
+package locale
+
+import (
+	"golang.org/x/text/language"
+)
+
+// Match returns the supported language of a language tag such as "kk" or "en-GB",
+// or false when the tag is malformed or its language is not supported.
+func Match(tag string, supported []string) (string, bool) {
+	t, err := language.Parse(tag)
+	if err != nil {
+		return "", false
+	}
+	base, _ := t.Base()
+	for _, lang := range supported {
+		if lang == base.String() {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// Negotiate returns the supported language that best matches an Accept-Language header.
+// Supported languages are base language codes such as "ru" or "kk", the first one is
+// returned when the header is empty, malformed or matches none of them.
+func Negotiate(acceptLanguage string, supported []string) string {
+	if len(supported) == 0 {
+		return ""
+	}
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return supported[0]
+	}
+
+	available := make([]language.Tag, 0, len(supported))
+	for _, lang := range supported {
+		available = append(available, language.Make(lang))
+	}
+	_, index, confidence := language.NewMatcher(available).Match(tags...)
+	if confidence == language.No {
+		return supported[0]
+	}
+	return supported[index]
+}
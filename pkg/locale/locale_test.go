@@ -0,0 +1,58 @@
+package locale_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"tourism-backend/pkg/locale"
+)
+
+func TestNegotiate(t *testing.T) {
+	t.Parallel()
+
+	supported := []string{"ru", "kk", "en"}
+
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "empty header", header: "", want: "ru"},
+		{name: "exact match", header: "kk", want: "kk"},
+		{name: "region subtag", header: "en-GB,en;q=0.9", want: "en"},
+		{name: "quality order", header: "de;q=0.9,kk;q=0.5,en;q=0.8", want: "en"},
+		{name: "no match", header: "de, fr", want: "ru"},
+		{name: "malformed", header: "!!!", want: "ru"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.want, locale.Negotiate(tc.header, supported))
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	t.Parallel()
+
+	supported := []string{"ru", "kk", "en"}
+
+	lang, ok := locale.Match("kk", supported)
+	require.True(t, ok)
+	require.Equal(t, "kk", lang)
+
+	lang, ok = locale.Match("en-US", supported)
+	require.True(t, ok)
+	require.Equal(t, "en", lang)
+
+	_, ok = locale.Match("de", supported)
+	require.False(t, ok)
+
+	_, ok = locale.Match("not a tag", supported)
+	require.False(t, ok)
+}
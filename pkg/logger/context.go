@@ -0,0 +1,43 @@
+package logger
+
+import "context"
+
+// fields are the request attributes WithContext adds to log entries.
+type fields struct {
+	requestID string
+	userID    string
+	route     string
+}
+
+type fieldsKey struct{}
+
+func fieldsFrom(ctx context.Context) fields {
+	f, _ := ctx.Value(fieldsKey{}).(fields)
+	return f
+}
+
+// WithRequestID returns a copy of ctx whose log entries carry the request ID.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	f := fieldsFrom(ctx)
+	f.requestID = requestID
+	return context.WithValue(ctx, fieldsKey{}, f)
+}
+
+// WithUserID returns a copy of ctx whose log entries carry the ID of the authenticated user.
+func WithUserID(ctx context.Context, userID string) context.Context {
+	f := fieldsFrom(ctx)
+	f.userID = userID
+	return context.WithValue(ctx, fieldsKey{}, f)
+}
+
+// WithRoute returns a copy of ctx whose log entries carry the route pattern of the request.
+func WithRoute(ctx context.Context, route string) context.Context {
+	f := fieldsFrom(ctx)
+	f.route = route
+	return context.WithValue(ctx, fieldsKey{}, f)
+}
+
+// RequestID returns the request ID carried by ctx, if any.
+func RequestID(ctx context.Context) string {
+	return fieldsFrom(ctx).requestID
+}
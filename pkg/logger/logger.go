@@ -1,11 +1,13 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Interface -.
@@ -15,6 +17,10 @@ type Interface interface {
 	Warn(message string, args ...interface{})
 	Error(message interface{}, args ...interface{})
 	Fatal(message interface{}, args ...interface{})
+	// WithContext returns a logger that adds the request fields and the trace of ctx to every entry.
+	WithContext(ctx context.Context) Interface
+	// With returns a logger that adds the fields given as key, value pairs to every entry.
+	With(keyvals ...interface{}) Interface
 }
 
 // Logger -.
@@ -53,50 +59,85 @@ func New(level string) *Logger {
 
 // Debug -.
 func (l *Logger) Debug(message interface{}, args ...interface{}) {
-	l.msg("debug", message, args...)
+	l.msg(zerolog.DebugLevel, message, args...)
 }
 
 // Info -.
 func (l *Logger) Info(message string, args ...interface{}) {
-	l.log(message, args...)
+	l.msg(zerolog.InfoLevel, message, args...)
 }
 
 // Warn -.
 func (l *Logger) Warn(message string, args ...interface{}) {
-	l.log(message, args...)
+	l.msg(zerolog.WarnLevel, message, args...)
 }
 
 // Error -.
 func (l *Logger) Error(message interface{}, args ...interface{}) {
-	if l.logger.GetLevel() == zerolog.DebugLevel {
-		l.Debug(message, args...)
-	}
-
-	l.msg("error", message, args...)
+	l.msg(zerolog.ErrorLevel, message, args...)
 }
 
 // Fatal -.
 func (l *Logger) Fatal(message interface{}, args ...interface{}) {
-	l.msg("fatal", message, args...)
+	l.msg(zerolog.FatalLevel, message, args...)
 
 	os.Exit(1)
 }
 
-func (l *Logger) log(message string, args ...interface{}) {
+// WithContext -.
+func (l *Logger) WithContext(ctx context.Context) Interface {
+	f := fieldsFrom(ctx)
+	span := trace.SpanContextFromContext(ctx)
+	if f == (fields{}) && !span.IsValid() {
+		return l
+	}
+
+	c := l.logger.With()
+	if span.IsValid() {
+		c = c.Str("trace_id", span.TraceID().String()).Str("span_id", span.SpanID().String())
+	}
+	if f.requestID != "" {
+		c = c.Str("request_id", f.requestID)
+	}
+	if f.userID != "" {
+		c = c.Str("user_id", f.userID)
+	}
+	if f.route != "" {
+		c = c.Str("route", f.route)
+	}
+	logger := c.Logger()
+
+	return &Logger{
+		logger: &logger,
+	}
+}
+
+// With -.
+func (l *Logger) With(keyvals ...interface{}) Interface {
+	logger := l.logger.With().Fields(keyvals).Logger()
+
+	return &Logger{
+		logger: &logger,
+	}
+}
+
+func (l *Logger) log(level zerolog.Level, message string, args ...interface{}) {
+	// Fatal entries are written by Fatal itself, WithLevel does not exit.
+	event := l.logger.WithLevel(level)
 	if len(args) == 0 {
-		l.logger.Info().Msg(message)
+		event.Msg(message)
 	} else {
-		l.logger.Info().Msgf(message, args...)
+		event.Msgf(message, args...)
 	}
 }
 
-func (l *Logger) msg(level string, message interface{}, args ...interface{}) {
+func (l *Logger) msg(level zerolog.Level, message interface{}, args ...interface{}) {
 	switch msg := message.(type) {
 	case error:
-		l.log(msg.Error(), args...)
+		l.log(level, msg.Error(), args...)
 	case string:
-		l.log(msg, args...)
+		l.log(level, msg, args...)
 	default:
-		l.log(fmt.Sprintf("%s message %v has unknown type %v", level, message, msg), args...)
+		l.log(level, fmt.Sprintf("%s message %v has unknown type %v", level, message, msg), args...)
 	}
 }
@@ -0,0 +1,212 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStore keeps objects as files under a root directory.
+type LocalStore struct {
+	root    string
+	baseURL string
+}
+
+var (
+	_ Store  = (*LocalStore)(nil)
+	_ Lister = (*LocalStore)(nil)
+)
+
+// NewLocalStore -.
+// baseURL is the path the root directory is served from, e.g. "/uploads".
+func NewLocalStore(root, baseURL string) *LocalStore {
+	return &LocalStore{
+		root:    root,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// Root returns the directory objects are stored in.
+func (s *LocalStore) Root() string {
+	return s.root
+}
+
+// Put -.
+func (s *LocalStore) Put(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("media - LocalStore - Put - mkdir: %w", err)
+	}
+
+	// Write to a temporary file first, so readers never see a partial object.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("media - LocalStore - Put - create: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("media - LocalStore - Put - copy: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("media - LocalStore - Put - close: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("media - LocalStore - Put - rename: %w", err)
+	}
+	return nil
+}
+
+// Get -.
+func (s *LocalStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("media - LocalStore - Get: %w", err)
+	}
+	return f, nil
+}
+
+// Open -.
+func (s *LocalStore) Open(_ context.Context, key string) (File, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("media - LocalStore - Open: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("media - LocalStore - Open - stat: %w", err)
+	}
+	if info.IsDir() {
+		f.Close()
+		return nil, ErrNotFound
+	}
+
+	// The type comes from the extension, keys without one such as blob digests are sniffed when served.
+	return &localFile{File: f, object: Object{
+		Key:         key,
+		Size:        info.Size(),
+		ModTime:     info.ModTime(),
+		ContentType: mime.TypeByExtension(filepath.Ext(path)),
+		// Objects are replaced by renaming a new file into place, so size and mtime identify a version.
+		ETag: fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size()),
+	}}, nil
+}
+
+type localFile struct {
+	*os.File
+	object Object
+}
+
+func (f *localFile) Info() Object {
+	return f.object
+}
+
+// Move -.
+func (s *LocalStore) Move(_ context.Context, from, to string) error {
+	src, err := s.path(from)
+	if err != nil {
+		return err
+	}
+	dst, err := s.path(to)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return fmt.Errorf("media - LocalStore - Move - mkdir: %w", err)
+	}
+	err = os.Rename(src, dst)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("media - LocalStore - Move: %w", err)
+	}
+	return nil
+}
+
+// Delete -.
+func (s *LocalStore) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("media - LocalStore - Delete: %w", err)
+	}
+	return nil
+}
+
+// URL -.
+func (s *LocalStore) URL(key string) string {
+	return s.baseURL + "/" + strings.TrimPrefix(key, "/")
+}
+
+// List -.
+func (s *LocalStore) List(ctx context.Context, prefix string, fn func(Object) error) error {
+	err := filepath.WalkDir(s.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return fn(Object{Key: key, Size: info.Size(), ModTime: info.ModTime()})
+	})
+	if err != nil {
+		return fmt.Errorf("media - LocalStore - List: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
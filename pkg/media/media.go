@@ -0,0 +1,78 @@
+// Package media implements storage backends for uploaded media files.
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when a key does not exist in the store.
+var ErrNotFound = errors.New("media: object not found")
+
+// ErrInvalidKey is returned for keys that are empty or try to escape the store root.
+var ErrInvalidKey = errors.New("media: invalid key")
+
+// Store keeps media objects addressed by slash-separated keys such as "images/<uuid>.jpg".
+type Store interface {
+	// Put stores size bytes read from r under key, replacing any existing object.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the object stored under key.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Open opens the object for random access, as needed to serve Range requests.
+	Open(ctx context.Context, key string) (File, error)
+	// Move renames the object stored under from to to, replacing any existing object.
+	Move(ctx context.Context, from, to string) error
+	// Delete removes the object. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// URL returns the address clients use to download the object.
+	URL(key string) string
+}
+
+// Object describes a stored object.
+type Object struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+	// ContentType and ETag are only filled by Open.
+	ContentType string
+	ETag        string
+}
+
+// File is an object opened with Open.
+type File interface {
+	io.ReadSeekCloser
+	// Info describes the opened object.
+	Info() Object
+}
+
+// PrivatePrefix is the key prefix of objects that are only served through signed URLs.
+const PrivatePrefix = "private/"
+
+// IsPrivate reports whether key may only be served through a signed URL.
+func IsPrivate(key string) bool {
+	return strings.HasPrefix(key, PrivatePrefix)
+}
+
+// Lister is implemented by stores that can enumerate their objects, e.g. for garbage collection.
+type Lister interface {
+	// List calls fn for every object whose key starts with prefix. An error from fn stops the listing.
+	List(ctx context.Context, prefix string, fn func(Object) error) error
+}
+
+// CleanKey validates key and returns it in canonical form.
+func CleanKey(key string) (string, error) {
+	for _, part := range strings.Split(key, "/") {
+		if part == ".." {
+			return "", ErrInvalidKey
+		}
+	}
+	key = strings.TrimPrefix(path.Clean("/"+strings.TrimSpace(key)), "/")
+	if key == "" {
+		return "", ErrInvalidKey
+	}
+	return key, nil
+}
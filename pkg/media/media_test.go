@@ -0,0 +1,269 @@
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint:gosec // S3 ETags are MD5 digests.
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"tourism-backend/pkg/media"
+)
+
+// fakeS3 is an in-process stand-in for an S3-compatible service with path-style addressing.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{objects: map[string][]byte{}, types: map[string]string{}}
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=access/") ||
+		!strings.Contains(auth, "SignedHeaders=") || r.Header.Get("X-Amz-Date") == "" {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		if source := r.Header.Get("X-Amz-Copy-Source"); source != "" {
+			body, ok := f.objects[source]
+			if !ok {
+				http.Error(w, "NoSuchKey", http.StatusNotFound)
+				return
+			}
+			f.objects[r.URL.Path] = body
+			f.types[r.URL.Path] = f.types[source]
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = body
+		f.types[r.URL.Path] = r.Header.Get("Content-Type")
+	case http.MethodGet, http.MethodHead:
+		if r.URL.Query().Get("list-type") == "2" {
+			f.list(w, r)
+			return
+		}
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", f.types[r.URL.Path])
+		w.Header().Set("ETag", fmt.Sprintf(`"%x"`, md5.Sum(body)))
+		// ServeContent answers HEAD, Range and If-Match like S3 does.
+		http.ServeContent(w, r, "", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), bytes.NewReader(body))
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// list serves ListObjectsV2 one object per page to exercise continuation tokens.
+func (f *fakeS3) list(w http.ResponseWriter, r *http.Request) {
+	bucket := strings.TrimSuffix(r.URL.Path, "/") + "/"
+	prefix := r.URL.Query().Get("prefix")
+	keys := make([]string, 0, len(f.objects))
+	for path := range f.objects {
+		key := strings.TrimPrefix(path, bucket)
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	start, _ := strconv.Atoi(r.URL.Query().Get("continuation-token"))
+	type content struct {
+		Key          string
+		Size         int
+		LastModified time.Time
+	}
+	result := struct {
+		XMLName               xml.Name `xml:"ListBucketResult"`
+		IsTruncated           bool
+		NextContinuationToken string `xml:",omitempty"`
+		Contents              []content
+	}{}
+	if start < len(keys) {
+		key := keys[start]
+		result.Contents = append(result.Contents, content{key, len(f.objects[bucket+key]), time.Now().UTC()})
+		if start+1 < len(keys) {
+			result.IsTruncated = true
+			result.NextContinuationToken = strconv.Itoa(start + 1)
+		}
+	}
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+func testList(t *testing.T, store interface {
+	media.Store
+	media.Lister
+}) {
+	t.Helper()
+	ctx := context.Background()
+
+	for _, key := range []string{"images/a.jpg", "images/b/c.webp", "videos/d.mp4"} {
+		require.NoError(t, store.Put(ctx, key, strings.NewReader("x"), 1, ""))
+	}
+
+	var keys []string
+	err := store.List(ctx, "images/", func(object media.Object) error {
+		require.Equal(t, int64(1), object.Size)
+		require.False(t, object.ModTime.IsZero())
+		keys = append(keys, object.Key)
+		return nil
+	})
+	require.NoError(t, err)
+	sort.Strings(keys)
+	require.Equal(t, []string{"images/a.jpg", "images/b/c.webp"}, keys)
+}
+
+func testOpen(t *testing.T, store media.Store) {
+	t.Helper()
+	ctx := context.Background()
+
+	content := "0123456789"
+	require.NoError(t, store.Put(ctx, "videos/a.mp4", strings.NewReader(content), int64(len(content)), "video/mp4"))
+
+	file, err := store.Open(ctx, "videos/a.mp4")
+	require.NoError(t, err)
+	defer file.Close()
+
+	object := file.Info()
+	require.Equal(t, int64(len(content)), object.Size)
+	require.Equal(t, "video/mp4", object.ContentType)
+	require.NotEmpty(t, object.ETag)
+
+	_, err = file.Seek(6, io.SeekStart)
+	require.NoError(t, err)
+	got, err := io.ReadAll(file)
+	require.NoError(t, err)
+	require.Equal(t, "6789", string(got))
+
+	_, err = file.Seek(-8, io.SeekEnd)
+	require.NoError(t, err)
+	head := make([]byte, 3)
+	_, err = io.ReadFull(file, head)
+	require.NoError(t, err)
+	require.Equal(t, "234", string(head))
+
+	_, err = store.Open(ctx, "videos/missing.mp4")
+	require.ErrorIs(t, err, media.ErrNotFound)
+}
+
+func testStore(t *testing.T, store media.Store) {
+	t.Helper()
+	ctx := context.Background()
+
+	content := "jpeg bytes"
+	err := store.Put(ctx, "images/a.jpg", strings.NewReader(content), int64(len(content)), "image/jpeg")
+	require.NoError(t, err)
+
+	rc, err := store.Get(ctx, "images/a.jpg")
+	require.NoError(t, err)
+	got, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	require.Equal(t, content, string(got))
+
+	require.NoError(t, store.Delete(ctx, "images/a.jpg"))
+	_, err = store.Get(ctx, "images/a.jpg")
+	require.ErrorIs(t, err, media.ErrNotFound)
+
+	// Deleting twice is fine.
+	require.NoError(t, store.Delete(ctx, "images/a.jpg"))
+
+	require.NoError(t, store.Put(ctx, "private/staging/a", strings.NewReader(content), int64(len(content)), "image/jpeg"))
+	require.NoError(t, store.Move(ctx, "private/staging/a", "images/ab/abcd"))
+	rc, err = store.Get(ctx, "images/ab/abcd")
+	require.NoError(t, err)
+	got, err = io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	require.Equal(t, content, string(got))
+	_, err = store.Get(ctx, "private/staging/a")
+	require.ErrorIs(t, err, media.ErrNotFound)
+	require.ErrorIs(t, store.Move(ctx, "private/staging/a", "images/ab/abcd"), media.ErrNotFound)
+	require.NoError(t, store.Delete(ctx, "images/ab/abcd"))
+
+	_, err = store.Get(ctx, "../etc/passwd")
+	require.ErrorIs(t, err, media.ErrInvalidKey)
+}
+
+func TestLocalStore(t *testing.T) {
+	store := media.NewLocalStore(t.TempDir(), "/uploads/")
+	testStore(t, store)
+	require.Equal(t, "/uploads/images/a.jpg", store.URL("images/a.jpg"))
+	testList(t, store)
+	testOpen(t, store)
+}
+
+func TestS3Store(t *testing.T) {
+	fake := newFakeS3()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := media.NewS3Store(media.S3Config{
+		Endpoint:  server.URL,
+		Region:    "us-east-1",
+		Bucket:    "media",
+		AccessKey: "access",
+		SecretKey: "secret",
+		PathStyle: true,
+	}, media.S3HTTPClient(server.Client()))
+	require.NoError(t, err)
+
+	testStore(t, store)
+	require.Equal(t, server.URL+"/media/images/a.jpg", store.URL("images/a.jpg"))
+
+	content := "png"
+	require.NoError(t, store.Put(context.Background(), "images/b.png", strings.NewReader(content), 3, "image/png"))
+	require.Equal(t, "image/png", fake.types["/media/images/b.png"])
+
+	require.NoError(t, store.Delete(context.Background(), "images/b.png"))
+	testList(t, store)
+	testOpen(t, store)
+}
+
+func TestS3StoreURL(t *testing.T) {
+	store, err := media.NewS3Store(media.S3Config{
+		Endpoint: "https://s3.eu-central-1.amazonaws.com",
+		Region:   "eu-central-1",
+		Bucket:   "media",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "https://media.s3.eu-central-1.amazonaws.com/images/a%20b.jpg", store.URL("images/a b.jpg"))
+
+	store, err = media.NewS3Store(media.S3Config{
+		Endpoint:  "https://s3.eu-central-1.amazonaws.com",
+		Region:    "eu-central-1",
+		Bucket:    "media",
+		PublicURL: "https://cdn.example.com/",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "https://cdn.example.com/images/a.jpg", store.URL("images/a.jpg"))
+
+	_, err = media.NewS3Store(media.S3Config{Endpoint: "not a url", Region: "r", Bucket: "b"})
+	require.Error(t, err)
+}
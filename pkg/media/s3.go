@@ -0,0 +1,481 @@
+package media
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	_s3Service         = "s3"
+	_s3Algorithm       = "AWS4-HMAC-SHA256"
+	_s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	_s3EmptyPayload    = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	_s3DateFormat      = "20060102T150405Z"
+	_s3DefaultTimeout  = 5 * time.Minute
+)
+
+// S3Config -.
+type S3Config struct {
+	// Endpoint is the base URL of the service, e.g. "https://s3.eu-central-1.amazonaws.com" or "http://minio:9000".
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PathStyle addresses objects as {endpoint}/{bucket}/{key}; MinIO and most stand-ins need it.
+	PathStyle bool
+	// PublicURL is the base URL clients download objects from, e.g. a CDN. Defaults to the object URL.
+	PublicURL string
+}
+
+// S3Store keeps objects in an S3-compatible bucket using signature V4 requests.
+type S3Store struct {
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+var (
+	_ Store  = (*S3Store)(nil)
+	_ Lister = (*S3Store)(nil)
+)
+
+// S3Option -.
+type S3Option func(*S3Store)
+
+// S3HTTPClient -.
+func S3HTTPClient(client *http.Client) S3Option {
+	return func(s *S3Store) {
+		s.client = client
+	}
+}
+
+// NewS3Store -.
+func NewS3Store(cfg S3Config, opts ...S3Option) (*S3Store, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("media - NewS3Store - invalid endpoint %q", cfg.Endpoint)
+	}
+	if cfg.Bucket == "" || cfg.Region == "" {
+		return nil, fmt.Errorf("media - NewS3Store - bucket and region are required")
+	}
+
+	s := &S3Store{
+		cfg:      cfg,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: _s3DefaultTimeout},
+		now:      time.Now,
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
+}
+
+// Put -.
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	req, err := s.newRequest(ctx, http.MethodPut, key, r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := s.do(req, _s3UnsignedPayload)
+	if err != nil {
+		return fmt.Errorf("media - S3Store - Put: %w", err)
+	}
+	defer resp.Body.Close()
+	return checkS3Response(resp, "Put")
+}
+
+// Get -.
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req, _s3EmptyPayload)
+	if err != nil {
+		return nil, fmt.Errorf("media - S3Store - Get: %w", err)
+	}
+	if err := checkS3Response(resp, "Get"); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Open -.
+func (s *S3Store) Open(ctx context.Context, key string) (File, error) {
+	req, err := s.newRequest(ctx, http.MethodHead, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req, _s3EmptyPayload)
+	if err != nil {
+		return nil, fmt.Errorf("media - S3Store - Open: %w", err)
+	}
+	resp.Body.Close()
+	if err := checkS3Response(resp, "Open"); err != nil {
+		return nil, err
+	}
+
+	object := Object{
+		Key:         key,
+		Size:        resp.ContentLength,
+		ContentType: resp.Header.Get("Content-Type"),
+		ETag:        resp.Header.Get("ETag"),
+	}
+	if modTime, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		object.ModTime = modTime
+	}
+	return &s3File{store: s, ctx: ctx, object: object}, nil
+}
+
+// s3File reads an object with ranged GET requests, starting a new one after each seek.
+type s3File struct {
+	store  *S3Store
+	ctx    context.Context
+	object Object
+	offset int64
+	body   io.ReadCloser
+}
+
+func (f *s3File) Info() Object {
+	return f.object
+}
+
+func (f *s3File) Read(p []byte) (int, error) {
+	if f.offset >= f.object.Size {
+		return 0, io.EOF
+	}
+	if f.body == nil {
+		req, err := f.store.newRequest(f.ctx, http.MethodGet, f.object.Key, nil)
+		if err != nil {
+			return 0, err
+		}
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", f.offset))
+		if f.object.ETag != "" {
+			// Fail instead of mixing bytes of two versions when the object is replaced mid-read.
+			req.Header.Set("If-Match", f.object.ETag)
+		}
+		resp, err := f.store.do(req, _s3EmptyPayload)
+		if err != nil {
+			return 0, fmt.Errorf("media - S3Store - Read: %w", err)
+		}
+		if err := checkS3Response(resp, "Read"); err != nil {
+			resp.Body.Close()
+			return 0, err
+		}
+		if resp.StatusCode != http.StatusPartialContent && f.offset > 0 {
+			resp.Body.Close()
+			return 0, fmt.Errorf("media - S3Store - Read: range request answered with status %d", resp.StatusCode)
+		}
+		f.body = resp.Body
+	}
+
+	n, err := f.body.Read(p)
+	f.offset += int64(n)
+	return n, err
+}
+
+func (f *s3File) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.object.Size
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("media - S3Store - Seek: negative offset %d", offset)
+	}
+	if offset != f.offset {
+		f.closeBody()
+		f.offset = offset
+	}
+	return offset, nil
+}
+
+func (f *s3File) Close() error {
+	f.closeBody()
+	return nil
+}
+
+func (f *s3File) closeBody() {
+	if f.body != nil {
+		f.body.Close()
+		f.body = nil
+	}
+}
+
+// Move copies the object to the new key and deletes the old one, S3 has no rename.
+// The copy keeps the content type of the object.
+func (s *S3Store) Move(ctx context.Context, from, to string) error {
+	source, err := CleanKey(from)
+	if err != nil {
+		return err
+	}
+	req, err := s.newRequest(ctx, http.MethodPut, to, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Amz-Copy-Source", escapeS3Path("/"+s.cfg.Bucket+"/"+source))
+
+	resp, err := s.do(req, _s3EmptyPayload)
+	if err != nil {
+		return fmt.Errorf("media - S3Store - Move: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := checkS3Response(resp, "Move"); err != nil {
+		return err
+	}
+	return s.Delete(ctx, from)
+}
+
+// Delete -.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req, _s3EmptyPayload)
+	if err != nil {
+		return fmt.Errorf("media - S3Store - Delete: %w", err)
+	}
+	defer resp.Body.Close()
+
+	err = checkS3Response(resp, "Delete")
+	if err == ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+type s3ListResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+}
+
+// List -.
+func (s *S3Store) List(ctx context.Context, prefix string, fn func(Object) error) error {
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}}
+		if prefix != "" {
+			query.Set("prefix", prefix)
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		u := s.bucketURL()
+		u.RawQuery = canonicalS3Query(query)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return fmt.Errorf("media - S3Store - List - new request: %w", err)
+		}
+
+		resp, err := s.do(req, _s3EmptyPayload)
+		if err != nil {
+			return fmt.Errorf("media - S3Store - List: %w", err)
+		}
+		var result s3ListResult
+		err = checkS3Response(resp, "List")
+		if err == nil {
+			err = xml.NewDecoder(resp.Body).Decode(&result)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("media - S3Store - List: %w", err)
+		}
+
+		for _, item := range result.Contents {
+			if err := fn(Object{Key: item.Key, Size: item.Size, ModTime: item.LastModified}); err != nil {
+				return err
+			}
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// URL -.
+func (s *S3Store) URL(key string) string {
+	if s.cfg.PublicURL != "" {
+		return strings.TrimSuffix(s.cfg.PublicURL, "/") + "/" + escapeS3Path(key)
+	}
+	return s.objectURL(key).String()
+}
+
+func (s *S3Store) bucketURL() *url.URL {
+	u := *s.endpoint
+	if s.cfg.PathStyle {
+		u.Path = u.Path + "/" + s.cfg.Bucket
+	} else {
+		u.Host = s.cfg.Bucket + "." + u.Host
+		u.Path = u.Path + "/"
+	}
+	u.RawPath = ""
+	return &u
+}
+
+func (s *S3Store) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	if s.cfg.PathStyle {
+		u.Path = u.Path + "/" + s.cfg.Bucket + "/" + key
+	} else {
+		u.Host = s.cfg.Bucket + "." + u.Host
+		u.Path = u.Path + "/" + key
+	}
+	u.RawPath = ""
+	return &u
+}
+
+func (s *S3Store) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	key, err := CleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+	u := s.objectURL(key)
+	u.RawPath = escapeS3Path(u.Path)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("media - S3Store - new request: %w", err)
+	}
+	return req, nil
+}
+
+func (s *S3Store) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	s.sign(req, payloadHash, s.now().UTC())
+	return s.client.Do(req)
+}
+
+// sign adds AWS signature V4 headers to req.
+func (s *S3Store) sign(req *http.Request, payloadHash string, t time.Time) {
+	amzDate := t.Format(_s3DateFormat)
+	date := amzDate[:8]
+
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders, canonicalHeaders := canonicalS3Headers(req)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalS3Query(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.cfg.Region + "/" + _s3Service + "/aws4_request"
+	stringToSign := strings.Join([]string{
+		_s3Algorithm,
+		amzDate,
+		scope,
+		hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), date)
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, _s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		_s3Algorithm, s.cfg.AccessKey, scope, signedHeaders, signature))
+}
+
+func canonicalS3Headers(req *http.Request) (string, string) {
+	names := make([]string, 0, len(req.Header))
+	values := make(map[string]string, len(req.Header))
+	for name, v := range req.Header {
+		lower := strings.ToLower(name)
+		if lower != "host" && lower != "content-type" && !strings.HasPrefix(lower, "x-amz-") {
+			continue
+		}
+		names = append(names, lower)
+		values[lower] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte(':')
+		b.WriteString(values[name])
+		b.WriteByte('\n')
+	}
+	return strings.Join(names, ";"), b.String()
+}
+
+// canonicalS3Query encodes query parameters sorted by name with spaces as %20, as signature V4 requires.
+func canonicalS3Query(query url.Values) string {
+	return strings.ReplaceAll(query.Encode(), "+", "%20")
+}
+
+// escapeS3Path percent-encodes everything except unreserved characters and slashes.
+func escapeS3Path(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func checkS3Response(resp *http.Response, op string) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("media - S3Store - %s: unexpected status %d: %s", op, resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
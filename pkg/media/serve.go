@@ -0,0 +1,78 @@
+package media
+
+import (
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Handler serves store objects over HTTP with Range, ETag and Last-Modified support.
+// Keys under PrivatePrefix require a valid signature from the Signer, other keys are public.
+// Request paths are the object keys, mount the handler with http.StripPrefix.
+type Handler struct {
+	store  Store
+	signer *Signer
+}
+
+// NewHandler -.
+func NewHandler(store Store, signer *Signer) *Handler {
+	return &Handler{store: store, signer: signer}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	key, err := CleanKey(strings.TrimPrefix(r.URL.Path, "/"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	private := IsPrivate(key)
+	query := r.URL.Query()
+	if private || query.Has("signature") {
+		switch err := h.signer.Verify(key, query.Get("expires"), query.Get("signature")); {
+		case errors.Is(err, ErrURLExpired):
+			http.Error(w, "signed URL expired", http.StatusForbidden)
+			return
+		case err != nil:
+			http.Error(w, "invalid signature", http.StatusForbidden)
+			return
+		}
+	}
+
+	file, err := h.store.Open(r.Context(), key)
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrInvalidKey) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	object := file.Info()
+	header := w.Header()
+	// nosniff keeps browsers from rendering an upload as anything but its declared type.
+	header.Set("X-Content-Type-Options", "nosniff")
+	if object.ContentType != "" {
+		header.Set("Content-Type", object.ContentType)
+	}
+	if object.ETag != "" {
+		header.Set("ETag", object.ETag)
+	}
+	if private {
+		// Shared caches must not keep private files, browsers revalidate with the ETag.
+		header.Set("Cache-Control", "private, no-cache")
+	} else {
+		header.Set("Cache-Control", "public, max-age=86400")
+	}
+
+	// ServeContent answers Range, If-Range, If-None-Match and If-Modified-Since requests.
+	http.ServeContent(w, r, path.Base(key), object.ModTime, file)
+}
@@ -0,0 +1,103 @@
+package media_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"tourism-backend/pkg/media"
+)
+
+func signedQuery(t *testing.T, signedURL string) (string, url.Values) {
+	t.Helper()
+	u, err := url.Parse(signedURL)
+	require.NoError(t, err)
+	return u.Path, u.Query()
+}
+
+func TestSigner(t *testing.T) {
+	signer := media.NewSigner([]byte("secret"), "/uploads/", time.Minute)
+
+	signedURL, expires := signer.URL("private/documents/a b.pdf")
+	require.WithinDuration(t, time.Now().Add(time.Minute), expires, 2*time.Second)
+	path, query := signedQuery(t, signedURL)
+	require.Equal(t, "/uploads/private/documents/a b.pdf", path)
+	require.True(t, strings.HasPrefix(signedURL, "/uploads/private/documents/a%20b.pdf?"))
+
+	require.NoError(t, signer.Verify("private/documents/a b.pdf", query.Get("expires"), query.Get("signature")))
+	// The signature is bound to the key and the expiry.
+	require.ErrorIs(t, signer.Verify("private/documents/other.pdf", query.Get("expires"), query.Get("signature")), media.ErrInvalidSignature)
+	require.ErrorIs(t, signer.Verify("private/documents/a b.pdf", "9999999999", query.Get("signature")), media.ErrInvalidSignature)
+	require.ErrorIs(t, signer.Verify("private/documents/a b.pdf", "", ""), media.ErrInvalidSignature)
+
+	other := media.NewSigner([]byte("other secret"), "/uploads", time.Minute)
+	require.ErrorIs(t, other.Verify("private/documents/a b.pdf", query.Get("expires"), query.Get("signature")), media.ErrInvalidSignature)
+
+	expired := media.NewSigner([]byte("secret"), "/uploads", -time.Minute)
+	_, query = signedQuery(t, mustURL(expired, "private/a.pdf"))
+	require.ErrorIs(t, expired.Verify("private/a.pdf", query.Get("expires"), query.Get("signature")), media.ErrURLExpired)
+}
+
+func mustURL(signer *media.Signer, key string) string {
+	signedURL, _ := signer.URL(key)
+	return signedURL
+}
+
+func TestHandler(t *testing.T) {
+	store := media.NewLocalStore(t.TempDir(), "/uploads")
+	signer := media.NewSigner([]byte("secret"), "/uploads", time.Minute)
+	handler := http.StripPrefix("/uploads", media.NewHandler(store, signer))
+
+	ctx := context.Background()
+	video := "0123456789"
+	require.NoError(t, store.Put(ctx, "videos/a.mp4", strings.NewReader(video), 10, "video/mp4"))
+	require.NoError(t, store.Put(ctx, "private/documents/a.pdf", strings.NewReader("%PDF-1.7"), 8, "application/pdf"))
+
+	serve := func(target string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("/uploads/videos/a.mp4", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, video, rec.Body.String())
+	require.Equal(t, "video/mp4", rec.Header().Get("Content-Type"))
+	require.Equal(t, "bytes", rec.Header().Get("Accept-Ranges"))
+	require.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	rec = serve("/uploads/videos/a.mp4", http.Header{"Range": {"bytes=2-5"}})
+	require.Equal(t, http.StatusPartialContent, rec.Code)
+	require.Equal(t, "2345", rec.Body.String())
+	require.Equal(t, "bytes 2-5/10", rec.Header().Get("Content-Range"))
+
+	rec = serve("/uploads/videos/a.mp4", http.Header{"If-None-Match": {etag}})
+	require.Equal(t, http.StatusNotModified, rec.Code)
+
+	require.Equal(t, http.StatusNotFound, serve("/uploads/videos/missing.mp4", nil).Code)
+	require.Equal(t, http.StatusNotFound, serve("/uploads/../secret", nil).Code)
+
+	// Private objects need a valid signature.
+	require.Equal(t, http.StatusForbidden, serve("/uploads/private/documents/a.pdf", nil).Code)
+	require.Equal(t, http.StatusForbidden, serve("/uploads/private/documents/a.pdf?expires=9999999999&signature=x", nil).Code)
+	rec = serve(mustURL(signer, "private/documents/a.pdf"), nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "%PDF-1.7", rec.Body.String())
+	require.Equal(t, "private, no-cache", rec.Header().Get("Cache-Control"))
+
+	// A URL signed for one key does not open another.
+	_, query := signedQuery(t, mustURL(signer, "private/documents/a.pdf"))
+	require.Equal(t, http.StatusForbidden, serve("/uploads/private/documents/b.pdf?"+query.Encode(), nil).Code)
+}
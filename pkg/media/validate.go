@@ -0,0 +1,211 @@
+package media
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Kind is a class of uploads sharing the same limits and allowed formats.
+type Kind string
+
+// Upload kinds.
+const (
+	KindImage    Kind = "image"
+	KindVideo    Kind = "video"
+	KindDocument Kind = "document"
+)
+
+// Upload validation error codes.
+const (
+	CodeTooLarge          = "too_large"
+	CodeTooMany           = "too_many"
+	CodeEmpty             = "empty"
+	CodeUnsupportedType   = "unsupported_type"
+	CodeExtensionMismatch = "extension_mismatch"
+	CodeUnreadable        = "unreadable"
+)
+
+// _sniffLen is the number of leading bytes inspected to detect the content type.
+const _sniffLen = 512
+
+// allowedTypes maps the accepted content types of each kind to their file extensions.
+var allowedTypes = map[Kind]map[string][]string{
+	KindImage: {
+		"image/jpeg": {".jpg", ".jpeg"},
+		"image/png":  {".png"},
+		"image/webp": {".webp"},
+		"image/gif":  {".gif"},
+	},
+	KindVideo: {
+		"video/mp4":       {".mp4", ".m4v"},
+		"video/webm":      {".webm"},
+		"video/quicktime": {".mov"},
+	},
+	KindDocument: {
+		"application/pdf": {".pdf"},
+		"image/jpeg":      {".jpg", ".jpeg"},
+		"image/png":       {".png"},
+	},
+}
+
+// Limits -.
+type Limits struct {
+	// MaxSize is the largest accepted file in bytes.
+	MaxSize int64
+	// MaxCount is the largest number of files accepted in one form field.
+	MaxCount int
+}
+
+// FileError describes why one uploaded file was rejected.
+type FileError struct {
+	Field    string `json:"field"`
+	Filename string `json:"filename,omitempty"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+}
+
+// ValidationError is returned when one or more uploaded files are rejected.
+type ValidationError struct {
+	Files []FileError `json:"files"`
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Files) == 1 {
+		return "invalid upload: " + e.Files[0].Message
+	}
+	return fmt.Sprintf("invalid upload: %d files rejected", len(e.Files))
+}
+
+// Validator checks uploads against per-kind limits using the file contents, not the client-supplied type.
+type Validator struct {
+	limits map[Kind]Limits
+}
+
+// NewValidator -.
+func NewValidator(image, video, document Limits) *Validator {
+	return &Validator{
+		limits: map[Kind]Limits{
+			KindImage:    image,
+			KindVideo:    video,
+			KindDocument: document,
+		},
+	}
+}
+
+// Limits returns the limits of kind.
+func (v *Validator) Limits(kind Kind) Limits {
+	return v.limits[kind]
+}
+
+// Validate checks the files of a form field. Accepted files get their Content-Type
+// header replaced with the sniffed type, so stores never persist the client's claim.
+// All problems are collected, so the client can fix every file in one round trip.
+func (v *Validator) Validate(field string, kind Kind, files []*multipart.FileHeader) []FileError {
+	limits := v.limits[kind]
+	var errs []FileError
+
+	if limits.MaxCount > 0 && len(files) > limits.MaxCount {
+		errs = append(errs, FileError{
+			Field:   field,
+			Code:    CodeTooMany,
+			Message: fmt.Sprintf("at most %d files are allowed in %s", limits.MaxCount, field),
+		})
+	}
+
+	for _, file := range files {
+		if err := v.validateFile(field, kind, limits, file); err != nil {
+			errs = append(errs, *err)
+		}
+	}
+	return errs
+}
+
+func (v *Validator) validateFile(field string, kind Kind, limits Limits, file *multipart.FileHeader) *FileError {
+	contentType, err := v.validateContent(field, kind, limits, file.Filename, file.Size, func() (string, error) {
+		return sniffFile(file)
+	})
+	if err != nil {
+		return err
+	}
+
+	if file.Header == nil {
+		file.Header = textproto.MIMEHeader{}
+	}
+	file.Header.Set("Content-Type", contentType)
+	return nil
+}
+
+// ValidateStream checks a single file that did not arrive as a multipart upload,
+// such as an assembled resumable upload, and returns its sniffed content type.
+// Only the leading bytes of r are read.
+func (v *Validator) ValidateStream(field string, kind Kind, filename string, size int64, r io.Reader) (string, *FileError) {
+	return v.validateContent(field, kind, v.limits[kind], filename, size, func() (string, error) {
+		return sniff(r)
+	})
+}
+
+func (v *Validator) validateContent(field string, kind Kind, limits Limits, filename string, size int64, detect func() (string, error)) (string, *FileError) {
+	fail := func(code, format string, args ...interface{}) *FileError {
+		return &FileError{Field: field, Filename: filename, Code: code, Message: fmt.Sprintf(format, args...)}
+	}
+
+	if size == 0 {
+		return "", fail(CodeEmpty, "%s is empty", filename)
+	}
+	if limits.MaxSize > 0 && size > limits.MaxSize {
+		return "", fail(CodeTooLarge, "%s is larger than %d bytes", filename, limits.MaxSize)
+	}
+
+	contentType, err := detect()
+	if err != nil {
+		return "", fail(CodeUnreadable, "%s could not be read", filename)
+	}
+	extensions, ok := allowedTypes[kind][contentType]
+	if !ok {
+		return "", fail(CodeUnsupportedType, "%s has unsupported content type %s for %s uploads", filename, contentType, kind)
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	if !slices.Contains(extensions, ext) {
+		return "", fail(CodeExtensionMismatch, "%s has extension %q but its content is %s", filename, ext, contentType)
+	}
+	return contentType, nil
+}
+
+// sniffFile detects the content type of an uploaded file from its magic bytes.
+func sniffFile(file *multipart.FileHeader) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+	return sniff(src)
+}
+
+func sniff(r io.Reader) (string, error) {
+	head := make([]byte, _sniffLen)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	return DetectContentType(head[:n]), nil
+}
+
+// DetectContentType extends http.DetectContentType with the ISO media brands it does not know.
+func DetectContentType(head []byte) string {
+	if len(head) >= 12 && bytes.Equal(head[4:8], []byte("ftyp")) && bytes.Equal(head[8:12], []byte("qt  ")) {
+		return "video/quicktime"
+	}
+	contentType := http.DetectContentType(head)
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return contentType
+}
@@ -0,0 +1,158 @@
+package media_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"tourism-backend/pkg/media"
+)
+
+var (
+	_pngHead  = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	_jpegHead = []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00")
+	_mp4Head  = []byte("\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00mp42isom")
+	_movHead  = []byte("\x00\x00\x00\x14ftypqt  \x00\x00\x00\x00qt  ")
+	_pdfHead  = []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+	_htmlHead = []byte("<!DOCTYPE html><html><script>alert(1)</script></html>")
+)
+
+type upload struct {
+	name    string
+	content []byte
+}
+
+func multipartFiles(t *testing.T, field string, uploads ...upload) []*multipart.FileHeader {
+	t.Helper()
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for _, u := range uploads {
+		part, err := w.CreateFormFile(field, u.name)
+		require.NoError(t, err)
+		_, err = part.Write(u.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	form, err := multipart.NewReader(&body, w.Boundary()).ReadForm(1 << 20)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = form.RemoveAll() })
+	return form.File[field]
+}
+
+func TestValidator(t *testing.T) {
+	v := media.NewValidator(
+		media.Limits{MaxSize: 64, MaxCount: 3},
+		media.Limits{MaxSize: 64, MaxCount: 1},
+		media.Limits{MaxSize: 64, MaxCount: 1},
+	)
+
+	tests := []struct {
+		name  string
+		kind  media.Kind
+		files []upload
+		codes []string
+	}{
+		{
+			name:  "valid images",
+			kind:  media.KindImage,
+			files: []upload{{"a.png", _pngHead}, {"b.JPG", _jpegHead}},
+		},
+		{
+			name:  "valid videos",
+			kind:  media.KindVideo,
+			files: []upload{{"clip.mov", _movHead}},
+		},
+		{
+			name:  "valid document",
+			kind:  media.KindDocument,
+			files: []upload{{"licence.pdf", _pdfHead}},
+		},
+		{
+			name:  "video as document",
+			kind:  media.KindDocument,
+			files: []upload{{"licence.pdf", _mp4Head}},
+			codes: []string{media.CodeUnsupportedType},
+		},
+		{
+			name:  "html disguised as image",
+			kind:  media.KindImage,
+			files: []upload{{"a.png", _htmlHead}},
+			codes: []string{media.CodeUnsupportedType},
+		},
+		{
+			name:  "extension mismatch",
+			kind:  media.KindImage,
+			files: []upload{{"a.jpg", _pngHead}},
+			codes: []string{media.CodeExtensionMismatch},
+		},
+		{
+			name:  "video in image field",
+			kind:  media.KindImage,
+			files: []upload{{"a.mp4", _mp4Head}},
+			codes: []string{media.CodeUnsupportedType},
+		},
+		{
+			name:  "too large",
+			kind:  media.KindImage,
+			files: []upload{{"a.png", append(append([]byte{}, _pngHead...), make([]byte, 64)...)}},
+			codes: []string{media.CodeTooLarge},
+		},
+		{
+			name:  "empty",
+			kind:  media.KindImage,
+			files: []upload{{"a.png", nil}},
+			codes: []string{media.CodeEmpty},
+		},
+		{
+			name:  "too many",
+			kind:  media.KindVideo,
+			files: []upload{{"a.mp4", _mp4Head}, {"b.exe", []byte("MZ\x90\x00")}},
+			codes: []string{media.CodeTooMany, media.CodeUnsupportedType},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := multipartFiles(t, "files", tt.files...)
+			errs := v.Validate("files", tt.kind, files)
+
+			codes := make([]string, 0, len(errs))
+			for _, e := range errs {
+				require.Equal(t, "files", e.Field)
+				codes = append(codes, e.Code)
+			}
+			if len(tt.codes) == 0 {
+				require.Empty(t, errs)
+				return
+			}
+			require.Equal(t, tt.codes, codes)
+		})
+	}
+}
+
+func TestValidatorSetsSniffedContentType(t *testing.T) {
+	v := media.NewValidator(media.Limits{}, media.Limits{}, media.Limits{})
+	files := multipartFiles(t, "images", upload{"a.png", _pngHead})
+	files[0].Header.Set("Content-Type", "text/html")
+
+	require.Empty(t, v.Validate("images", media.KindImage, files))
+	require.Equal(t, "image/png", files[0].Header.Get("Content-Type"))
+}
+
+func TestValidatorValidateStream(t *testing.T) {
+	v := media.NewValidator(media.Limits{}, media.Limits{MaxSize: 1 << 20}, media.Limits{})
+
+	contentType, err := v.ValidateStream("video", media.KindVideo, "walk.mov", 1024, bytes.NewReader(_movHead))
+	require.Nil(t, err)
+	require.Equal(t, "video/quicktime", contentType)
+
+	_, err = v.ValidateStream("video", media.KindVideo, "walk.mp4", 2<<20, bytes.NewReader(_mp4Head))
+	require.Equal(t, media.CodeTooLarge, err.Code)
+
+	_, err = v.ValidateStream("video", media.KindVideo, "walk.mp4", 1024, bytes.NewReader(_htmlHead))
+	require.Equal(t, media.CodeUnsupportedType, err.Code)
+}
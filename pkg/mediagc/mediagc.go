@@ -0,0 +1,70 @@
+package mediagc
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"tourism-backend/internal/usecase"
+	"tourism-backend/pkg/logger"
+)
+
+// OrphanCollector periodically deletes stored media files that no tour, image variant or review references,
+// and resumable uploads that expired before completion.
+type OrphanCollector struct {
+	interval         time.Duration
+	gracePeriod      time.Duration
+	tourMediaUsecase usecase.TourMediaInterface
+	uploadUsecase    usecase.UploadInterface
+	l                logger.Interface
+	ctx              context.Context
+	cancel           context.CancelFunc
+}
+
+func NewOrphanCollector(interval, gracePeriod time.Duration, tourMedia usecase.TourMediaInterface, uploads usecase.UploadInterface, l logger.Interface) *OrphanCollector {
+	c := &OrphanCollector{
+		interval:         interval,
+		gracePeriod:      gracePeriod,
+		tourMediaUsecase: tourMedia,
+		uploadUsecase:    uploads,
+		l:                l,
+	}
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+
+	// Start the worker goroutine
+	go c.Run()
+
+	return c
+}
+
+func (c *OrphanCollector) Run() {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			deleted, err := c.tourMediaUsecase.CollectOrphanedMedia(c.ctx, c.gracePeriod)
+			if err != nil {
+				c.l.Error(fmt.Errorf("mediagc - CollectOrphanedMedia: %w", err))
+			}
+			if deleted > 0 {
+				c.l.Info("mediagc - orphaned media collection: %d files deleted", deleted)
+			}
+
+			expired, err := c.uploadUsecase.DeleteExpiredUploads(c.ctx)
+			if err != nil {
+				c.l.Error(fmt.Errorf("mediagc - DeleteExpiredUploads: %w", err))
+			}
+			if expired > 0 {
+				c.l.Info("mediagc - expired upload collection: %d uploads deleted", expired)
+			}
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// Stop stops the worker and cancels the run in progress. It is safe to call more than once.
+func (c *OrphanCollector) Stop() {
+	c.cancel()
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import "sync"
+
+// _otherLabel is the value of the label values past the bound.
+const _otherLabel = "other"
+
+// boundedLabel passes through the first max distinct values of a label and reports later ones
+// as other, so a label fed from data cannot create series without limit.
+type boundedLabel struct {
+	mu   sync.Mutex
+	max  int
+	seen map[string]struct{}
+}
+
+func newBoundedLabel(max int) *boundedLabel {
+	return &boundedLabel{max: max, seen: make(map[string]struct{}, max)}
+}
+
+func (b *boundedLabel) value(v string) string {
+	if v == "" {
+		return _otherLabel
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.seen[v]; ok {
+		return v
+	}
+	if len(b.seen) >= b.max {
+		return _otherLabel
+	}
+	b.seen[v] = struct{}{}
+	return v
+}
@@ -0,0 +1,183 @@
+// Package metrics holds the Prometheus metrics of the business flows: purchases and their payment,
+// seats sold, logins and HTTP requests. They are registered with the default registry that /metrics
+// serves. Every label takes values from a fixed set or is bounded, so series cannot grow with traffic.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const _namespace = "tourism"
+
+// Statuses of the purchase lifecycle.
+const (
+	PurchaseCreated = "created"
+	PurchasePaid    = "paid"
+	PurchaseFailed  = "failed"
+)
+
+// Outcomes of a payment.
+const (
+	PaymentPaid     = "paid"
+	PaymentDeclined = "declined"
+	PaymentError    = "error"
+	// PaymentCancelled is a payment given up on shutdown.
+	PaymentCancelled = "cancelled"
+)
+
+// Results of a login.
+const (
+	LoginSuccess = "success"
+	LoginFailure = "failure"
+	LoginError   = "error"
+)
+
+const (
+	// _maxCategories bounds the categories seats sold are counted by, the rest are counted as other.
+	_maxCategories = 100
+	// _uncategorized labels the seats of tours without a category.
+	_uncategorized = "uncategorized"
+	// _unmatchedRoute labels the requests that matched no route, so unknown paths are not labels.
+	_unmatchedRoute = "unmatched"
+)
+
+var (
+	purchases = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: _namespace,
+		Name:      "purchases_total",
+		Help:      "Purchases by lifecycle status: created when a seat is taken, paid or failed once the payment finished.",
+	}, []string{"status"})
+
+	paymentDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: _namespace,
+		Name:      "payment_duration_seconds",
+		Help:      "Time from enqueueing a purchase for payment until its payment finished, by outcome.",
+		Buckets:   []float64{1, 2.5, 5, 7.5, 10, 15, 30, 60, 120, 300},
+	}, []string{"outcome"})
+
+	paymentQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: _namespace,
+		Name:      "payment_queue_depth",
+		Help:      "Purchases waiting in the payment queue.",
+	})
+
+	paymentWorkers = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: _namespace,
+		Name:      "payment_workers",
+		Help:      "Workers paying queued purchases.",
+	})
+
+	paymentWorkersBusy = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: _namespace,
+		Name:      "payment_workers_busy",
+		Help:      "Workers paying a purchase right now.",
+	})
+
+	paymentWorkerBusySeconds = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: _namespace,
+		Name:      "payment_worker_busy_seconds_total",
+		Help:      "Time workers spent paying purchases, its rate over payment_workers is the utilization.",
+	})
+
+	seatsSold = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: _namespace,
+		Name:      "seats_sold_total",
+		Help:      "Seats of tour events sold, by category slug of the tour. A seat counts once for every category of its tour.",
+	}, []string{"category"})
+
+	logins = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: _namespace,
+		Name:      "logins_total",
+		Help:      "Logins by result: success, failure for invalid credentials, error when they could not be checked.",
+	}, []string{"result"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: _namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Time to serve HTTP requests, by method, route template and status class.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	categories = newBoundedLabel(_maxCategories)
+)
+
+// PurchaseCreatedIn counts a purchase that took a seat of a tour in the given categories.
+func PurchaseCreatedIn(categorySlugs ...string) {
+	purchases.WithLabelValues(PurchaseCreated).Inc()
+	if len(categorySlugs) == 0 {
+		seatsSold.WithLabelValues(_uncategorized).Inc()
+		return
+	}
+	for _, slug := range categorySlugs {
+		seatsSold.WithLabelValues(categories.value(slug)).Inc()
+	}
+}
+
+// PaymentFinished counts a payment with the given outcome that took latency since it was enqueued.
+// Paid payments count their purchase as paid, the others as failed.
+func PaymentFinished(outcome string, latency time.Duration) {
+	status := PurchaseFailed
+	if outcome == PaymentPaid {
+		status = PurchasePaid
+	}
+	purchases.WithLabelValues(status).Inc()
+	paymentDuration.WithLabelValues(outcome).Observe(latency.Seconds())
+}
+
+// SetPaymentQueueDepth sets the number of purchases waiting to be paid.
+func SetPaymentQueueDepth(n int) {
+	paymentQueueDepth.Set(float64(n))
+}
+
+// AddPaymentWorkers changes the number of payment workers by delta.
+func AddPaymentWorkers(delta int) {
+	paymentWorkers.Add(float64(delta))
+}
+
+// PaymentWorkerBusy marks a worker busy until the returned function is called.
+func PaymentWorkerBusy() (done func()) {
+	start := time.Now()
+	paymentWorkersBusy.Inc()
+	return func() {
+		paymentWorkersBusy.Dec()
+		paymentWorkerBusySeconds.Add(time.Since(start).Seconds())
+	}
+}
+
+// Login counts a login with the given result.
+func Login(result string) {
+	logins.WithLabelValues(result).Inc()
+}
+
+// ObserveHTTPRequest records the time taken to serve a request. Route is the route template,
+// empty when no route matched.
+func ObserveHTTPRequest(method, route string, status int, d time.Duration) {
+	if route == "" {
+		route = _unmatchedRoute
+	}
+	httpDuration.WithLabelValues(httpMethod(method), route, statusClass(status)).Observe(d.Seconds())
+}
+
+// httpMethod keeps the standard methods, anything else a client sends is counted as OTHER.
+func httpMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodOptions:
+		return method
+	default:
+		return "OTHER"
+	}
+}
+
+// statusClass reduces a status code to its class, e.g. 2xx.
+func statusClass(status int) string {
+	if status < 100 || status > 599 {
+		return "unknown"
+	}
+	return strconv.Itoa(status/100) + "xx"
+}
@@ -0,0 +1,57 @@
+package metrics_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"tourism-backend/pkg/metrics"
+)
+
+// series returns the label sets of the series of the named metric in the default registry.
+func series(t *testing.T, name string) []map[string]string {
+	t.Helper()
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+
+	var sets []map[string]string
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string, len(metric.GetLabel()))
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			sets = append(sets, labels)
+		}
+	}
+	return sets
+}
+
+func TestSeatsSoldCategoriesAreBounded(t *testing.T) {
+	for i := 0; i < 150; i++ {
+		metrics.PurchaseCreatedIn(fmt.Sprintf("category-%d", i))
+	}
+	metrics.PurchaseCreatedIn()
+
+	sets := series(t, "tourism_seats_sold_total")
+	require.Len(t, sets, 102, "100 categories, other and uncategorized")
+	require.Contains(t, sets, map[string]string{"category": "other"})
+	require.Contains(t, sets, map[string]string{"category": "uncategorized"})
+}
+
+func TestHTTPRequestLabels(t *testing.T) {
+	metrics.ObserveHTTPRequest(http.MethodGet, "/v1/tours/:id", http.StatusNotFound, time.Millisecond)
+	metrics.ObserveHTTPRequest("PROPFIND", "", http.StatusMethodNotAllowed, time.Millisecond)
+
+	sets := series(t, "tourism_http_request_duration_seconds")
+	require.Contains(t, sets, map[string]string{"method": "GET", "route": "/v1/tours/:id", "status": "4xx"})
+	require.Contains(t, sets, map[string]string{"method": "OTHER", "route": "unmatched", "status": "4xx"})
+}
@@ -1,55 +1,261 @@
 package payment
 
 import (
-	"log"
+	"context"
+	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 	"tourism-backend/internal/entity"
 	"tourism-backend/internal/usecase"
+	"tourism-backend/pkg/logger"
+	"tourism-backend/pkg/metrics"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// _processingDelay simulates the response time of the payment gateway.
+	_processingDelay = 5 * time.Second
+	// _heartbeatInterval is how often an idle worker reports that it is alive.
+	_heartbeatInterval = time.Second
+	// _heartbeatTimeout is how long the worker may go unseen: a payment plus a few missed beats.
+	_heartbeatTimeout = _processingDelay + 5*_heartbeatInterval
+	// _failTimeout bounds marking a purchase failed once its payment was cancelled by the shutdown.
+	_failTimeout = 5 * time.Second
 )
 
+// ErrShuttingDown is returned by Enqueue once Shutdown has been called.
+var ErrShuttingDown = errors.New("payment processor is shutting down")
+
+const _tracerName = "tourism-backend/pkg/payment"
+
+// job is a queued purchase along with the ID and trace context of the request that made it,
+// so its payment shows up in the logs and trace of that request.
+type job struct {
+	purchase   *entity.Purchase
+	requestID  string
+	carrier    propagation.MapCarrier
+	enqueuedAt time.Time
+}
+
 type PaymentProcessor struct {
-	mu             sync.Mutex
-	PurchaseQueue  chan *entity.Purchase
+	// mu guards closed. Enqueue joins senders under it and sends without it, Shutdown closes
+	// the queue once the senders have left.
+	mu      sync.RWMutex
+	closed  bool
+	senders sync.WaitGroup
+	// closing is closed by Shutdown to release the senders waiting for room in the queue.
+	closing        chan struct{}
+	queue          chan job
 	tourismUsecase usecase.TourismInterface
+	l              logger.Interface
+	// heartbeat is the time in Unix nanoseconds the worker was last seen.
+	heartbeat atomic.Int64
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
 }
 
-func NewPaymentProcessor(bufferSize int, usecase usecase.TourismInterface) *PaymentProcessor {
+func NewPaymentProcessor(bufferSize int, usecase usecase.TourismInterface, l logger.Interface) *PaymentProcessor {
 	p := &PaymentProcessor{
-		PurchaseQueue:  make(chan *entity.Purchase, bufferSize),
+		closing:        make(chan struct{}),
+		queue:          make(chan job, bufferSize),
 		tourismUsecase: usecase,
+		l:              l,
+		done:           make(chan struct{}),
 	}
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	p.beat()
 
 	// Start the worker goroutine
+	metrics.AddPaymentWorkers(1)
 	go p.ProcessPurchases()
 
 	return p
 }
 
-func (p *PaymentProcessor) ProcessPurchases() {
-	for purchase := range p.PurchaseQueue {
-		p.mu.Lock()
+// Enqueue schedules a purchase for payment. It waits for room in the queue until ctx is done.
+func (p *PaymentProcessor) Enqueue(ctx context.Context, purchase *entity.Purchase) (err error) {
+	ctx, span := otel.Tracer(_tracerName).Start(ctx, "payment enqueue",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attribute.String("purchase.id", purchase.ID.String())),
+	)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
 
-		log.Printf("Processing purchase: User %s -> TourEvent %s\n", purchase.UserID, purchase.TourEventID)
+	j := job{
+		purchase:   purchase,
+		requestID:  logger.RequestID(ctx),
+		carrier:    propagation.MapCarrier{},
+		enqueuedAt: time.Now(),
+	}
+	otel.GetTextMapPropagator().Inject(ctx, j.carrier)
 
-		// Simulate payment processing
-		time.Sleep(5 * time.Second) // Simulate network delay
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		return ErrShuttingDown
+	}
+	p.senders.Add(1)
+	p.mu.RUnlock()
+	defer p.senders.Done()
+
+	select {
+	case p.queue <- j:
+		metrics.SetPaymentQueueDepth(len(p.queue))
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-p.closing:
+		return ErrShuttingDown
+	}
+}
+
+func (p *PaymentProcessor) ProcessPurchases() {
+	defer close(p.done)
+	defer metrics.AddPaymentWorkers(-1)
 
-		// Mock payment API
-		success := mockPaymentGateway(1)
+	ticker := time.NewTicker(_heartbeatInterval)
+	defer ticker.Stop()
 
-		if success {
-			err := p.tourismUsecase.PayTourEvent(purchase)
-			if err != nil {
-				log.Printf("Payment processing error: %v\n", err)
+	for {
+		select {
+		case j, ok := <-p.queue:
+			if !ok {
+				return
 			}
-			log.Printf("Payment successful for User %s on TourEvent %s\n", purchase.UserID, purchase.TourEventID)
-		} else {
-			log.Printf("Payment failed for User %s\n", purchase.UserID)
+			metrics.SetPaymentQueueDepth(len(p.queue))
+			p.process(j)
+			p.beat()
+		case <-ticker.C:
+			p.beat()
+		}
+	}
+}
+
+func (p *PaymentProcessor) beat() {
+	p.heartbeat.Store(time.Now().UnixNano())
+}
+
+// Check returns an error when the worker has stopped or has not been seen for longer than
+// a payment takes, it is a readiness check.
+func (p *PaymentProcessor) Check(context.Context) error {
+	select {
+	case <-p.done:
+		return errors.New("payment worker stopped")
+	default:
+	}
+	if since := time.Since(time.Unix(0, p.heartbeat.Load())); since > _heartbeatTimeout {
+		return fmt.Errorf("payment worker last seen %s ago", since.Round(time.Second))
+	}
+	return nil
+}
+
+func (p *PaymentProcessor) process(j job) {
+	defer metrics.PaymentWorkerBusy()()
+
+	purchase := j.purchase
+	ctx := otel.GetTextMapPropagator().Extract(logger.WithRequestID(p.ctx, j.requestID), j.carrier)
+	ctx, span := otel.Tracer(_tracerName).Start(ctx, "payment process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("purchase.id", purchase.ID.String()),
+			attribute.Int64("payment.queue_wait_ms", time.Since(j.enqueuedAt).Milliseconds()),
+		),
+	)
+	defer span.End()
+
+	l := p.l.WithContext(ctx).With(
+		"purchase_id", purchase.ID.String(),
+		"user_id", purchase.UserID.String(),
+		"tour_event_id", purchase.TourEventID.String(),
+	)
+	l.Info("payment - processing purchase")
+
+	// Simulate payment processing
+	_, gatewaySpan := otel.Tracer(_tracerName).Start(ctx, "payment gateway", trace.WithSpanKind(trace.SpanKindClient))
+	select {
+	case <-time.After(_processingDelay): // Simulate network delay
+	case <-p.ctx.Done():
+		gatewaySpan.End()
+		span.SetStatus(codes.Error, "interrupted by shutdown")
+		p.fail(ctx, l, j)
+		return
+	}
+
+	// Mock payment API
+	success := mockPaymentGateway(1)
+	gatewaySpan.SetAttributes(attribute.Bool("payment.success", success))
+	gatewaySpan.End()
+
+	if success {
+		err := p.tourismUsecase.PayTourEvent(ctx, purchase)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "pay tour event")
+			l.Error(fmt.Errorf("payment - PayTourEvent: %w", err))
+			metrics.PaymentFinished(metrics.PaymentError, time.Since(j.enqueuedAt))
+			return
 		}
+		l.Info("payment - purchase paid")
+		metrics.PaymentFinished(metrics.PaymentPaid, time.Since(j.enqueuedAt))
+	} else {
+		span.SetStatus(codes.Error, "payment declined")
+		l.Warn("payment - payment declined")
+		metrics.PaymentFinished(metrics.PaymentDeclined, time.Since(j.enqueuedAt))
+	}
+}
+
+// fail marks the purchase of a payment cancelled by the shutdown as failed, so it does not stay
+// processing with its seat taken. The purchase is not retried: another instance may be paying it.
+func (p *PaymentProcessor) fail(ctx context.Context, l logger.Interface, j job) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), _failTimeout)
+	defer cancel()
+
+	metrics.PaymentFinished(metrics.PaymentCancelled, time.Since(j.enqueuedAt))
+	if err := p.tourismUsecase.FailPurchase(ctx, j.purchase); err != nil {
+		l.Error(fmt.Errorf("payment - FailPurchase: %w", err))
+		return
+	}
+	l.Warn("payment - cancelled by shutdown, purchase failed")
+}
 
+// Shutdown stops accepting purchases and waits for the queued ones to be paid.
+// When ctx is done first, the remaining payments are cancelled, their purchases are marked failed
+// and ctx.Err() is returned.
+// It is safe to call more than once.
+func (p *PaymentProcessor) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.closing)
+		p.mu.Unlock()
+		p.senders.Wait()
+		close(p.queue)
+	} else {
 		p.mu.Unlock()
 	}
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		<-p.done
+		return ctx.Err()
+	}
 }
 
 // Simulation of a payment gateway response
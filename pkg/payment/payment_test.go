@@ -0,0 +1,93 @@
+package payment_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+
+	"tourism-backend/internal/entity"
+	"tourism-backend/internal/usecase"
+	"tourism-backend/pkg/logger"
+	"tourism-backend/pkg/payment"
+	"tourism-backend/pkg/tracing"
+)
+
+// failingUseCase records the purchases marked failed, the other methods are not called.
+type failingUseCase struct {
+	usecase.TourismInterface
+
+	mu     sync.Mutex
+	failed []uuid.UUID
+}
+
+func (u *failingUseCase) FailPurchase(ctx context.Context, purchase *entity.Purchase) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.failed = append(u.failed, purchase.ID)
+	return nil
+}
+
+func TestEnqueueCarriesTrace(t *testing.T) {
+	recorder := tracing.NewRecorder()
+	defer recorder.Stop()
+
+	processor := payment.NewPaymentProcessor(1, &failingUseCase{}, logger.New("error"))
+
+	ctx, request := otel.Tracer("test").Start(context.Background(), "request")
+	require.NoError(t, processor.Enqueue(ctx, &entity.Purchase{ID: uuid.New()}))
+	request.End()
+
+	// The payment is interrupted, its span still ends in the trace of the request.
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, processor.Shutdown(cancelled), context.Canceled)
+
+	traceID := request.SpanContext().TraceID()
+	names := make([]string, 0)
+	for _, span := range recorder.GetSpans() {
+		require.Equal(t, traceID, span.SpanContext.TraceID(), span.Name)
+		names = append(names, span.Name)
+	}
+	require.ElementsMatch(t, []string{"request", "payment enqueue", "payment process", "payment gateway"}, names)
+}
+
+func TestCheckFailsOnceWorkerStopped(t *testing.T) {
+	processor := payment.NewPaymentProcessor(1, nil, logger.New("error"))
+	require.NoError(t, processor.Check(context.Background()))
+
+	require.NoError(t, processor.Shutdown(context.Background()))
+	require.EqualError(t, processor.Check(context.Background()), "payment worker stopped")
+}
+
+func TestShutdownReleasesBlockedEnqueue(t *testing.T) {
+	tourism := &failingUseCase{}
+	processor := payment.NewPaymentProcessor(1, tourism, logger.New("error"))
+
+	// The worker pays the first purchase and the second fills the queue, so the third waits for room.
+	queued := []uuid.UUID{uuid.New(), uuid.New()}
+	for _, id := range queued {
+		require.NoError(t, processor.Enqueue(context.Background(), &entity.Purchase{ID: id}))
+	}
+	blocked := make(chan error, 1)
+	go func() {
+		blocked <- processor.Enqueue(context.Background(), &entity.Purchase{ID: uuid.New()})
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, processor.Shutdown(cancelled), context.Canceled)
+	require.ErrorIs(t, <-blocked, payment.ErrShuttingDown)
+	require.ErrorIs(t, processor.Enqueue(context.Background(), &entity.Purchase{ID: uuid.New()}), payment.ErrShuttingDown)
+
+	// The payment in progress and the queued one are cancelled, their purchases are failed.
+	require.ElementsMatch(t, queued, tourism.failed)
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"tourism-backend/pkg/logger"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// queryLogger writes the failed and slow queries of GORM to the application logger,
+// with the request fields of the query context.
+type queryLogger struct {
+	l             logger.Interface
+	level         gormlogger.LogLevel
+	slowThreshold time.Duration
+}
+
+var _ gormlogger.Interface = (*queryLogger)(nil)
+
+// NewQueryLogger -.
+func NewQueryLogger(l logger.Interface, slowThreshold time.Duration) gormlogger.Interface {
+	return &queryLogger{l: l, level: gormlogger.Warn, slowThreshold: slowThreshold}
+}
+
+// LogMode -.
+func (q *queryLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	copied := *q
+	copied.level = level
+	return &copied
+}
+
+// Info -.
+func (q *queryLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if q.level >= gormlogger.Info {
+		q.l.WithContext(ctx).Info("gorm - "+msg, data...)
+	}
+}
+
+// Warn -.
+func (q *queryLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if q.level >= gormlogger.Warn {
+		q.l.WithContext(ctx).Warn("gorm - "+msg, data...)
+	}
+}
+
+// Error -.
+func (q *queryLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if q.level >= gormlogger.Error {
+		q.l.WithContext(ctx).Error("gorm - "+msg, data...)
+	}
+}
+
+// Trace logs a query that failed or took longer than the slow threshold. Missing records are
+// expected by the callers and not logged, nor are queries cancelled with their request.
+func (q *queryLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if q.level <= gormlogger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+
+	switch {
+	case err != nil && q.level >= gormlogger.Error &&
+		!errors.Is(err, gorm.ErrRecordNotFound) && !errors.Is(err, context.Canceled):
+		q.entry(ctx, elapsed, fc).Error(fmt.Errorf("gorm - query failed: %w", err))
+	case q.slowThreshold > 0 && elapsed > q.slowThreshold && q.level >= gormlogger.Warn:
+		q.entry(ctx, elapsed, fc).Warn("gorm - slow query")
+	}
+}
+
+func (q *queryLogger) entry(ctx context.Context, elapsed time.Duration, fc func() (string, int64)) logger.Interface {
+	sql, rows := fc()
+	return q.l.WithContext(ctx).With(
+		"sql", sql,
+		"rows", rows,
+		"elapsed_ms", elapsed.Milliseconds(),
+	)
+}
@@ -0,0 +1,59 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"tourism-backend/pkg/logger"
+	"tourism-backend/pkg/postgres"
+)
+
+// recordingLogger keeps the levels of the entries written to it.
+type recordingLogger struct {
+	levels *[]string
+}
+
+func (r recordingLogger) Debug(interface{}, ...interface{})            { *r.levels = append(*r.levels, "debug") }
+func (r recordingLogger) Info(string, ...interface{})                  { *r.levels = append(*r.levels, "info") }
+func (r recordingLogger) Warn(string, ...interface{})                  { *r.levels = append(*r.levels, "warn") }
+func (r recordingLogger) Error(interface{}, ...interface{})            { *r.levels = append(*r.levels, "error") }
+func (r recordingLogger) Fatal(interface{}, ...interface{})            { *r.levels = append(*r.levels, "fatal") }
+func (r recordingLogger) WithContext(context.Context) logger.Interface { return r }
+func (r recordingLogger) With(...interface{}) logger.Interface         { return r }
+
+func TestQueryLoggerTrace(t *testing.T) {
+	t.Parallel()
+
+	sql := func() (string, int64) { return "SELECT 1", 1 }
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		err     error
+		want    []string
+	}{
+		{name: "fast query", elapsed: time.Millisecond},
+		{name: "slow query", elapsed: time.Second, want: []string{"warn"}},
+		{name: "failed query", elapsed: time.Millisecond, err: errors.New("connection reset"), want: []string{"error"}},
+		{name: "record not found", elapsed: time.Millisecond, err: fmt.Errorf("get: %w", gorm.ErrRecordNotFound)},
+		{name: "cancelled query", elapsed: time.Second, err: context.Canceled, want: []string{"warn"}},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var levels []string
+			queryLogger := postgres.NewQueryLogger(recordingLogger{&levels}, 100*time.Millisecond)
+			queryLogger.Trace(context.Background(), time.Now().Add(-tc.elapsed), sql, tc.err)
+			require.Equal(t, tc.want, levels)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/golang-migrate/migrate/v4"
+	migratepg "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	// database/sql driver of the migration connection
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// ErrSchemaVersion is returned by Check when the database is not at the latest migration.
+var ErrSchemaVersion = errors.New("unexpected schema version")
+
+// Migrator applies the versioned SQL migrations of a file system to the database.
+type Migrator struct {
+	m      *migrate.Migrate
+	latest uint
+}
+
+// NewMigrator opens a dedicated connection to url for the migrations in migrations.
+func NewMigrator(url string, migrations fs.FS) (*Migrator, error) {
+	src, err := iofs.New(migrations, ".")
+	if err != nil {
+		return nil, fmt.Errorf("migrations source: %w", err)
+	}
+	latest, err := latestVersion(src)
+	if err != nil {
+		src.Close()
+		return nil, err
+	}
+
+	db, err := sql.Open("pgx", url)
+	if err != nil {
+		src.Close()
+		return nil, fmt.Errorf("migrations connection: %w", err)
+	}
+	driver, err := migratepg.WithInstance(db, &migratepg.Config{})
+	if err != nil {
+		src.Close()
+		db.Close()
+		return nil, fmt.Errorf("migrations driver: %w", err)
+	}
+	m, err := migrate.NewWithInstance("iofs", src, "postgres", driver)
+	if err != nil {
+		src.Close()
+		driver.Close()
+		return nil, fmt.Errorf("migrations: %w", err)
+	}
+	return &Migrator{m: m, latest: latest}, nil
+}
+
+// LatestVersion returns the version of the last migration in migrations.
+func LatestVersion(migrations fs.FS) (uint, error) {
+	src, err := iofs.New(migrations, ".")
+	if err != nil {
+		return 0, fmt.Errorf("migrations source: %w", err)
+	}
+	defer src.Close()
+	return latestVersion(src)
+}
+
+func latestVersion(src source.Driver) (uint, error) {
+	version, err := src.First()
+	if err != nil {
+		return 0, fmt.Errorf("first migration: %w", err)
+	}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("next migration: %w", err)
+		}
+		version = next
+	}
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up() error {
+	if err := m.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("migrate up: %w", err)
+	}
+	return nil
+}
+
+// Down rolls back the last steps migrations.
+func (m *Migrator) Down(steps int) error {
+	if err := m.m.Steps(-steps); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("migrate down: %w", err)
+	}
+	return nil
+}
+
+// Force records version as applied and clears the dirty flag without running any migration.
+// It is used to recover from a failed migration once the schema was fixed by hand.
+func (m *Migrator) Force(version int) error {
+	if err := m.m.Force(version); err != nil {
+		return fmt.Errorf("migrate force: %w", err)
+	}
+	return nil
+}
+
+// Version returns the applied version, 0 when no migration was applied yet.
+// dirty reports a migration that failed halfway.
+func (m *Migrator) Version() (version uint, dirty bool, err error) {
+	version, dirty, err = m.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("migrate version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// Latest returns the version of the last known migration.
+func (m *Migrator) Latest() uint {
+	return m.latest
+}
+
+// Check returns ErrSchemaVersion unless the schema is cleanly at the latest version.
+func (m *Migrator) Check() error {
+	version, dirty, err := m.Version()
+	if err != nil {
+		return err
+	}
+	return checkVersion(version, dirty, m.latest)
+}
+
+// CheckSchema is Check over the connection of p, for checks repeated while the service runs.
+// latest is the version of the last migration, see LatestVersion.
+func (p *Postgres) CheckSchema(ctx context.Context, latest uint) error {
+	var (
+		version uint
+		dirty   bool
+	)
+	err := p.primary().QueryRowContext(ctx, "SELECT version, dirty FROM "+migratepg.DefaultMigrationsTable+" LIMIT 1").Scan(&version, &dirty)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("check schema: %w", err)
+	}
+	return checkVersion(version, dirty, latest)
+}
+
+func checkVersion(version uint, dirty bool, latest uint) error {
+	if dirty {
+		return fmt.Errorf("%w: migration %d is dirty, fix the schema and run migrate force", ErrSchemaVersion, version)
+	}
+	if version != latest {
+		return fmt.Errorf("%w: database is at %d, expected %d, run migrate up", ErrSchemaVersion, version, latest)
+	}
+	return nil
+}
+
+// Close releases the migration connection.
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
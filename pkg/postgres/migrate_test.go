@@ -0,0 +1,47 @@
+package postgres_test
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+
+	"tourism-backend/migrations"
+	"tourism-backend/pkg/postgres"
+)
+
+func TestLatestVersion(t *testing.T) {
+	t.Parallel()
+
+	files := fstest.MapFS{
+		"000001_init.up.sql":     {Data: []byte("SELECT 1;")},
+		"000001_init.down.sql":   {Data: []byte("SELECT 1;")},
+		"000003_later.up.sql":    {Data: []byte("SELECT 1;")},
+		"000003_later.down.sql":  {Data: []byte("SELECT 1;")},
+		"000002_middle.up.sql":   {Data: []byte("SELECT 1;")},
+		"000002_middle.down.sql": {Data: []byte("SELECT 1;")},
+	}
+	version, err := postgres.LatestVersion(files)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, version)
+
+	_, err = postgres.LatestVersion(fstest.MapFS{})
+	require.Error(t, err)
+}
+
+func TestMigrationsArePaired(t *testing.T) {
+	t.Parallel()
+
+	ups, err := fs.Glob(migrations.FS, "*.up.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, ups)
+	for _, up := range ups {
+		_, err := fs.Stat(migrations.FS, strings.TrimSuffix(up, ".up.sql")+".down.sql")
+		require.NoError(t, err, "missing down migration of %s", up)
+	}
+
+	_, err = postgres.LatestVersion(migrations.FS)
+	require.NoError(t, err)
+}
@@ -1,26 +1,78 @@
 package postgres
 
+import (
+	"time"
+	"tourism-backend/pkg/logger"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
 // Option -.
 type Option func(*Postgres)
 
-//
-//// MaxPoolSize -.
-//func MaxPoolSize(size int) Option {
-//	return func(c *Postgres) {
-//		c.maxPoolSize = size
-//	}
-//}
-//
-//// ConnAttempts -.
-//func ConnAttempts(attempts int) Option {
-//	return func(c *Postgres) {
-//		c.connAttempts = attempts
-//	}
-//}
-//
-//// ConnTimeout -.
-//func ConnTimeout(timeout time.Duration) Option {
-//	return func(c *Postgres) {
-//		c.connTimeout = timeout
-//	}
-//}
+// Logger writes failed queries and queries slower than slowThreshold to l, along with the failed connection
+// attempts. Zero disables slow query logging.
+func Logger(l logger.Interface, slowThreshold time.Duration) Option {
+	return func(c *Postgres) {
+		c.l = l
+		c.logger = NewQueryLogger(l, slowThreshold)
+	}
+}
+
+// MaxPoolSize -.
+func MaxPoolSize(size int) Option {
+	return func(c *Postgres) {
+		c.maxPoolSize = size
+	}
+}
+
+// MaxIdleConns bounds the connections kept open while idle.
+func MaxIdleConns(n int) Option {
+	return func(c *Postgres) {
+		c.maxIdleConns = n
+	}
+}
+
+// ConnMaxLifetime closes connections once they are this old, zero keeps them forever.
+func ConnMaxLifetime(lifetime time.Duration) Option {
+	return func(c *Postgres) {
+		c.connMaxLifetime = lifetime
+	}
+}
+
+// StatementTimeout makes the server cancel statements running longer than timeout, zero disables it.
+func StatementTimeout(timeout time.Duration) Option {
+	return func(c *Postgres) {
+		c.statementTimeout = timeout
+	}
+}
+
+// ConnAttempts -.
+func ConnAttempts(attempts int) Option {
+	return func(c *Postgres) {
+		c.connAttempts = attempts
+	}
+}
+
+// ConnTimeout bounds each connection attempt.
+func ConnTimeout(timeout time.Duration) Option {
+	return func(c *Postgres) {
+		c.connTimeout = timeout
+	}
+}
+
+// Replicas sends reads of tables, given as models or table names, to the read replicas at urls.
+// Every read goes to them when no table is given.
+func Replicas(urls []string, tables ...interface{}) Option {
+	return func(c *Postgres) {
+		c.replicaURLs = urls
+		c.replicaTables = tables
+	}
+}
+
+// Metrics registers the stats of the connection pools with r.
+func Metrics(r prometheus.Registerer) Option {
+	return func(c *Postgres) {
+		c.registerer = r
+	}
+}
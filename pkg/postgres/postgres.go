@@ -2,64 +2,276 @@
 package postgres
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"strconv"
+	"time"
+	"tourism-backend/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"time"
-	"tourism-backend/config"
-	"tourism-backend/internal/entity"
+	gormlogger "gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
 )
 
 const (
-	_defaultMaxPoolSize  = 1
+	_defaultMaxPoolSize  = 10
+	_defaultMaxIdleConns = 5
 	_defaultConnAttempts = 10
 	_defaultConnTimeout  = time.Second
+	// _connBackoff is the wait after the first failed connection attempt, it doubles up to _maxConnBackoff.
+	_connBackoff    = 500 * time.Millisecond
+	_maxConnBackoff = 10 * time.Second
 )
 
 // Postgres -.
 type Postgres struct {
 	Conn *gorm.DB
+
+	maxPoolSize      int
+	maxIdleConns     int
+	connMaxLifetime  time.Duration
+	statementTimeout time.Duration
+	connAttempts     int
+	connTimeout      time.Duration
+
+	replicaURLs   []string
+	replicaTables []interface{}
+
+	registerer prometheus.Registerer
+	collectors []prometheus.Collector
+
+	logger gormlogger.Interface
+	// l reports the connection attempts, nil when no logger is set.
+	l logger.Interface
+
+	// pools are the connection pools of the primary and then of the replicas.
+	pools []*sql.DB
 }
 
-// New -.
+// New opens the connection pool of the primary at url, retrying until the database answers,
+// and the pools of the replicas when configured.
 func New(url string, opts ...Option) (*Postgres, error) {
+	pg := &Postgres{
+		maxPoolSize:  _defaultMaxPoolSize,
+		maxIdleConns: _defaultMaxIdleConns,
+		connAttempts: _defaultConnAttempts,
+		connTimeout:  _defaultConnTimeout,
+	}
+	for _, opt := range opts {
+		opt(pg)
+	}
 
-	db, err := gorm.Open(postgres.Open(url), &gorm.Config{})
+	db, err := pg.open(url)
 	if err != nil {
+		pg.Close()
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
-
-	pg := &Postgres{
-		Conn: db,
-	}
+	pg.Conn = db
 
 	return pg, nil
 }
 
-func (p *Postgres) Connect(cfg *config.Config) error {
-	conn, err := gorm.Open(postgres.Open(cfg.URL), &gorm.Config{})
+// txKey is the context key of the transaction started by Transaction.
+type txKey struct{}
+
+// txState is the transaction of a context and the functions to run once its outermost
+// transaction commits.
+type txState struct {
+	tx          *gorm.DB
+	afterCommit *[]func()
+}
+
+// Transaction runs fn in a transaction. Queries made through DB with the context given to fn
+// take part in it, so the writes of several repositories commit or roll back together.
+// Transactions started within fn are nested with savepoints.
+func (p *Postgres) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	parent, nested := ctx.Value(txKey{}).(*txState)
+	afterCommit := new([]func())
+	if nested {
+		afterCommit = parent.afterCommit
+	}
+	registered := len(*afterCommit)
+
+	err := p.DB(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, &txState{tx: tx, afterCommit: afterCommit}))
+	})
 	if err != nil {
+		*afterCommit = (*afterCommit)[:registered]
 		return err
 	}
+	if !nested {
+		for _, f := range *afterCommit {
+			f()
+		}
+	}
+	return nil
+}
+
+// DB returns the transaction started by Transaction for ctx, or the connection pools outside of one.
+func (p *Postgres) DB(ctx context.Context) *gorm.DB {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.tx.WithContext(ctx)
+	}
+	return p.Conn.WithContext(ctx)
+}
+
+// AfterCommit runs fn once the transaction of ctx commits, or right away outside of one.
+// Side effects that cannot be rolled back, such as deleting stored objects, are deferred with it.
+// fn is dropped when the transaction rolls back.
+func (p *Postgres) AfterCommit(ctx context.Context, fn func()) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		fn()
+		return
+	}
+	*state.afterCommit = append(*state.afterCommit, fn)
+}
+
+// Primary makes db read from the primary. Reads of rows just written and reads guarding a write
+// use it, replicas may lag behind.
+func Primary(db *gorm.DB) *gorm.DB {
+	return db.Clauses(dbresolver.Write)
+}
 
-	p.Conn = conn
+// Ping checks that the database answers within ctx. It bypasses GORM, so health checks are neither
+// traced nor logged as queries.
+func (p *Postgres) Ping(ctx context.Context) error {
+	if err := p.primary().PingContext(ctx); err != nil {
+		return fmt.Errorf("postgres ping: %w", err)
+	}
 	return nil
 }
 
-func (p *Postgres) Migrate() error {
-	err := p.Conn.AutoMigrate(
-		&entity.Tour{},
-		&entity.Image{},
-		&entity.Video{},
-		&entity.User{},
-		&entity.TourEvent{},
-		&entity.Purchase{},
-		&entity.TourCategory{},
-		&entity.TourLocation{},
-	)
+// Close closes the connection pools and unregisters their metrics.
+func (p *Postgres) Close() error {
+	for _, c := range p.collectors {
+		p.registerer.Unregister(c)
+	}
+	p.collectors = nil
+
+	errs := make([]error, 0, len(p.pools))
+	for _, pool := range p.pools {
+		errs = append(errs, pool.Close())
+	}
+	p.pools = nil
+	return errors.Join(errs...)
+}
+
+func (p *Postgres) primary() *sql.DB {
+	return p.pools[0]
+}
+
+// gormConfig translates unique and foreign key violations into
+// gorm.ErrDuplicatedKey and gorm.ErrForeignKeyViolated.
+func (p *Postgres) gormConfig() *gorm.Config {
+	return &gorm.Config{TranslateError: true, Logger: p.logger}
+}
+
+// open connects to url and the replicas, traces the queries and exports the pool stats.
+func (p *Postgres) open(url string) (*gorm.DB, error) {
+	pool, err := p.connect(url)
 	if err != nil {
-		fmt.Errorf("Migrating entities to Postgres - err: %w", err)
-		return err
+		return nil, err
+	}
+	p.pools = append(p.pools, pool)
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: pool}), p.gormConfig())
+	if err != nil {
+		return nil, err
+	}
+	if err := registerTracing(db); err != nil {
+		return nil, fmt.Errorf("register tracing: %w", err)
+	}
+	if err := p.registerReplicas(db); err != nil {
+		return nil, fmt.Errorf("register replicas: %w", err)
+	}
+	if err := p.registerMetrics(); err != nil {
+		return nil, fmt.Errorf("register metrics: %w", err)
+	}
+	return db, nil
+}
+
+// connect opens a pool to url and waits until the database answers, backing off between attempts.
+func (p *Postgres) connect(url string) (*sql.DB, error) {
+	cfg, err := pgx.ParseConfig(url)
+	if err != nil {
+		return nil, fmt.Errorf("parse url: %w", err)
+	}
+	if p.statementTimeout > 0 {
+		cfg.RuntimeParams["statement_timeout"] = strconv.FormatInt(p.statementTimeout.Milliseconds(), 10)
+	}
+
+	pool := stdlib.OpenDB(*cfg)
+	pool.SetMaxOpenConns(p.maxPoolSize)
+	pool.SetMaxIdleConns(p.maxIdleConns)
+	pool.SetConnMaxLifetime(p.connMaxLifetime)
+
+	backoff := _connBackoff
+	for attempts := p.connAttempts; ; attempts-- {
+		ctx, cancel := context.WithTimeout(context.Background(), p.connTimeout)
+		err = pool.PingContext(ctx)
+		cancel()
+		if err == nil {
+			return pool, nil
+		}
+		if attempts <= 1 {
+			pool.Close()
+			return nil, fmt.Errorf("ping: %w", err)
+		}
+
+		if p.l != nil {
+			p.l.With("host", cfg.Host, "attempts_left", attempts-1, "backoff", backoff.String(), "error", err.Error()).
+				Warn("postgres - connect - database is not reachable, retrying")
+		}
+		time.Sleep(backoff)
+		backoff = min(2*backoff, _maxConnBackoff)
+	}
+}
+
+// registerReplicas sends the reads of the replica tables outside transactions to the replicas,
+// or every read when no table is given. Writes and transactions stay on the primary.
+func (p *Postgres) registerReplicas(db *gorm.DB) error {
+	if len(p.replicaURLs) == 0 {
+		return nil
+	}
+
+	replicas := make([]gorm.Dialector, 0, len(p.replicaURLs))
+	for i, url := range p.replicaURLs {
+		pool, err := p.connect(url)
+		if err != nil {
+			return fmt.Errorf("replica %d: %w", i+1, err)
+		}
+		p.pools = append(p.pools, pool)
+		replicas = append(replicas, postgres.New(postgres.Config{Conn: pool}))
+	}
+	return db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	}, p.replicaTables...))
+}
+
+// registerMetrics exports the stats of the pools as go_sql_* metrics, labelled primary or replica_<n>.
+func (p *Postgres) registerMetrics() error {
+	if p.registerer == nil {
+		return nil
+	}
+	for i, pool := range p.pools {
+		name := "primary"
+		if i > 0 {
+			name = "replica_" + strconv.Itoa(i)
+		}
+		c := collectors.NewDBStatsCollector(pool, name)
+		if err := p.registerer.Register(c); err != nil {
+			return err
+		}
+		p.collectors = append(p.collectors, c)
 	}
 	return nil
 }
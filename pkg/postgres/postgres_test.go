@@ -0,0 +1,133 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"tourism-backend/pkg/postgres"
+)
+
+func TestNewGivesUpAfterAttempts(t *testing.T) {
+	t.Parallel()
+
+	// A port nothing listens on anymore.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	registry := prometheus.NewRegistry()
+	var levels []string
+	start := time.Now()
+	_, err = postgres.New("postgres://postgres@"+addr+"/tourism_db?sslmode=disable",
+		postgres.ConnAttempts(2),
+		postgres.ConnTimeout(100*time.Millisecond),
+		postgres.Metrics(registry),
+		postgres.Logger(recordingLogger{&levels}, 0),
+	)
+	require.ErrorContains(t, err, "failed to connect to database")
+	require.Less(t, time.Since(start), 5*time.Second)
+	require.Equal(t, []string{"warn"}, levels, "the retry is logged once")
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Empty(t, families, "no pool stats are left registered")
+}
+
+func TestNewRejectsInvalidURL(t *testing.T) {
+	t.Parallel()
+
+	_, err := postgres.New("postgres://%zz", postgres.ConnAttempts(1))
+	require.ErrorContains(t, err, "parse url")
+}
+
+func TestTransaction(t *testing.T) {
+	t.Parallel()
+
+	type note struct {
+		ID   uint
+		Text string
+	}
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	pool, err := db.DB()
+	require.NoError(t, err)
+	pool.SetMaxOpenConns(1)
+	require.NoError(t, db.AutoMigrate(&note{}))
+	pg := &postgres.Postgres{Conn: db}
+	ctx := context.Background()
+
+	errFailed := errors.New("failed")
+	err = pg.Transaction(ctx, func(ctx context.Context) error {
+		require.NoError(t, pg.DB(ctx).Create(&note{Text: "change"}).Error)
+		require.NoError(t, pg.DB(ctx).Create(&note{Text: "audit"}).Error)
+		return errFailed
+	})
+	require.ErrorIs(t, err, errFailed)
+	var count int64
+	require.NoError(t, pg.DB(ctx).Model(&note{}).Count(&count).Error)
+	require.Zero(t, count, "writes through DB are rolled back together")
+
+	err = pg.Transaction(ctx, func(ctx context.Context) error {
+		require.NoError(t, pg.DB(ctx).Create(&note{Text: "change"}).Error)
+		// A nested transaction rolls back to its savepoint only.
+		err := pg.DB(ctx).Transaction(func(tx *gorm.DB) error {
+			require.NoError(t, tx.Create(&note{Text: "discarded"}).Error)
+			return errFailed
+		})
+		require.ErrorIs(t, err, errFailed)
+		return pg.DB(ctx).Create(&note{Text: "audit"}).Error
+	})
+	require.NoError(t, err)
+	var texts []string
+	require.NoError(t, pg.DB(ctx).Model(&note{}).Order("id").Pluck("text", &texts).Error)
+	require.Equal(t, []string{"change", "audit"}, texts)
+}
+
+func TestAfterCommit(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	pg := &postgres.Postgres{Conn: db}
+	ctx := context.Background()
+	errFailed := errors.New("failed")
+
+	var ran []string
+	pg.AfterCommit(ctx, func() { ran = append(ran, "outside") })
+	require.Equal(t, []string{"outside"}, ran, "runs right away outside a transaction")
+
+	ran = nil
+	err = pg.Transaction(ctx, func(ctx context.Context) error {
+		pg.AfterCommit(ctx, func() { ran = append(ran, "outer") })
+		err := pg.Transaction(ctx, func(ctx context.Context) error {
+			pg.AfterCommit(ctx, func() { ran = append(ran, "discarded") })
+			return errFailed
+		})
+		require.ErrorIs(t, err, errFailed)
+		require.NoError(t, pg.Transaction(ctx, func(ctx context.Context) error {
+			pg.AfterCommit(ctx, func() { ran = append(ran, "nested") })
+			return nil
+		}))
+		require.Empty(t, ran, "nothing runs before the outermost transaction commits")
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"outer", "nested"}, ran)
+
+	ran = nil
+	err = pg.Transaction(ctx, func(ctx context.Context) error {
+		pg.AfterCommit(ctx, func() { ran = append(ran, "rolled back") })
+		return errFailed
+	})
+	require.ErrorIs(t, err, errFailed)
+	require.Empty(t, ran)
+}
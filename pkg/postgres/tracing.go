@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const _tracerName = "tourism-backend/pkg/postgres"
+
+// registerTracing wraps every GORM operation in a client span, a child of the span of the query context.
+// Spans carry the SQL with placeholders, never the values.
+func registerTracing(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("otel:before_create", startSpan("INSERT")),
+		cb.Create().After("gorm:create").Register("otel:after_create", endSpan("INSERT")),
+		cb.Query().Before("gorm:query").Register("otel:before_query", startSpan("SELECT")),
+		cb.Query().After("gorm:query").Register("otel:after_query", endSpan("SELECT")),
+		cb.Update().Before("gorm:update").Register("otel:before_update", startSpan("UPDATE")),
+		cb.Update().After("gorm:update").Register("otel:after_update", endSpan("UPDATE")),
+		cb.Delete().Before("gorm:delete").Register("otel:before_delete", startSpan("DELETE")),
+		cb.Delete().After("gorm:delete").Register("otel:after_delete", endSpan("DELETE")),
+		cb.Row().Before("gorm:row").Register("otel:before_row", startSpan("ROW")),
+		cb.Row().After("gorm:row").Register("otel:after_row", endSpan("ROW")),
+		cb.Raw().Before("gorm:raw").Register("otel:before_raw", startSpan("RAW")),
+		cb.Raw().After("gorm:raw").Register("otel:after_raw", endSpan("RAW")),
+	)
+}
+
+func startSpan(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement.Context == nil {
+			return
+		}
+		ctx, _ := otel.Tracer(_tracerName).Start(db.Statement.Context, operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBOperationName(operation)),
+		)
+		db.Statement.Context = ctx
+	}
+}
+
+func endSpan(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement.Context == nil {
+			return
+		}
+		span := trace.SpanFromContext(db.Statement.Context)
+		if !span.IsRecording() {
+			return
+		}
+		defer span.End()
+
+		if table := db.Statement.Table; table != "" {
+			span.SetName(operation + " " + table)
+			span.SetAttributes(semconv.DBCollectionName(table))
+		}
+		span.SetAttributes(
+			semconv.DBQueryText(db.Statement.SQL.String()),
+			attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+		)
+		if err := db.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,6 +10,10 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/streadway/amqp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 
 	rmqrpc "tourism-backend/pkg/rabbitmq/rmq_rpc"
 )
@@ -83,7 +88,7 @@ func New(url, serverExchange, clientExchange string, opts ...Option) (*Client, e
 	return c, nil
 }
 
-func (c *Client) publish(corrID, handler string, request interface{}) error {
+func (c *Client) publish(ctx context.Context, corrID, handler string, request interface{}) error {
 	var (
 		requestBody []byte
 		err         error
@@ -102,6 +107,7 @@ func (c *Client) publish(corrID, handler string, request interface{}) error {
 			CorrelationId: corrID,
 			ReplyTo:       c.conn.ConsumerExchange,
 			Type:          handler,
+			Headers:       rmqrpc.InjectHeaders(ctx),
 			Body:          requestBody,
 		})
 	if err != nil {
@@ -112,7 +118,33 @@ func (c *Client) publish(corrID, handler string, request interface{}) error {
 }
 
 // RemoteCall -.
-func (c *Client) RemoteCall(handler string, request, response interface{}) error { //nolint:cyclop // complex func
+func (c *Client) RemoteCall(handler string, request, response interface{}) error {
+	return c.RemoteCallContext(context.Background(), handler, request, response)
+}
+
+// RemoteCallContext calls handler within the trace of ctx, the server continues it from the message headers.
+func (c *Client) RemoteCallContext(ctx context.Context, handler string, request, response interface{}) (err error) {
+	ctx, span := otel.Tracer(rmqrpc.TracerName).Start(ctx, handler+" publish",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.MessagingSystemRabbitmq,
+			semconv.MessagingOperationTypePublish,
+			semconv.MessagingDestinationName(c.serverExchange),
+			semconv.MessagingOperationName(handler),
+		),
+	)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	return c.remoteCall(ctx, span, handler, request, response)
+}
+
+func (c *Client) remoteCall(ctx context.Context, span trace.Span, handler string, request, response interface{}) error { //nolint:cyclop // complex func
 	select {
 	case <-c.stop:
 		time.Sleep(c.timeout)
@@ -125,8 +157,9 @@ func (c *Client) RemoteCall(handler string, request, response interface{}) error
 	}
 
 	corrID := uuid.New().String()
+	span.SetAttributes(semconv.MessagingMessageConversationID(corrID))
 
-	err := c.publish(corrID, handler, request)
+	err := c.publish(ctx, corrID, handler, request)
 	if err != nil {
 		return fmt.Errorf("rmq_rpc client - Client - RemoteCall - c.publish: %w", err)
 	}
@@ -223,6 +256,11 @@ func (c *Client) deleteCall(corrID string) {
 	c.rw.Unlock()
 }
 
+// Check returns an error while the connection to RabbitMQ is down, it is a readiness check.
+func (c *Client) Check(context.Context) error {
+	return c.conn.Check()
+}
+
 // Notify -.
 func (c *Client) Notify() <-chan error {
 	return c.error
@@ -1,6 +1,7 @@
 package rmqrpc
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -34,6 +35,14 @@ func New(consumerExchange string, cfg Config) *Connection {
 	return conn
 }
 
+// Check returns an error unless the connection is open.
+func (c *Connection) Check() error {
+	if c.Connection == nil || c.Connection.IsClosed() {
+		return errors.New("rmq_rpc - connection is closed")
+	}
+	return nil
+}
+
 // AttemptConnect -.
 func (c *Connection) AttemptConnect() error {
 	var err error
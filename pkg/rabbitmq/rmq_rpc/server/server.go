@@ -1,11 +1,16 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/streadway/amqp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 
 	"tourism-backend/pkg/logger"
 	rmqrpc "tourism-backend/pkg/rabbitmq/rmq_rpc"
@@ -17,8 +22,8 @@ const (
 	_defaultTimeout  = 2 * time.Second
 )
 
-// CallHandler -.
-type CallHandler func(*amqp.Delivery) (interface{}, error)
+// CallHandler serves a call. ctx continues the trace of the caller.
+type CallHandler func(ctx context.Context, d *amqp.Delivery) (interface{}, error)
 
 // Server -.
 type Server struct {
@@ -84,40 +89,56 @@ func (s *Server) consumer() {
 }
 
 func (s *Server) serveCall(d *amqp.Delivery) {
+	ctx, span := otel.Tracer(rmqrpc.TracerName).Start(rmqrpc.ExtractHeaders(context.Background(), d.Headers), d.Type+" process",
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.MessagingSystemRabbitmq,
+			semconv.MessagingOperationTypeDeliver,
+			semconv.MessagingDestinationName(s.conn.ConsumerExchange),
+			semconv.MessagingOperationName(d.Type),
+			semconv.MessagingMessageConversationID(d.CorrelationId),
+		),
+	)
+	defer span.End()
+
 	callHandler, ok := s.router[d.Type]
 	if !ok {
-		s.publish(d, nil, rmqrpc.ErrBadHandler.Error())
+		span.SetStatus(codes.Error, rmqrpc.ErrBadHandler.Error())
+		s.publish(ctx, d, nil, rmqrpc.ErrBadHandler.Error())
 
 		return
 	}
 
-	response, err := callHandler(d)
+	response, err := callHandler(ctx, d)
 	if err != nil {
-		s.publish(d, nil, rmqrpc.ErrInternalServer.Error())
+		span.RecordError(err)
+		span.SetStatus(codes.Error, rmqrpc.ErrInternalServer.Error())
+		s.publish(ctx, d, nil, rmqrpc.ErrInternalServer.Error())
 
-		s.logger.Error(err, "rmq_rpc server - Server - serveCall - callHandler")
+		s.logger.WithContext(ctx).Error(err, "rmq_rpc server - Server - serveCall - callHandler")
 
 		return
 	}
 
 	body, err := json.Marshal(response)
 	if err != nil {
-		s.logger.Error(err, "rmq_rpc server - Server - serveCall - json.Marshal")
+		s.logger.WithContext(ctx).Error(err, "rmq_rpc server - Server - serveCall - json.Marshal")
 	}
 
-	s.publish(d, body, rmqrpc.Success)
+	s.publish(ctx, d, body, rmqrpc.Success)
 }
 
-func (s *Server) publish(d *amqp.Delivery, body []byte, status string) {
+func (s *Server) publish(ctx context.Context, d *amqp.Delivery, body []byte, status string) {
 	err := s.conn.Channel.Publish(d.ReplyTo, "", false, false,
 		amqp.Publishing{
 			ContentType:   "application/json",
 			CorrelationId: d.CorrelationId,
 			Type:          status,
+			Headers:       rmqrpc.InjectHeaders(ctx),
 			Body:          body,
 		})
 	if err != nil {
-		s.logger.Error(err, "rmq_rpc server - Server - publish - s.conn.Channel.Publish")
+		s.logger.WithContext(ctx).Error(err, "rmq_rpc server - Server - publish - s.conn.Channel.Publish")
 	}
 }
 
@@ -137,6 +158,11 @@ func (s *Server) reconnect() {
 	go s.consumer()
 }
 
+// Check returns an error while the connection to RabbitMQ is down, it is a readiness check.
+func (s *Server) Check(context.Context) error {
+	return s.conn.Check()
+}
+
 // Notify -.
 func (s *Server) Notify() <-chan error {
 	return s.error
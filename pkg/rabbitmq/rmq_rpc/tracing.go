@@ -0,0 +1,52 @@
+package rmqrpc
+
+import (
+	"context"
+
+	"github.com/streadway/amqp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// TracerName names the tracer of the RPC client and server spans.
+const TracerName = "tourism-backend/pkg/rabbitmq/rmq_rpc"
+
+// HeaderCarrier lets the trace context travel in the headers of AMQP messages.
+type HeaderCarrier amqp.Table
+
+var _ propagation.TextMapCarrier = HeaderCarrier(nil)
+
+// Get -.
+func (c HeaderCarrier) Get(key string) string {
+	value, _ := c[key].(string)
+	return value
+}
+
+// Set -.
+func (c HeaderCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+// Keys -.
+func (c HeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// InjectHeaders returns the headers carrying the trace context of ctx.
+func InjectHeaders(ctx context.Context) amqp.Table {
+	headers := amqp.Table{}
+	otel.GetTextMapPropagator().Inject(ctx, HeaderCarrier(headers))
+	return headers
+}
+
+// ExtractHeaders returns a copy of ctx continuing the trace carried by headers.
+func ExtractHeaders(ctx context.Context, headers amqp.Table) context.Context {
+	if headers == nil {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, HeaderCarrier(headers))
+}
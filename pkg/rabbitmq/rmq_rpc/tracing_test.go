@@ -0,0 +1,29 @@
+package rmqrpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	rmqrpc "tourism-backend/pkg/rabbitmq/rmq_rpc"
+	"tourism-backend/pkg/tracing"
+)
+
+func TestHeadersCarryTrace(t *testing.T) {
+	recorder := tracing.NewRecorder()
+	defer recorder.Stop()
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "call")
+	defer span.End()
+
+	headers := rmqrpc.InjectHeaders(ctx)
+	require.Contains(t, headers, "traceparent")
+
+	remote := trace.SpanContextFromContext(rmqrpc.ExtractHeaders(context.Background(), headers))
+	require.True(t, remote.IsRemote())
+	require.Equal(t, span.SpanContext().TraceID(), remote.TraceID())
+	require.Equal(t, span.SpanContext().SpanID(), remote.SpanID())
+}
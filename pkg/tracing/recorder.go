@@ -0,0 +1,36 @@
+package tracing
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Recorder keeps the spans ended while it is installed in memory, for tests.
+// It replaces the global provider, so tests using it must not run in parallel.
+type Recorder struct {
+	*tracetest.InMemoryExporter
+
+	previous   trace.TracerProvider
+	propagator propagation.TextMapPropagator
+}
+
+// NewRecorder installs a provider that records every span and the W3C trace context propagator.
+func NewRecorder() *Recorder {
+	r := &Recorder{
+		InMemoryExporter: tracetest.NewInMemoryExporter(),
+		previous:         otel.GetTracerProvider(),
+		propagator:       otel.GetTextMapPropagator(),
+	}
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(r.InMemoryExporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return r
+}
+
+// Stop restores the provider and propagator installed before the recorder. The recorded spans are kept.
+func (r *Recorder) Stop() {
+	otel.SetTracerProvider(r.previous)
+	otel.SetTextMapPropagator(r.propagator)
+}
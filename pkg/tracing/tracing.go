@@ -0,0 +1,99 @@
+// Package tracing sets up OpenTelemetry tracing. Instrumented packages start their spans with
+// otel.Tracer, so they follow the provider installed here.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// Exporters.
+const (
+	// ExporterNone records no spans. Incoming trace context is still passed on to outgoing calls.
+	ExporterNone = "none"
+	// ExporterStdout writes spans to stdout as JSON, for local debugging.
+	ExporterStdout = "stdout"
+	// ExporterOTLP sends spans to an OpenTelemetry collector over OTLP/HTTP.
+	ExporterOTLP = "otlp"
+)
+
+// Config -.
+type Config struct {
+	Exporter       string
+	ServiceName    string
+	ServiceVersion string
+	// Endpoint is the host:port of the OTLP/HTTP collector.
+	Endpoint string
+	Insecure bool
+	// SampleRatio is the share of new traces that are recorded, traces started by callers keep their decision.
+	SampleRatio float64
+}
+
+// Provider -.
+type Provider struct {
+	tp *sdktrace.TracerProvider
+}
+
+// New installs the global tracer provider for cfg and the W3C trace context and baggage propagators.
+func New(ctx context.Context, cfg Config) (*Provider, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case "", ExporterNone:
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		return &Provider{}, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New()
+	case ExporterOTLP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("tracing - New: unknown exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tracing - New - %s exporter: %w", cfg.Exporter, err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(semconv.ServiceName(cfg.ServiceName), semconv.ServiceVersion(cfg.ServiceVersion)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("tracing - New - resource.New: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+
+	return &Provider{tp: tp}, nil
+}
+
+// Shutdown exports the spans still buffered and stops the provider.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p.tp == nil {
+		return nil
+	}
+	if err := p.tp.Shutdown(ctx); err != nil {
+		return fmt.Errorf("tracing - Shutdown: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package tus
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"tourism-backend/pkg/media"
+
+	"github.com/google/uuid"
+)
+
+// _partsPrefix is the private directory of the media store the chunks of unfinished uploads are kept in.
+const _partsPrefix = media.PrivatePrefix + "uploads"
+
+// ErrChunkTooLarge is returned when a chunk carries more bytes than the upload has left.
+var ErrChunkTooLarge = errors.New("chunk exceeds upload length")
+
+// PartStore keeps the bytes of unfinished uploads in the shared media store, one object per
+// received chunk keyed by its offset, so any instance can resume or complete an upload.
+// A chunk is buffered in a temporary file under dir until its size is known.
+type PartStore struct {
+	store  media.Store
+	lister media.Lister
+	dir    string
+}
+
+// NewPartStore -.
+func NewPartStore(store media.Store, dir string) (*PartStore, error) {
+	lister, ok := store.(media.Lister)
+	if !ok {
+		return nil, errors.New("tus - NewPartStore: store cannot list objects")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("tus - NewPartStore - os.MkdirAll: %w", err)
+	}
+	return &PartStore{store: store, lister: lister, dir: dir}, nil
+}
+
+// Append stores the chunk read from r at offset, reading at most limit bytes.
+// It returns the number of bytes stored, which are kept even when reading r fails,
+// so an interrupted chunk can be resumed from the new offset.
+//
+// With a checksum, the chunk is all or nothing: on any error or a digest mismatch
+// nothing is stored and ErrChecksumMismatch is returned for a mismatch.
+// A chunk stored again at the same offset replaces the previous one.
+func (s *PartStore) Append(ctx context.Context, id uuid.UUID, offset, limit int64, r io.Reader, checksum *Checksum) (int64, error) {
+	buf, err := os.CreateTemp(s.dir, "chunk-*")
+	if err != nil {
+		return 0, fmt.Errorf("create chunk buffer: %w", err)
+	}
+	defer os.Remove(buf.Name())
+	defer buf.Close()
+
+	var w io.Writer = buf
+	var digest interface{ Sum([]byte) []byte }
+	if checksum != nil {
+		h := checksum.New()
+		w = io.MultiWriter(buf, h)
+		digest = h
+	}
+
+	n, err := io.Copy(w, io.LimitReader(r, limit))
+	if err == nil && n == limit {
+		// Anything left over means the client sent more than the upload length.
+		var probe [1]byte
+		if m, _ := r.Read(probe[:]); m > 0 {
+			err = ErrChunkTooLarge
+		}
+	}
+	if err == nil && digest != nil && !bytes.Equal(digest.Sum(nil), checksum.Sum) {
+		err = ErrChecksumMismatch
+	}
+	if err != nil && (checksum != nil || errors.Is(err, ErrChunkTooLarge)) {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, err
+	}
+
+	if _, serr := buf.Seek(0, io.SeekStart); serr != nil {
+		return 0, fmt.Errorf("seek chunk buffer: %w", serr)
+	}
+	if perr := s.store.Put(ctx, s.key(id, offset), buf, n, "application/octet-stream"); perr != nil {
+		return 0, fmt.Errorf("store chunk: %w", perr)
+	}
+	return n, err
+}
+
+// Open returns the first length bytes of the upload, read from its chunks in order.
+func (s *PartStore) Open(ctx context.Context, id uuid.UUID, length int64) (io.ReadCloser, error) {
+	sizes := make(map[int64]int64)
+	err := s.lister.List(ctx, s.prefix(id), func(object media.Object) error {
+		if offset, err := strconv.ParseInt(path.Base(object.Key), 10, 64); err == nil {
+			sizes[offset] = object.Size
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list upload chunks: %w", err)
+	}
+
+	// Chunks past the recorded offset, left by a write that was not recorded, are not on the chain.
+	var keys []string
+	for offset := int64(0); offset < length; {
+		size, ok := sizes[offset]
+		if !ok || size == 0 {
+			return nil, fmt.Errorf("open upload: chunk at offset %d is missing", offset)
+		}
+		keys = append(keys, s.key(id, offset))
+		offset += size
+	}
+	return &chunkReader{ctx: ctx, store: s.store, keys: keys}, nil
+}
+
+// Remove deletes every chunk of the upload. A missing upload is not an error.
+func (s *PartStore) Remove(ctx context.Context, id uuid.UUID) error {
+	var keys []string
+	err := s.lister.List(ctx, s.prefix(id), func(object media.Object) error {
+		keys = append(keys, object.Key)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("list upload chunks: %w", err)
+	}
+	for _, key := range keys {
+		if err := s.store.Delete(ctx, key); err != nil {
+			return fmt.Errorf("delete upload chunk: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *PartStore) prefix(id uuid.UUID) string {
+	return path.Join(_partsPrefix, id.String()) + "/"
+}
+
+// key zero pads the offset, so the chunks of an upload are listed in order.
+func (s *PartStore) key(id uuid.UUID, offset int64) string {
+	return fmt.Sprintf("%s%020d", s.prefix(id), offset)
+}
+
+// chunkReader reads the chunks one after another, opening each only when it is reached.
+type chunkReader struct {
+	ctx     context.Context
+	store   media.Store
+	keys    []string
+	current io.ReadCloser
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.keys) == 0 {
+				return 0, io.EOF
+			}
+			current, err := r.store.Get(r.ctx, r.keys[0])
+			if err != nil {
+				return 0, fmt.Errorf("open upload chunk: %w", err)
+			}
+			r.current, r.keys = current, r.keys[1:]
+		}
+		n, err := r.current.Read(p)
+		if errors.Is(err, io.EOF) {
+			r.current.Close()
+			r.current = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+func (r *chunkReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+	err := r.current.Close()
+	r.current = nil
+	return err
+}
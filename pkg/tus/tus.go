@@ -0,0 +1,123 @@
+// Package tus implements the server side pieces of the tus resumable upload protocol 1.0.0
+// (https://tus.io/protocols/resumable-upload): header parsing, chunk checksums and chunk storage.
+package tus
+
+import (
+	"crypto/md5"  //nolint:gosec // md5 is one of the checksum algorithms clients may choose.
+	"crypto/sha1" //nolint:gosec // sha1 is the checksum algorithm every tus server must support.
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"sort"
+	"strings"
+)
+
+// Protocol constants.
+const (
+	Version = "1.0.0"
+	// Extensions are the protocol extensions implemented by the server.
+	Extensions = "creation,expiration,checksum,termination"
+	// ContentType is the required content type of PATCH requests.
+	ContentType = "application/offset+octet-stream"
+	// StatusChecksumMismatch is returned when a chunk does not match its Upload-Checksum.
+	StatusChecksumMismatch = 460
+)
+
+// Protocol headers.
+const (
+	HeaderResumable         = "Tus-Resumable"
+	HeaderVersion           = "Tus-Version"
+	HeaderExtension         = "Tus-Extension"
+	HeaderMaxSize           = "Tus-Max-Size"
+	HeaderChecksumAlgorithm = "Tus-Checksum-Algorithm"
+	HeaderUploadOffset      = "Upload-Offset"
+	HeaderUploadLength      = "Upload-Length"
+	HeaderUploadMetadata    = "Upload-Metadata"
+	HeaderUploadExpires     = "Upload-Expires"
+	HeaderUploadChecksum    = "Upload-Checksum"
+)
+
+var (
+	// ErrInvalidMetadata is returned for a malformed Upload-Metadata header.
+	ErrInvalidMetadata = errors.New("invalid Upload-Metadata header")
+	// ErrInvalidChecksum is returned for a malformed Upload-Checksum header.
+	ErrInvalidChecksum = errors.New("invalid Upload-Checksum header")
+	// ErrUnsupportedChecksum is returned for a checksum algorithm the server does not implement.
+	ErrUnsupportedChecksum = errors.New("unsupported checksum algorithm")
+	// ErrChecksumMismatch is returned when the received chunk does not match its checksum.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+)
+
+var algorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+}
+
+// ChecksumAlgorithms returns the supported algorithms for the Tus-Checksum-Algorithm header.
+func ChecksumAlgorithms() string {
+	names := make([]string, 0, len(algorithms))
+	for name := range algorithms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// ParseMetadata decodes an Upload-Metadata header: comma separated pairs of a key
+// and an optional base64 encoded value.
+func ParseMetadata(header string) (map[string]string, error) {
+	metadata := map[string]string{}
+	if strings.TrimSpace(header) == "" {
+		return metadata, nil
+	}
+	for _, pair := range strings.Split(header, ",") {
+		fields := strings.Fields(pair)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, ErrInvalidMetadata
+		}
+		if _, ok := metadata[fields[0]]; ok {
+			return nil, fmt.Errorf("%w: duplicate key %s", ErrInvalidMetadata, fields[0])
+		}
+		value := ""
+		if len(fields) == 2 {
+			decoded, err := base64.StdEncoding.DecodeString(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s is not base64", ErrInvalidMetadata, fields[0])
+			}
+			value = string(decoded)
+		}
+		metadata[fields[0]] = value
+	}
+	return metadata, nil
+}
+
+// Checksum is the expected digest of one chunk.
+type Checksum struct {
+	Algorithm string
+	Sum       []byte
+}
+
+// ParseChecksum decodes an Upload-Checksum header of the form "<algorithm> <base64 digest>".
+func ParseChecksum(header string) (*Checksum, error) {
+	fields := strings.Fields(header)
+	if len(fields) != 2 {
+		return nil, ErrInvalidChecksum
+	}
+	algorithm := strings.ToLower(fields[0])
+	if _, ok := algorithms[algorithm]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedChecksum, fields[0])
+	}
+	sum, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return nil, ErrInvalidChecksum
+	}
+	return &Checksum{Algorithm: algorithm, Sum: sum}, nil
+}
+
+// New returns a hash computing the digest of the checksum algorithm.
+func (c *Checksum) New() hash.Hash {
+	return algorithms[c.Algorithm]()
+}
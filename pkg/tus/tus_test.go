@@ -0,0 +1,152 @@
+package tus_test
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // tus checksum algorithm.
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"tourism-backend/pkg/media"
+	"tourism-backend/pkg/tus"
+)
+
+func b64(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+func sha1Checksum(t *testing.T, s string) *tus.Checksum {
+	t.Helper()
+	sum := sha1.Sum([]byte(s)) //nolint:gosec // tus checksum algorithm.
+	checksum, err := tus.ParseChecksum("sha1 " + base64.StdEncoding.EncodeToString(sum[:]))
+	require.NoError(t, err)
+	return checksum
+}
+
+func TestParseMetadata(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "empty", header: "", want: map[string]string{}},
+		{
+			name:   "pairs",
+			header: "filename " + b64("walk.mp4") + ", tour_id " + b64("42") + ",is_draft",
+			want:   map[string]string{"filename": "walk.mp4", "tour_id": "42", "is_draft": ""},
+		},
+		{name: "not base64", header: "filename walk.mp4", wantErr: true},
+		{name: "duplicate key", header: "a " + b64("1") + ",a " + b64("2"), wantErr: true},
+		{name: "too many fields", header: "a b c", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tus.ParseMetadata(tt.header)
+			if tt.wantErr {
+				require.ErrorIs(t, err, tus.ErrInvalidMetadata)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseChecksum(t *testing.T) {
+	checksum, err := tus.ParseChecksum("SHA256 " + b64("digest"))
+	require.NoError(t, err)
+	require.Equal(t, "sha256", checksum.Algorithm)
+	require.Equal(t, []byte("digest"), checksum.Sum)
+
+	_, err = tus.ParseChecksum("crc32 " + b64("x"))
+	require.ErrorIs(t, err, tus.ErrUnsupportedChecksum)
+	_, err = tus.ParseChecksum("sha1")
+	require.ErrorIs(t, err, tus.ErrInvalidChecksum)
+	_, err = tus.ParseChecksum("sha1 !!!")
+	require.ErrorIs(t, err, tus.ErrInvalidChecksum)
+
+	require.Equal(t, "md5,sha1,sha256", tus.ChecksumAlgorithms())
+}
+
+// failingReader returns its data and then a connection error.
+type failingReader struct {
+	data string
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.data == "" {
+		return 0, errors.New("connection reset")
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func readPart(t *testing.T, store *tus.PartStore, id uuid.UUID, length int64) string {
+	t.Helper()
+	r, err := store.Open(context.Background(), id, length)
+	require.NoError(t, err)
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestPartStore(t *testing.T) {
+	ctx := context.Background()
+	objects := media.NewLocalStore(t.TempDir(), "/uploads")
+	store, err := tus.NewPartStore(objects, t.TempDir())
+	require.NoError(t, err)
+	id := uuid.New()
+
+	n, err := store.Append(ctx, id, 0, 10, strings.NewReader("hello"), sha1Checksum(t, "hello"))
+	require.NoError(t, err)
+	require.Equal(t, int64(5), n)
+
+	// A chunk with a wrong checksum is discarded.
+	_, err = store.Append(ctx, id, 5, 5, strings.NewReader("world"), sha1Checksum(t, "word!"))
+	require.ErrorIs(t, err, tus.ErrChecksumMismatch)
+	require.Equal(t, "hello", readPart(t, store, id, 5))
+
+	// Without a checksum, the bytes received before a connection drop are kept.
+	n, err = store.Append(ctx, id, 5, 5, &failingReader{data: "wo"}, nil)
+	require.Error(t, err)
+	require.Equal(t, int64(2), n)
+	require.Equal(t, "hellowo", readPart(t, store, id, 7))
+
+	// More bytes than the upload has left are rejected.
+	_, err = store.Append(ctx, id, 7, 3, strings.NewReader("rld!"), nil)
+	require.ErrorIs(t, err, tus.ErrChunkTooLarge)
+	_, err = store.Open(ctx, id, 10)
+	require.Error(t, err)
+
+	// A chunk whose offset was never recorded is replaced by the retry.
+	_, err = store.Append(ctx, id, 7, 3, strings.NewReader("xyz"), nil)
+	require.NoError(t, err)
+	n, err = store.Append(ctx, id, 7, 3, strings.NewReader("rld"), nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), n)
+	require.Equal(t, "helloworld", readPart(t, store, id, 10))
+
+	// Another store over the same objects, as on another instance, reads the same upload.
+	other, err := tus.NewPartStore(objects, t.TempDir())
+	require.NoError(t, err)
+	require.Equal(t, "helloworld", readPart(t, other, id, 10))
+
+	require.NoError(t, store.Remove(ctx, id))
+	require.NoError(t, store.Remove(ctx, id))
+	_, err = store.Open(ctx, id, 10)
+	require.Error(t, err)
+	var left []string
+	require.NoError(t, objects.List(ctx, media.PrivatePrefix, func(object media.Object) error {
+		left = append(left, object.Key)
+		return nil
+	}))
+	require.Empty(t, left)
+}
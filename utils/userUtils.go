@@ -3,21 +3,41 @@ package utils
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"net/http"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/logger"
 )
 
 func GetUserIDFromContext(c *gin.Context) uuid.UUID {
 	userIDStr, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		_ = c.Error(ErrTokenRequired)
+		c.Abort()
 		return uuid.Nil
 	}
 
 	// Convert user_id string to UUID
 	userID, err := uuid.Parse(userIDStr.(string))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID format"})
+		_ = c.Error(ErrInvalidToken)
+		c.Abort()
 		return uuid.Nil
 	}
 	return userID
 }
+
+// GetAuditActor collects the caller identity, IP and request ID for audit records.
+func GetAuditActor(c *gin.Context) entity.AuditActor {
+	actor := entity.AuditActor{
+		IP:        c.ClientIP(),
+		RequestID: logger.RequestID(c.Request.Context()),
+	}
+	if userIDStr, ok := c.Get("userID"); ok {
+		if userID, err := uuid.Parse(userIDStr.(string)); err == nil {
+			actor.UserID = userID
+		}
+	}
+	if role, ok := c.Get("role"); ok {
+		actor.Role = role.(string)
+	}
+	return actor
+}
@@ -1,20 +1,32 @@
 package utils
 
 import (
+	"fmt"
 	"github.com/casbin/casbin/v2"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
-	"net/http"
 	"os"
 	"strconv"
 	"strings"
 	"time"
+	"tourism-backend/internal/entity"
+	"tourism-backend/pkg/logger"
 )
 
 var jwtSecret = []byte(os.Getenv("JWT_SECRET"))
 
+// The middlewares attach these errors to the context, the router renders them as problems.
+var (
+	// ErrTokenRequired -.
+	ErrTokenRequired = entity.NewUnauthorizedError("token_required", "token required")
+	// ErrInvalidToken is returned for malformed, expired or incomplete tokens.
+	ErrInvalidToken = entity.NewUnauthorizedError("invalid_token", "invalid token")
+	// ErrForbidden is returned when the role may not call the route.
+	ErrForbidden = entity.NewForbiddenError("forbidden", "forbidden")
+)
+
 func HashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	return string(bytes), err
@@ -39,7 +51,8 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tokenStr := c.GetHeader("Authorization")
 		if tokenStr == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token required"})
+			_ = c.Error(ErrTokenRequired)
+			c.Abort()
 			return
 		}
 
@@ -50,7 +63,8 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 		})
 
 		if err != nil || !token.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			_ = c.Error(ErrInvalidToken)
+			c.Abort()
 			return
 		}
 
@@ -58,6 +72,7 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 
 		c.Set("userID", claims["user_id"].(string))
 		c.Set("role", claims["role"].(string))
+		c.Request = c.Request.WithContext(logger.WithUserID(c.Request.Context(), claims["user_id"].(string)))
 		c.Next()
 	}
 }
@@ -67,7 +82,7 @@ func CasbinMiddleware(e *casbin.Enforcer) gin.HandlerFunc {
 		// Get user role from context
 		role, exists := c.Get("role")
 		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Role not found"})
+			_ = c.Error(ErrInvalidToken)
 			c.Abort()
 			return
 		}
@@ -78,13 +93,13 @@ func CasbinMiddleware(e *casbin.Enforcer) gin.HandlerFunc {
 
 		allowed, err := e.Enforce(role, path, method)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error checking permission"})
+			_ = c.Error(fmt.Errorf("check permission: %w", err))
 			c.Abort()
 			return
 		}
 
 		if !allowed {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+			_ = c.Error(ErrForbidden)
 			c.Abort()
 			return
 		}